@@ -0,0 +1,37 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+// addBaselineFlags adds the flags to leave known failures out of the results.
+func addBaselineFlags(cmd *cobra.Command) {
+	cmd.Flags().String("baseline", "", "baseline file of known failures, only new failures are reported and gated on")
+	cmd.Flags().Bool("write-baseline", false, "write the failures of this run to the --baseline file, keeping the justification and expiry of known ones")
+}
+
+// baselineFlags returns the baseline file and whether to write it.
+func baselineFlags(cmd *cobra.Command) (string, bool, error) {
+	path, _ := cmd.Flags().GetString("baseline")
+	write, _ := cmd.Flags().GetBool("write-baseline")
+	if write && path == "" {
+		return "", false, errors.New("--write-baseline requires --baseline <file>")
+	}
+	return path, write, nil
+}
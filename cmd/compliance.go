@@ -29,7 +29,7 @@ var complianceCmd = &cobra.Command{
 Check if our SBOM meets compliance requirements for various standards, such as NTIA minimum elements, 
 BSI TR-03183-2, Framing Software Component Transparency (v3) and OpenChain Telco.
 	`,
-	Example: ` sbomqs compliance  < --ntia | --bsi | --bsi-v2 | --fsct | --oct >  [--basic | --json]   <SBOM file>
+	Example: ` sbomqs compliance  < --ntia | --bsi | --bsi-v2 | --fsct | --oct >  [--basic | --json | --sarif | --junit | --html]   <SBOM file>
 
   # Check a NTIA minimum elements compliance against a SBOM in a table output
   sbomqs compliance --ntia samples/sbomqs-spdx-syft.json
@@ -43,16 +43,32 @@ BSI TR-03183-2, Framing Software Component Transparency (v3) and OpenChain Telco
    # Check a Framing Software Component Transparency (v3) compliance against a SBOM in a table output
   sbomqs compliance --fsct samples/sbomqs-spdx-syft.json
 
+  # Check a NTIA compliance against a SBOM read from stdin
+  cat samples/sbomqs-spdx-syft.json | sbomqs compliance --ntia -
+
   # Check a OpenChain Telco compliance against a SBOM in a JSON output
   sbomqs compliance --oct --json samples/sbomqs-spdx-syft.json
 
    # Check a Framing Software Component Transparency (v3) compliance against a SBOM in a table colorful output
   sbomqs compliance --fsct --color samples/sbomqs-spdx-syft.json
 
+  # Report the failed BSI v2.0.0 checks in SARIF, for code scanning dashboards
+  sbomqs compliance --bsi-v2 --sarif samples/sbomqs-spdx-syft.json > bsi.sarif
+
+  # Report the NTIA checks as JUnit XML test cases, for CI test dashboards
+  sbomqs compliance --ntia --junit samples/sbomqs-spdx-syft.json > ntia.xml
+
+  # Check a BSI TR-03183-2 v2.0.0 compliance against a SBOM as a self-contained HTML page
+  sbomqs compliance --bsi-v2 --html samples/sbomqs-spdx-syft.json > bsi.html
+
+  # Record the components failing NTIA today, then only report new failures
+  sbomqs compliance --ntia --baseline baseline.json --write-baseline samples/sbomqs-spdx-syft.json
+  sbomqs compliance --ntia --baseline baseline.json samples/sbomqs-spdx-syft.json
+
 `,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
-			return fmt.Errorf("compliance requires a single argument, the path to an SBOM file")
+			return fmt.Errorf("compliance requires a single argument, the path to an SBOM file; run it once per SBOM")
 		}
 
 		return nil
@@ -68,6 +84,13 @@ BSI TR-03183-2, Framing Software Component Transparency (v3) and OpenChain Telco
 		ctx := logger.WithLogger(context.Background())
 
 		engParams := setupEngineParams(cmd, args)
+
+		var err error
+		engParams.BaselinePath, engParams.WriteBaseline, err = baselineFlags(cmd)
+		if err != nil {
+			return err
+		}
+
 		return engine.ComplianceRun(ctx, engParams)
 	},
 }
@@ -78,7 +101,11 @@ func setupEngineParams(cmd *cobra.Command, args []string) *engine.Params {
 	engParams.Basic, _ = cmd.Flags().GetBool("basic")
 	engParams.Detailed, _ = cmd.Flags().GetBool("detailed")
 	engParams.JSON, _ = cmd.Flags().GetBool("json")
+	engParams.SARIF, _ = cmd.Flags().GetBool("sarif")
+	engParams.JUnit, _ = cmd.Flags().GetBool("junit")
+	engParams.HTML, _ = cmd.Flags().GetBool("html")
 	engParams.Color, _ = cmd.Flags().GetBool("color")
+	engParams.ShowDiagnostics, _ = cmd.Flags().GetBool("show-diagnostics")
 
 	engParams.Ntia, _ = cmd.Flags().GetBool("ntia")
 	engParams.Bsi, _ = cmd.Flags().GetBool("bsi")
@@ -106,11 +133,15 @@ func init() {
 	// Output control
 	complianceCmd.Flags().BoolP("json", "j", false, "output in json format")
 	complianceCmd.Flags().BoolP("basic", "b", false, "output in basic format")
+	complianceCmd.Flags().Bool("sarif", false, "output failed checks in SARIF format")
+	complianceCmd.Flags().Bool("junit", false, "output checks as JUnit XML test cases")
+	complianceCmd.Flags().Bool("html", false, "output as a self-contained HTML page")
 	complianceCmd.Flags().BoolP("detailed", "d", false, "output in detailed format(default)")
 	complianceCmd.Flags().BoolP("color", "l", false, "output in colorful")
+	complianceCmd.Flags().Bool("show-diagnostics", false, "print malformed and missing elements found while parsing to stderr")
 
 	// complianceCmd.Flags().BoolP("pdf", "p", false, "output in pdf format")
-	complianceCmd.MarkFlagsMutuallyExclusive("json", "basic", "detailed")
+	complianceCmd.MarkFlagsMutuallyExclusive("json", "basic", "detailed", "sarif", "junit", "html")
 
 	// Standards control
 	complianceCmd.Flags().BoolP("ntia", "n", false, "NTIA minimum elements (July 12, 2021)")
@@ -121,4 +152,7 @@ func init() {
 
 	complianceCmd.Flags().StringP("sig", "v", "", "signature of sbom")
 	complianceCmd.Flags().StringP("pub", "p", "", "public key of sbom")
+
+	// Baseline control
+	addBaselineFlags(complianceCmd)
 }
@@ -0,0 +1,155 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/engine"
+	"github.com/interlynk-io/sbomqs/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// userDiffCmd holds the configuration for the diff command
+type userDiffCmd struct {
+	// Input control
+	oldPath string
+	newPath string
+
+	// Filter control
+	categories []string
+	features   []string
+	configPath string
+
+	// Output control
+	json     bool
+	markdown bool
+
+	strict bool
+
+	// Exit control
+	failOnRegression bool
+
+	// Debug control
+	debug bool
+}
+
+// diffCmd compares the quality of two SBOMs
+var diffCmd = &cobra.Command{
+	Use:          "diff <old SBOM> <new SBOM>",
+	Short:        "Compare the quality of two SBOMs",
+	SilenceUsage: true,
+	Example: `  sbomqs diff [--category <category>] [--feature <feature>] [--json|--markdown] <old SBOM> <new SBOM>
+
+  # Compare the last release's SBOM with the new one
+  sbomqs diff release-1.0.json release-1.1.json
+
+  # Compare only the NTIA minimum elements, as markdown for a PR comment
+  sbomqs diff --category ntia --markdown release-1.0.json release-1.1.json
+
+  # Fail with exit code 4 when the new SBOM is worse than the old one
+  sbomqs diff --fail-on-regression release-1.0.json release-1.1.json
+`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if debug, _ := cmd.Flags().GetBool("debug"); debug {
+			logger.InitDebugLogger()
+		} else {
+			logger.InitProdLogger()
+		}
+
+		ctx := logger.WithLogger(context.Background())
+		uCmd := parseDiffParams(cmd, args)
+
+		if uCmd.configPath != "" {
+			if err := validatePath(uCmd.configPath); err != nil {
+				return err
+			}
+		}
+
+		return engine.DiffRun(ctx, fromDiffToEngineParams(uCmd))
+	},
+}
+
+func parseDiffParams(cmd *cobra.Command, args []string) *userDiffCmd {
+	uCmd := &userDiffCmd{}
+
+	// Input control
+	uCmd.oldPath = args[0]
+	uCmd.newPath = args[1]
+
+	// Filter control
+	c, _ := cmd.Flags().GetString("category")
+	for _, val := range strings.Split(c, ",") {
+		if fullName, ok := categoryAliases[val]; ok {
+			val = fullName
+		}
+		uCmd.categories = append(uCmd.categories, val)
+	}
+	f, _ := cmd.Flags().GetString("feature")
+	uCmd.features = strings.Split(f, ",")
+	uCmd.configPath, _ = cmd.Flags().GetString("configpath")
+
+	// Output control
+	uCmd.json, _ = cmd.Flags().GetBool("json")
+	uCmd.markdown, _ = cmd.Flags().GetBool("markdown")
+	uCmd.strict, _ = cmd.Flags().GetBool("strict")
+
+	// Exit control
+	uCmd.failOnRegression, _ = cmd.Flags().GetBool("fail-on-regression")
+
+	// Debug control
+	uCmd.debug, _ = cmd.Flags().GetBool("debug")
+
+	return uCmd
+}
+
+func fromDiffToEngineParams(uCmd *userDiffCmd) *engine.Params {
+	return &engine.Params{
+		Path:       []string{uCmd.oldPath, uCmd.newPath},
+		Categories: removeEmptyStrings(uCmd.categories),
+		Features:   removeEmptyStrings(uCmd.features),
+		ConfigPath: uCmd.configPath,
+		JSON:       uCmd.json,
+		Markdown:   uCmd.markdown,
+		Strict:     uCmd.strict,
+		Debug:      uCmd.debug,
+
+		FailOnRegression: uCmd.failOnRegression,
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	// Filter Control
+	diffCmd.Flags().StringP("configpath", "", "", "scoring based on config path")
+	diffCmd.Flags().StringP("category", "c", "", "filter by category (e.g. 'ntia', 'Quality', 'Semantic', 'Sharing', 'Structural')")
+	diffCmd.Flags().StringP("feature", "f", "", "filter by feature (e.g. 'sbom_authors', 'comp_with_supplier')")
+
+	// Output Control
+	diffCmd.Flags().BoolP("json", "j", false, "results in json")
+	diffCmd.Flags().BoolP("markdown", "m", false, "results in markdown")
+	diffCmd.Flags().BoolP("detailed", "d", true, "results in table format, default")
+	diffCmd.MarkFlagsMutuallyExclusive("json", "markdown")
+	diffCmd.Flags().Bool("strict", false, "validate JSON SBOMs against the schema of their spec version")
+
+	// Exit Control
+	diffCmd.Flags().Bool("fail-on-regression", false, "exit with code 4 if the new SBOM scores lower or a component lost a feature")
+
+	// Debug Control
+	diffCmd.Flags().BoolP("debug", "D", false, "enable debug logging")
+}
@@ -84,6 +84,7 @@ func extractArgs(cmd *cobra.Command, args []string) (*engine.DtParams, error) {
 	}
 
 	params.Timeout, _ = cmd.Flags().GetInt("timeout")
+	params.ConfigPath, _ = cmd.Flags().GetString("configpath")
 
 	return params, nil
 }
@@ -111,4 +112,5 @@ func init() {
 
 	dtrackScoreCmd.Flags().BoolP("tag-project-with-score", "t", false, "tag project with sbomqs score")
 	dtrackScoreCmd.Flags().IntP("timeout", "i", 60, "Timeout in seconds for Dependency-Track API requests")
+	dtrackScoreCmd.Flags().StringP("configpath", "", "", "scoring based on config path")
 }
@@ -0,0 +1,80 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/engine"
+	"github.com/interlynk-io/sbomqs/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd reports the scores recorded by 'sbomqs score --record'
+var historyCmd = &cobra.Command{
+	Use:          "history [product]",
+	Short:        "Show the recorded scores of a product over time",
+	SilenceUsage: true,
+	Example: `  sbomqs history [--last <n>] [--feature <feature>] [--json] [product]
+
+  # List the products with recorded scores
+  sbomqs history
+
+  # Show the last 10 runs of a product and the features whose score changed
+  sbomqs history sbomqs
+
+  # Show the trend of the supplier and license features over the last 5 runs
+  sbomqs history --last 5 --feature comp_with_supplier,comp_valid_licenses sbomqs
+
+  # Use a history store shared by the CI jobs
+  sbomqs history --history /var/lib/sbomqs/history.jsonl sbomqs
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if debug, _ := cmd.Flags().GetBool("debug"); debug {
+			logger.InitDebugLogger()
+		} else {
+			logger.InitProdLogger()
+		}
+
+		ctx := logger.WithLogger(context.Background())
+
+		historyPath, _ := cmd.Flags().GetString("history")
+		last, _ := cmd.Flags().GetInt("last")
+		features, _ := cmd.Flags().GetString("feature")
+		json, _ := cmd.Flags().GetBool("json")
+		debug, _ := cmd.Flags().GetBool("debug")
+
+		return engine.HistoryRun(ctx, &engine.Params{
+			Path:        args,
+			Features:    removeEmptyStrings(strings.Split(features, ",")),
+			JSON:        json,
+			Debug:       debug,
+			HistoryPath: historyPath,
+			Last:        last,
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().String("history", "", "history store to read (default: history.jsonl in the user's cache directory)")
+	historyCmd.Flags().IntP("last", "n", 10, "number of runs to show, 0 for all")
+	historyCmd.Flags().StringP("feature", "f", "", "show the trend of these features, by default the features whose score changed")
+	historyCmd.Flags().BoolP("json", "j", false, "results in json")
+	historyCmd.Flags().BoolP("debug", "D", false, "enable debug logging")
+}
@@ -29,7 +29,10 @@ import (
 // userListCmd holds the configuration for the list command
 type userListCmd struct {
 	// Input control
-	path string
+	path    string
+	recurse bool
+	include []string
+	exclude []string
 
 	// Filter control
 	feature string
@@ -39,9 +42,17 @@ type userListCmd struct {
 	basic    bool
 	json     bool
 	detailed bool
+	sarif    bool
 	color    bool
 	show     bool
 
+	showDiagnostics bool
+	strict          bool
+
+	// Baseline control
+	baselinePath  string
+	writeBaseline bool
+
 	// Debug control
 	debug bool
 }
@@ -59,22 +70,30 @@ var listCmd = &cobra.Command{
   # List all components missing suppliers
   sbomqs list --feature comp_with_supplier --missing samples/sbomqs-spdx-syft.json
 
+  # List all components with suppliers from an SBOM read from stdin
+  cat samples/sbomqs-spdx-syft.json | sbomqs list --feature comp_with_supplier -
+
   # List all components with valid licenses
   sbomqs list --feature comp_valid_licenses samples/sbomqs-spdx-syft.json
 
   # List all components with invalid licenses
   sbomqs list --feature comp_valid_licenses --missing samples/sbomqs-spdx-syft.json
 
+  # Report the components without suppliers in SARIF, for code scanning dashboards
+  sbomqs list --feature comp_with_supplier --sarif samples/sbomqs-spdx-syft.json > suppliers.sarif
+
   # Component features: 
   [comp_with_name, comp_with_version, comp_with_supplier, comp_with_uniq_ids, comp_valid_licenses, comp_with_any_vuln_lookup_id, 
   comp_with_deprecated_licenses, comp_with_multi_vuln_lookup_id, comp_with_primary_purpose, comp_with_restrictive_licenses, 
   comp_with_checksums, comp_with_licenses, comp_with_checksums_sha256, comp_with_source_code_uri, comp_with_source_code_hash, 
-  comp_with_executable_uri, comp_with_associated_license, comp_with_concluded_license, comp_with_declared_license]
+  comp_with_executable_uri, comp_with_associated_license, comp_with_concluded_license, comp_with_declared_license,
+  comp_with_dangling_dependencies, comp_reachable, comp_in_dependency_cycle, comp_with_complete_dependencies,
+  comp_with_dependency_composition, comp_dependency_completeness]
   
   # SBOM features:
   [sbom_creation_timestamp, sbom_authors, sbom_with_creator_and_version, sbom_with_primary_component, sbom_dependencies, 
   sbom_sharable, sbom_parsable, sbom_spec, sbom_file_format, sbom_spec_version, spec_with_version_compliant, sbom_with_uri,
-  sbom_with_vuln, sbom_build_process, sbom_with_bomlinks]
+  sbom_with_vuln, sbom_build_process, sbom_with_bomlinks, sbom_dependency_depth]
 `,
 
 	Args: func(_ *cobra.Command, args []string) error {
@@ -100,6 +119,12 @@ var listCmd = &cobra.Command{
 			return err
 		}
 
+		var err error
+		uCmd.baselinePath, uCmd.writeBaseline, err = baselineFlags(cmd)
+		if err != nil {
+			return err
+		}
+
 		engParams := fromListToEngineParams(uCmd)
 		logger.FromContext(ctx).Debugf("Parsed command: %s", cmd.CommandPath())
 		logger.FromContext(ctx).Debugf("Parsed user command: %+v", uCmd)
@@ -112,6 +137,9 @@ func parseListParams(cmd *cobra.Command, args []string) *userListCmd {
 
 	// Input control
 	uCmd.path = args[0]
+	uCmd.recurse, _ = cmd.Flags().GetBool("recurse")
+	uCmd.include, _ = cmd.Flags().GetStringSlice("include")
+	uCmd.exclude, _ = cmd.Flags().GetStringSlice("exclude")
 
 	// Filter control
 	feature, _ := cmd.Flags().GetString("feature")
@@ -130,12 +158,21 @@ func parseListParams(cmd *cobra.Command, args []string) *userListCmd {
 	detailed, _ := cmd.Flags().GetBool("detailed")
 	uCmd.detailed = detailed
 
+	sarif, _ := cmd.Flags().GetBool("sarif")
+	uCmd.sarif = sarif
+
 	color, _ := cmd.Flags().GetBool("color")
 	uCmd.color = color
 
 	show, _ := cmd.Flags().GetBool("show")
 	uCmd.show = show
 
+	showDiagnostics, _ := cmd.Flags().GetBool("show-diagnostics")
+	uCmd.showDiagnostics = showDiagnostics
+
+	strict, _ := cmd.Flags().GetBool("strict")
+	uCmd.strict = strict
+
 	// Debug control
 	debug, _ := cmd.Flags().GetBool("debug")
 	uCmd.debug = debug
@@ -146,14 +183,24 @@ func parseListParams(cmd *cobra.Command, args []string) *userListCmd {
 func fromListToEngineParams(uCmd *userListCmd) *engine.Params {
 	return &engine.Params{
 		Path:     []string{uCmd.path},
+		Recurse:  uCmd.recurse,
+		Include:  uCmd.include,
+		Exclude:  uCmd.exclude,
 		Features: []string{uCmd.feature},
 		Missing:  uCmd.missing,
 		Basic:    uCmd.basic,
 		JSON:     uCmd.json,
 		Detailed: uCmd.detailed,
+		SARIF:    uCmd.sarif,
 		Color:    uCmd.color,
 		Debug:    uCmd.debug,
 		Show:     uCmd.show,
+
+		ShowDiagnostics: uCmd.showDiagnostics,
+		Strict:          uCmd.strict,
+
+		BaselinePath:  uCmd.baselinePath,
+		WriteBaseline: uCmd.writeBaseline,
 	}
 }
 
@@ -168,12 +215,23 @@ func init() {
 	}
 	listCmd.Flags().BoolP("missing", "m", false, "List components or properties missing the specified feature")
 
+	// Directory Control
+	listCmd.Flags().BoolP("recurse", "r", false, "Recurse into subdirectories, following symlinks")
+	listCmd.Flags().StringSlice("include", nil, "Only list files matching these glob patterns")
+	listCmd.Flags().StringSlice("exclude", nil, "Skip files and directories matching these glob patterns")
+
 	// Output Control
 	listCmd.Flags().BoolP("basic", "b", false, "Results in single-line format")
 	listCmd.Flags().BoolP("json", "j", false, "Results in JSON")
 	listCmd.Flags().BoolP("detailed", "d", true, "Results in table format, default")
+	listCmd.Flags().Bool("sarif", false, "Results in SARIF, the components failing the feature are reported")
 	listCmd.Flags().BoolP("color", "l", false, "Output in color")
 	listCmd.Flags().BoolP("show", "s", false, "Show values of features, (default: false)")
+	listCmd.Flags().Bool("show-diagnostics", false, "Print malformed and missing elements found while parsing to stderr")
+	listCmd.Flags().Bool("strict", false, "Validate JSON SBOMs against the schema of their spec version")
+
+	// Baseline Control
+	addBaselineFlags(listCmd)
 
 	// Debug Control
 	listCmd.Flags().BoolP("debug", "D", false, "Enable debug logging")
@@ -253,6 +311,14 @@ var isFeaturePresent = map[string]bool{
 	"comp_with_concluded_license":  true,
 	"comp_with_declared_license":   true,
 
+	"comp_with_dangling_dependencies": true,
+	"comp_reachable":                  true,
+	"comp_in_dependency_cycle":        true,
+	"comp_with_complete_dependencies": true,
+
+	"comp_with_dependency_composition": true,
+	"comp_dependency_completeness":     true,
+
 	"sbom_creation_timestamp":       true,
 	"sbom_authors":                  true,
 	"sbom_with_creator_and_version": true,
@@ -260,6 +326,7 @@ var isFeaturePresent = map[string]bool{
 	"sbom_dependencies":             true,
 	"sbom_sharable":                 true,
 	"sbom_parsable":                 true,
+	"sbom_schema_valid":             true,
 	"sbom_spec":                     true,
 	"sbom_file_format":              true,
 	"sbom_spec_version":             true,
@@ -268,5 +335,6 @@ var isFeaturePresent = map[string]bool{
 	"sbom_with_vuln":                true,
 	"sbom_build_process":            true,
 	"sbom_with_bomlinks":            true,
+	"sbom_dependency_depth":         true,
 	// "sbom_with_signature":           true,
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/interlynk-io/sbomqs/pkg/engine"
@@ -49,10 +50,32 @@ type userCmd struct {
 	json     bool
 	basic    bool
 	detailed bool
+	sarif    bool
+	junit    bool
+	html     bool
 	color    bool
 
+	showDiagnostics bool
+
+	// validation control
+	strict bool
+
 	// directory control
 	recurse bool
+	include []string
+	exclude []string
+	jobs    int
+
+	// exit control
+	failOnError string
+
+	// history control
+	record      bool
+	historyPath string
+
+	// baseline control
+	baselinePath  string
+	writeBaseline bool
 
 	// debug control
 	debug bool
@@ -60,6 +83,14 @@ type userCmd struct {
 	// config control
 	configPath string
 
+	// quality gates, the per category and feature minimums are given as
+	// name=score and parsed by validateFlags
+	failBelow             float64
+	failBelowCategoryArgs []string
+	failBelowFeatureArgs  []string
+	failBelowCategory     map[string]float64
+	failBelowFeature      map[string]float64
+
 	signature string
 	publicKey string
 }
@@ -80,6 +111,15 @@ var scoreCmd = &cobra.Command{
   # Get a score against a SBOM in a JSON output
   sbomqs score --json samples/sbomqs-spdx-syft.json
 
+  # Get a score against a SBOM in SARIF, for code scanning dashboards
+  sbomqs score --sarif samples/sbomqs-spdx-syft.json > sbomqs.sarif
+
+  # Get a score against a SBOM in JUnit XML, for CI test dashboards
+  sbomqs score --junit --fail-below-feature comp_with_supplier=5 samples/sbomqs-spdx-syft.json > sbomqs.xml
+
+  # Get the scores of SBOMs as a self-contained HTML page
+  sbomqs score --html samples/ > sbomqs.html
+
   # Get a score for a 'BSI TR-03183-2 v1.1' category against a SBOM in a table output
   sbomqs score -c bsi-v1.1 samples/sbomqs-spdx-syft.json
 
@@ -98,6 +138,25 @@ var scoreCmd = &cobra.Command{
   # Get  a score for multiple features
   sbomqs score --feature comp_with_name,comp_with_uniq_ids,sbom_authors,sbom_creation_timestamp  samples/sbomqs-spdx-syft.json 
 
+  # Fail with exit code 2 when the score is below 7.0 or NTIA minimum elements are below 9.0
+  sbomqs score --fail-below 7.0 --fail-below-category ntia=9.0 samples/sbomqs-spdx-syft.json
+
+  # Record the score in the history store, to follow it with 'sbomqs history'
+  sbomqs score --record samples/sbomqs-spdx-syft.json
+
+  # Accept the components failing today, then only score and gate on new failures
+  sbomqs score --baseline baseline.json --write-baseline samples/sbomqs-spdx-syft.json
+  sbomqs score --baseline baseline.json --fail-below 7.0 samples/sbomqs-spdx-syft.json
+
+  # Score an SBOM read from stdin
+  syft packages . -o spdx-json | sbomqs score -
+
+  # Score every SBOM below build/, skipping test fixtures
+  sbomqs score --recurse --include '*.json' --exclude testdata build/
+
+  # Score the SBOMs attached to the image tagged v1.2.0 in a local OCI image layout
+  sbomqs score oci-layout:./image:v1.2.0
+
   # Get a score for multiple categories
   sbomqs score --category NTIA-minimum-elements or ntia,bsi-v1.1,bsi-v2.0,Structural,Semantic,Sharing,Quality   samples/sbomqs-spdx-syft.json
 `,
@@ -138,8 +197,14 @@ func processScore(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	var err error
+	uCmd.baselinePath, uCmd.writeBaseline, err = baselineFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	engParams := toEngineParams(uCmd)
-	err := validateEngineParams(ctx, engParams)
+	err = validateEngineParams(ctx, engParams)
 	if err != nil {
 		return fmt.Errorf("failed to validate engine params: %w", err)
 	}
@@ -151,11 +216,11 @@ func validateEngineParams(ctx context.Context, ep *engine.Params) error {
 	log := logger.FromContext(ctx)
 	log.Debug("validating engine parameters")
 
-	validPaths := validatePaths(ctx, ep.Path)
-	if len(validPaths) == 0 {
+	// paths which don't exist are reported per file by the engine
+	ep.Path = removeEmptyStrings(ep.Path)
+	if len(ep.Path) == 0 {
 		return fmt.Errorf("no valid paths provided")
 	}
-	ep.Path = validPaths
 
 	if ep.ConfigPath != "" {
 		if _, err := os.Stat(ep.ConfigPath); err != nil {
@@ -179,21 +244,6 @@ func removeEmptyStrings(input []string) []string {
 	return result
 }
 
-// validatePaths returns the valid paths.
-func validatePaths(ctx context.Context, paths []string) []string {
-	log := logger.FromContext(ctx)
-	log.Debug("validating paths")
-	var validPaths []string
-	for _, path := range paths {
-		if _, err := os.Stat(path); err != nil {
-			log.Debugf("skipping invalid path: %s, error: %v", path, err)
-			continue
-		}
-		validPaths = append(validPaths, path)
-	}
-	return validPaths
-}
-
 func toUserCmd(cmd *cobra.Command, args []string) *userCmd {
 	uCmd := &userCmd{}
 
@@ -239,7 +289,12 @@ func toUserCmd(cmd *cobra.Command, args []string) *userCmd {
 	uCmd.json, _ = cmd.Flags().GetBool("json")
 	uCmd.basic, _ = cmd.Flags().GetBool("basic")
 	uCmd.detailed, _ = cmd.Flags().GetBool("detailed")
+	uCmd.sarif, _ = cmd.Flags().GetBool("sarif")
+	uCmd.junit, _ = cmd.Flags().GetBool("junit")
+	uCmd.html, _ = cmd.Flags().GetBool("html")
 	uCmd.color, _ = cmd.Flags().GetBool("color")
+	uCmd.showDiagnostics, _ = cmd.Flags().GetBool("show-diagnostics")
+	uCmd.strict, _ = cmd.Flags().GetBool("strict")
 	uCmd.signature, _ = cmd.Flags().GetString("sig")
 	uCmd.publicKey, _ = cmd.Flags().GetString("pub")
 
@@ -247,8 +302,30 @@ func toUserCmd(cmd *cobra.Command, args []string) *userCmd {
 		uCmd.json = strings.ToLower(reportFormat) == "json"
 		uCmd.basic = strings.ToLower(reportFormat) == "basic"
 		uCmd.detailed = strings.ToLower(reportFormat) == "detailed"
+		uCmd.sarif = strings.ToLower(reportFormat) == "sarif"
+		uCmd.junit = strings.ToLower(reportFormat) == "junit"
+		uCmd.html = strings.ToLower(reportFormat) == "html"
 	}
 
+	// directory control
+	uCmd.recurse, _ = cmd.Flags().GetBool("recurse")
+	uCmd.include, _ = cmd.Flags().GetStringSlice("include")
+	uCmd.exclude, _ = cmd.Flags().GetStringSlice("exclude")
+	uCmd.jobs, _ = cmd.Flags().GetInt("jobs")
+
+	// exit control
+	uCmd.failOnError, _ = cmd.Flags().GetString("fail-on-error")
+
+	// history control
+	uCmd.historyPath, _ = cmd.Flags().GetString("history")
+	uCmd.record, _ = cmd.Flags().GetBool("record")
+	uCmd.record = uCmd.record || uCmd.historyPath != ""
+
+	// quality gates
+	uCmd.failBelow, _ = cmd.Flags().GetFloat64("fail-below")
+	uCmd.failBelowCategoryArgs, _ = cmd.Flags().GetStringSlice("fail-below-category")
+	uCmd.failBelowFeatureArgs, _ = cmd.Flags().GetStringSlice("fail-below-feature")
+
 	// debug control
 	uCmd.debug, _ = cmd.Flags().GetBool("debug")
 
@@ -263,12 +340,33 @@ func toEngineParams(uCmd *userCmd) *engine.Params {
 		JSON:       uCmd.json,
 		Basic:      uCmd.basic,
 		Detailed:   uCmd.detailed,
+		SARIF:      uCmd.sarif,
+		JUnit:      uCmd.junit,
+		HTML:       uCmd.html,
 		Color:      uCmd.color,
 		Recurse:    uCmd.recurse,
-		Debug:      uCmd.debug,
-		ConfigPath: uCmd.configPath,
-		Signature:  uCmd.signature,
-		PublicKey:  uCmd.publicKey,
+		Include:    uCmd.include,
+		Exclude:    uCmd.exclude,
+		Jobs:       uCmd.jobs,
+
+		ShowDiagnostics: uCmd.showDiagnostics,
+		Strict:          uCmd.strict,
+
+		ErrorPolicy: uCmd.failOnError,
+		Debug:       uCmd.debug,
+		ConfigPath:  uCmd.configPath,
+		Signature:   uCmd.signature,
+		PublicKey:   uCmd.publicKey,
+
+		FailBelow:         uCmd.failBelow,
+		FailBelowCategory: uCmd.failBelowCategory,
+		FailBelowFeature:  uCmd.failBelowFeature,
+
+		Record:      uCmd.record,
+		HistoryPath: uCmd.historyPath,
+
+		BaselinePath:  uCmd.baselinePath,
+		WriteBaseline: uCmd.writeBaseline,
 	}
 }
 
@@ -290,9 +388,62 @@ func validateFlags(cmd *userCmd) error {
 		return fmt.Errorf("invalid report format: %s", reportFormat)
 	}
 
+	if !lo.Contains(engine.ErrorPolicies, cmd.failOnError) {
+		return fmt.Errorf("invalid --fail-on-error %q: expected one of %s", cmd.failOnError, strings.Join(engine.ErrorPolicies, ", "))
+	}
+
+	if cmd.jobs < 0 {
+		return fmt.Errorf("invalid --jobs %d: expected zero or more", cmd.jobs)
+	}
+
+	if cmd.failBelow < 0 || cmd.failBelow > 10 {
+		return fmt.Errorf("invalid --fail-below %v: expected a score between 0 and 10", cmd.failBelow)
+	}
+
+	var err error
+	cmd.failBelowCategory, err = parseMinimums("fail-below-category", cmd.failBelowCategoryArgs)
+	if err != nil {
+		return err
+	}
+	for name, minimum := range cmd.failBelowCategory {
+		if fullName, ok := categoryAliases[name]; ok {
+			delete(cmd.failBelowCategory, name)
+			cmd.failBelowCategory[fullName] = minimum
+		}
+	}
+
+	cmd.failBelowFeature, err = parseMinimums("fail-below-feature", cmd.failBelowFeatureArgs)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// parseMinimums turns name=score pairs into a map of minimum scores.
+func parseMinimums(flag string, args []string) (map[string]float64, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	minimums := make(map[string]float64, len(args))
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --%s %q: expected name=score", flag, arg)
+		}
+
+		minimum, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || minimum < 0 || minimum > 10 {
+			return nil, fmt.Errorf("invalid --%s %q: expected a score between 0 and 10", flag, arg)
+		}
+		minimums[name] = minimum
+	}
+
+	return minimums, nil
+}
+
 func init() {
 	rootCmd.AddCommand(scoreCmd)
 
@@ -303,6 +454,11 @@ func init() {
 	scoreCmd.Flags().StringP("category", "c", "", "filter by category (e.g. 'bsi-v1', 'NTIA-minimum-elements', 'Quality', 'Semantic', 'Sharing', 'Structural')")
 	scoreCmd.Flags().StringP("feature", "f", "", "filter by feature (e.g. 'sbom_authors',  'comp_with_name', 'sbom_creation_timestamp') ")
 
+	// Quality gates
+	scoreCmd.Flags().Float64("fail-below", 0, "exit with code 2 if the overall score is below this value")
+	scoreCmd.Flags().StringSlice("fail-below-category", nil, "exit with code 2 if a category scores below a minimum (e.g. 'ntia=8.0')")
+	scoreCmd.Flags().StringSlice("fail-below-feature", nil, "exit with code 2 if a feature scores below a minimum (e.g. 'comp_with_supplier=5' or 'Structural/sbom_spec=10')")
+
 	// Spec Control
 	scoreCmd.Flags().BoolP("spdx", "", false, "limit scoring to spdx sboms")
 	scoreCmd.Flags().BoolP("cdx", "", false, "limit scoring to cdx sboms")
@@ -319,18 +475,33 @@ func init() {
 	}
 
 	// Directory Control
-	scoreCmd.Flags().BoolP("recurse", "r", false, "recurse into subdirectories")
-	err = scoreCmd.Flags().MarkHidden("recurse")
-	if err != nil {
-		// Handle the error appropriately, such as logging it or returning it
-		log.Fatalf("Failed to mark flag as deprecated: %v", err)
-	}
+	scoreCmd.Flags().BoolP("recurse", "r", false, "recurse into subdirectories, following symlinks")
+	scoreCmd.Flags().StringSlice("include", nil, "only score files matching these glob patterns (e.g. '*.json', 'build/**/sbom/*')")
+	scoreCmd.Flags().StringSlice("exclude", nil, "skip files and directories matching these glob patterns (e.g. 'node_modules')")
+	scoreCmd.Flags().Int("jobs", 0, "number of files scored concurrently, defaults to the number of CPUs")
+
+	// Exit Control
+	scoreCmd.Flags().String("fail-on-error", engine.ErrorPolicyAll, "exit with code 3 when files fail to score: any, all (no file scored) or never")
+
+	// History Control
+	scoreCmd.Flags().Bool("record", false, "record the scores in the history store, see 'sbomqs history'")
+	scoreCmd.Flags().String("history", "", "history store to record the scores in, implies --record (default: history.jsonl in the user's cache directory)")
+
+	// Baseline Control
+	addBaselineFlags(scoreCmd)
 
 	// Output Control
 	scoreCmd.Flags().BoolP("json", "j", false, "results in json")
 	scoreCmd.Flags().BoolP("detailed", "d", false, "results in table format, default")
 	scoreCmd.Flags().BoolP("basic", "b", false, "results in single line format")
+	scoreCmd.Flags().Bool("sarif", false, "results in SARIF, features scoring below 10 are reported")
+	scoreCmd.Flags().Bool("html", false, "results as a self-contained HTML page")
+	scoreCmd.Flags().Bool("junit", false, "results in JUnit XML, features fail below their --fail-below-feature minimum or 10")
 	scoreCmd.Flags().BoolP("color", "l", false, "output in colorful")
+	scoreCmd.Flags().Bool("show-diagnostics", false, "print malformed and missing elements found while parsing to stderr")
+
+	// Validation Control
+	scoreCmd.Flags().Bool("strict", false, "validate JSON SBOMs against the schema of their spec version")
 
 	// Debug Control
 	scoreCmd.Flags().BoolP("debug", "D", false, "enable debug logging")
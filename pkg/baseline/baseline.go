@@ -0,0 +1,259 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package baseline records the known failures of an SBOM, the components
+// which fail a feature today, so later runs only report and gate on new
+// ones. Each entry can carry a justification and an expiry date after which
+// it no longer applies.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/interlynk-io/sbomqs/pkg/purl"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/samber/lo"
+)
+
+// DateFormat is the format of expiry dates.
+const DateFormat = "2006-01-02"
+
+// Component identifies the component of an entry. It is matched by purl if
+// the entry has one, by ID if it has one and by name and version otherwise.
+type Component struct {
+	ID      string `json:"id,omitempty"`
+	Purl    string `json:"purl,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+func newComponent(c sbom.GetComponent) Component {
+	comp := Component{ID: c.GetID(), Name: c.GetName(), Version: c.GetVersion()}
+	if purls := c.GetPurls(); len(purls) > 0 {
+		comp.Purl = purls[0].String()
+	}
+	return comp
+}
+
+func (c Component) String() string {
+	if c.Version == "" {
+		return c.Name
+	}
+	return c.Name + "@" + c.Version
+}
+
+func (c Component) matches(comp sbom.GetComponent) bool {
+	if c.Purl != "" {
+		return lo.ContainsBy(comp.GetPurls(), func(p purl.PURL) bool {
+			return p.String() == c.Purl
+		})
+	}
+	if c.ID != "" {
+		return c.ID == comp.GetID()
+	}
+	return c.Name == comp.GetName() && c.Version == comp.GetVersion()
+}
+
+// Entry is a known failure: a component which fails a feature.
+type Entry struct {
+	// Document is the path of the SBOM the entry applies to, every SBOM if
+	// empty
+	Document      string    `json:"document,omitempty"`
+	Feature       string    `json:"feature"`
+	Component     Component `json:"component"`
+	Justification string    `json:"justification,omitempty"`
+
+	// Expires is the last day the entry applies, empty if it doesn't expire
+	Expires string `json:"expires,omitempty"`
+}
+
+// Expired reports whether the entry has expired at now.
+func (e Entry) Expired(now time.Time) bool {
+	if e.Expires == "" {
+		return false
+	}
+	t, err := time.ParseInLocation(DateFormat, e.Expires, now.Location())
+	if err != nil {
+		return true
+	}
+	return !now.Before(t.AddDate(0, 0, 1))
+}
+
+// appliesTo reports whether the entry applies to the SBOM at document.
+func (e Entry) appliesTo(document string) bool {
+	return e.Document == "" || e.Document == document
+}
+
+func (e Entry) key() string {
+	return strings.Join([]string{e.Document, e.Feature, e.Component.ID, e.Component.Purl, e.Component.Name, e.Component.Version}, "\x00")
+}
+
+type file struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Baseline is a set of known failures. It also records the failures
+// observed while scoring, which Write turns into a new baseline. It is safe
+// for concurrent use.
+type Baseline struct {
+	*state
+
+	// document is the path of the SBOM observed through the baseline, see For
+	document string
+}
+
+// state is shared by a baseline and the baselines For returns.
+type state struct {
+	mu sync.Mutex
+
+	entries []Entry
+	now     time.Time
+
+	// evaluated holds the features evaluated per document, keyed by
+	// evaluatedKey, and per feature for any document
+	evaluated map[string]bool
+	failures  map[string]Entry
+}
+
+// New returns an empty baseline.
+func New() *Baseline {
+	return &Baseline{state: &state{
+		now:       time.Now(),
+		evaluated: map[string]bool{},
+		failures:  map[string]Entry{},
+	}}
+}
+
+// For returns the baseline of the SBOM at document, which shares the
+// entries and observed failures of b. It only applies the entries of that
+// SBOM and those without a document, and records its failures for it.
+func (b *Baseline) For(document string) *Baseline {
+	if b == nil {
+		return nil
+	}
+	return &Baseline{state: b.state, document: document}
+}
+
+func evaluatedKey(document, feature string) string {
+	return document + "\x00" + feature
+}
+
+// Read reads the baseline in the file at path.
+func Read(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+
+	for i, e := range f.Entries {
+		if e.Feature == "" {
+			return nil, fmt.Errorf("invalid baseline %s: entry %d has no feature", path, i+1)
+		}
+		if e.Expires != "" {
+			if _, err := time.Parse(DateFormat, e.Expires); err != nil {
+				return nil, fmt.Errorf("invalid baseline %s: entry %d: expiry %q isn't a %s date", path, i+1, e.Expires, "YYYY-MM-DD")
+			}
+		}
+	}
+
+	b := New()
+	b.entries = f.Entries
+	return b, nil
+}
+
+// Entries returns the entries of the baseline.
+func (b *Baseline) Entries() []Entry {
+	return b.entries
+}
+
+// Expired returns the entries which have expired and no longer apply.
+func (b *Baseline) Expired() []Entry {
+	return lo.Filter(b.entries, func(e Entry, _ int) bool {
+		return e.Expired(b.now)
+	})
+}
+
+func (b *Baseline) find(feature string, c sbom.GetComponent) (Entry, bool) {
+	return lo.Find(b.entries, func(e Entry) bool {
+		return e.Feature == feature && e.appliesTo(b.document) && e.Component.matches(c)
+	})
+}
+
+// Observe records that c passed or failed feature in the SBOM of the
+// baseline. It reports whether c fails it and the failure is known, by an
+// entry which hasn't expired.
+func (b *Baseline) Observe(feature string, c sbom.GetComponent, failing bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.evaluated[evaluatedKey(b.document, feature)] = true
+	b.evaluated[evaluatedKey("", feature)] = true
+	if !failing {
+		return false
+	}
+
+	e, known := b.find(feature, c)
+	failure := Entry{
+		Document:      b.document,
+		Feature:       feature,
+		Component:     newComponent(c),
+		Justification: e.Justification,
+		Expires:       e.Expires,
+	}
+	b.failures[failure.key()] = failure
+
+	return known && !e.Expired(b.now)
+}
+
+// Failures returns the baseline of the observed failures. The entries of
+// features which weren't evaluated for their document are kept, the
+// justification and expiry of known failures are carried over.
+func (b *Baseline) Failures() []Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries := lo.Filter(b.entries, func(e Entry, _ int) bool {
+		return !b.evaluated[evaluatedKey(e.Document, e.Feature)]
+	})
+	entries = append(entries, lo.Values(b.failures)...)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].key() < entries[j].key()
+	})
+	return entries
+}
+
+// Write writes the baseline of the observed failures to path.
+func (b *Baseline) Write(path string) error {
+	data, err := json.MarshalIndent(file{Entries: b.Failures()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baseline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func components(t *testing.T) []sbom.GetComponent {
+	t.Helper()
+	doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "version": "1.0", "purl": "pkg:golang/a@1.0"},
+    {"type": "library", "bom-ref": "b", "name": "b", "version": "1.0"},
+    {"type": "library", "bom-ref": "c", "name": "c", "version": "1.0"}
+  ]
+}`), sbom.Signature{})
+	require.NoError(t, err)
+	return doc.Components()
+}
+
+func write(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestObserve(t *testing.T) {
+	comps := components(t)
+
+	path := write(t, `{"entries": [
+  {"feature": "comp_with_supplier", "component": {"purl": "pkg:golang/a@1.0", "name": "a"}, "justification": "vendored"},
+  {"feature": "comp_with_supplier", "component": {"id": "b", "name": "b"}, "expires": "2020-01-31"},
+  {"feature": "comp_with_licenses", "component": {"name": "c", "version": "1.0"}}
+]}`)
+
+	b, err := Read(path)
+	require.NoError(t, err)
+	b.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.Len(t, b.Expired(), 1)
+	assert.Equal(t, "b", b.Expired()[0].Component.Name)
+
+	assert.True(t, b.Observe("comp_with_supplier", comps[0], true), "matched by purl")
+	assert.False(t, b.Observe("comp_with_supplier", comps[1], true), "expired")
+	assert.False(t, b.Observe("comp_with_supplier", comps[2], true), "not known")
+	assert.False(t, b.Observe("comp_with_version", comps[0], true), "known for another feature")
+	assert.False(t, b.Observe("comp_with_name", comps[0], false), "passing")
+
+	failures := b.Failures()
+	require.Len(t, failures, 5)
+
+	// comp_with_licenses wasn't evaluated, its entry is kept
+	assert.Equal(t, "comp_with_licenses", failures[0].Feature)
+
+	assert.Equal(t, Entry{
+		Feature:       "comp_with_supplier",
+		Component:     Component{ID: "a", Purl: "pkg:golang/a@1.0", Name: "a", Version: "1.0"},
+		Justification: "vendored",
+	}, failures[1])
+	assert.Equal(t, "2020-01-31", failures[2].Expires, "expiry is carried over")
+}
+
+func TestWrite(t *testing.T) {
+	comps := components(t)
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	b := New()
+	b.Observe("comp_with_supplier", comps[1], true)
+	require.NoError(t, b.Write(path))
+
+	b, err := Read(path)
+	require.NoError(t, err)
+	require.Len(t, b.Entries(), 1)
+	assert.True(t, b.Observe("comp_with_supplier", comps[1], true))
+}
+
+func TestDocuments(t *testing.T) {
+	comps := components(t)
+
+	path := write(t, `{"entries": [
+  {"document": "a.json", "feature": "comp_with_supplier", "component": {"id": "b", "name": "b"}},
+  {"document": "c.json", "feature": "comp_with_supplier", "component": {"id": "b", "name": "b"}},
+  {"feature": "comp_with_licenses", "component": {"id": "b", "name": "b"}}
+]}`)
+
+	b, err := Read(path)
+	require.NoError(t, err)
+
+	a, other := b.For("a.json"), b.For("b.json")
+	assert.True(t, a.Observe("comp_with_supplier", comps[1], true))
+	assert.False(t, other.Observe("comp_with_supplier", comps[1], true), "known in another document")
+	assert.True(t, other.Observe("comp_with_licenses", comps[1], true), "entries without a document apply to every document")
+
+	failures := b.Failures()
+	require.Len(t, failures, 4)
+	assert.Equal(t, []string{"a.json", "b.json", "b.json", "c.json"}, []string{failures[0].Document, failures[1].Document, failures[2].Document, failures[3].Document})
+
+	// c.json wasn't scored, its entry is kept
+	assert.Equal(t, "comp_with_supplier", failures[3].Feature)
+
+	assert.Nil(t, (*Baseline)(nil).For("a.json"))
+}
+
+func TestReadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"not json", `entries`, "invalid baseline"},
+		{"no feature", `{"entries": [{"component": {"name": "a"}}]}`, "entry 1 has no feature"},
+		{"bad expiry", `{"entries": [{"feature": "f", "component": {"name": "a"}, "expires": "31/01/2024"}]}`, "isn't a YYYY-MM-DD date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(write(t, tt.content))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestExpired(t *testing.T) {
+	e := Entry{Expires: "2024-01-31"}
+
+	assert.False(t, e.Expired(time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC)), "applies on its last day")
+	assert.True(t, e.Expired(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, Entry{}.Expired(time.Now()), "no expiry")
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/interlynk-io/sbomqs/pkg/baseline"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	db "github.com/interlynk-io/sbomqs/pkg/compliance/db"
 	"github.com/interlynk-io/sbomqs/pkg/logger"
@@ -88,7 +89,7 @@ const (
 	SBOM_SIGNATURE
 )
 
-func bsiResult(ctx context.Context, doc sbom.Document, fileName string, outFormat string, colorOutput bool) {
+func bsiResult(ctx context.Context, doc sbom.Document, fileName string, outFormat string, colorOutput bool, bl *baseline.Baseline) {
 	log := logger.FromContext(ctx)
 	log.Debug("compliance.bsiResult()")
 
@@ -102,11 +103,24 @@ func bsiResult(ctx context.Context, doc sbom.Document, fileName string, outForma
 	dtb.AddRecord(bsiTimestamp(doc))
 	dtb.AddRecord(bsiSbomURI(doc))
 	dtb.AddRecords(bsiComponents(doc))
+	common.ApplyBaseline(dtb, doc, bl, BSI_REPORT, bsiDataFields())
 
 	if outFormat == "json" {
 		bsiJSONReport(dtb, fileName)
 	}
 
+	if outFormat == "sarif" {
+		bsiSarifReport(dtb, doc, fileName)
+	}
+
+	if outFormat == "junit" {
+		bsiJUnitReport(dtb, doc, fileName)
+	}
+
+	if outFormat == "html" {
+		bsiHTMLReport(dtb, doc, fileName)
+	}
+
 	if outFormat == "basic" {
 		bsiBasicReport(dtb, fileName)
 	}
@@ -355,7 +369,7 @@ func bsiComponents(doc sbom.Document) []*db.Record {
 		records = append(records, bsiComponentName(component))
 		records = append(records, bsiComponentVersion(component))
 		records = append(records, bsiComponentLicense(component))
-		records = append(records, bsiComponentDepth(doc, component))
+		records = append(records, common.WithComposition(bsiComponentDepth(doc, component), component))
 		records = append(records, bsiComponentHash(component))
 		records = append(records, bsiComponentSourceCodeURL(component))
 		records = append(records, bsiComponentDownloadURL(component))
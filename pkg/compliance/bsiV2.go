@@ -16,9 +16,9 @@ package compliance
 
 import (
 	"context"
-	"os"
 	"strings"
 
+	"github.com/interlynk-io/sbomqs/pkg/baseline"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	db "github.com/interlynk-io/sbomqs/pkg/compliance/db"
 	"github.com/interlynk-io/sbomqs/pkg/licenses"
@@ -32,7 +32,7 @@ var (
 	validBsiV2CdxVersions  = []string{"1.5", "1.6"}
 )
 
-func bsiV2Result(ctx context.Context, doc sbom.Document, fileName string, outFormat string) {
+func bsiV2Result(ctx context.Context, doc sbom.Document, fileName string, outFormat string, bl *baseline.Baseline) {
 	log := logger.FromContext(ctx)
 	log.Debug("compliance.bsiV2Result()")
 
@@ -50,11 +50,24 @@ func bsiV2Result(ctx context.Context, doc sbom.Document, fileName string, outFor
 	// New SBOM fields
 	dtb.AddRecord(bsiV2SbomSignature(doc))
 	dtb.AddRecord(bsiV2SbomLinks(doc))
+	common.ApplyBaseline(dtb, doc, bl, BSI_V2_REPORT, bsiDataFields())
 
 	if outFormat == "json" {
 		bsiV2JSONReport(dtb, fileName)
 	}
 
+	if outFormat == "sarif" {
+		bsiV2SarifReport(dtb, doc, fileName)
+	}
+
+	if outFormat == "junit" {
+		bsiV2JUnitReport(dtb, doc, fileName)
+	}
+
+	if outFormat == "html" {
+		bsiV2HTMLReport(dtb, doc, fileName)
+	}
+
 	if outFormat == "basic" {
 		bsiV2BasicReport(dtb, fileName)
 	}
@@ -108,12 +121,11 @@ func bsiV2SbomSignature(doc sbom.Document) *db.Record {
 		blob := doc.Signature().GetBlob()
 		sig := doc.Signature().GetSigValue()
 
-		pubKeyData, err := os.ReadFile(pubKey)
-		if err != nil {
+		if pubKey == "" || sig == "" {
 			return db.NewRecordStmt(SBOM_SIGNATURE, "doc", "Sig not detected!", 0.0, "")
 		}
 
-		valid, err := common.VerifySignature(pubKeyData, blob, sig)
+		valid, err := common.VerifySignature([]byte(pubKey), []byte(blob), []byte(sig))
 		if err != nil {
 			return db.NewRecordStmt(SBOM_SIGNATURE, "doc", "Verification failed!", 0.0, "")
 		}
@@ -124,10 +136,6 @@ func bsiV2SbomSignature(doc sbom.Document) *db.Record {
 			score = 5.0
 			result = "Signature provided but verification failed!"
 		}
-
-		common.RemoveFileIfExists("extracted_public_key.pem")
-		common.RemoveFileIfExists("extracted_signature.bin")
-		common.RemoveFileIfExists("standalone_sbom.json")
 	}
 
 	return db.NewRecordStmt(SBOM_SIGNATURE, "doc", result, score, "")
@@ -187,7 +195,7 @@ func bsiV2Components(doc sbom.Document) []*db.Record {
 		records = append(records, bsiComponentCreator(component))
 		records = append(records, bsiComponentName(component))
 		records = append(records, bsiComponentVersion(component))
-		records = append(records, bsiComponentDepth(doc, component))
+		records = append(records, common.WithComposition(bsiComponentDepth(doc, component), component))
 		records = append(records, bsiV2ComponentAssociatedLicense(doc, component))
 		records = append(records, bsiComponentHash(component))
 		records = append(records, bsiComponentSourceCodeURL(component))
@@ -16,6 +16,7 @@ package compliance
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
@@ -292,15 +293,23 @@ func TestBSIV2CDXSbomBomLinks(t *testing.T) {
 	}
 }
 
-func spdxDocWithExternalSignatureVerificationSuccessfully() sbom.Document {
+func spdxSignature() sbom.Signature {
 	signature := "../../samples/signature-test-data/sbom.sig"
 	publicKey := "../../samples/signature-test-data/public_key.pem"
-	blob := "../../samples/signature-test-data/SPDXJSONExample-v2.3.spdx.json"
-	sig := sbom.Signature{
-		SigValue:  signature,
-		PublicKey: publicKey,
-		Blob:      blob,
+	data, err := os.ReadFile("../../samples/signature-test-data/SPDXJSONExample-v2.3.spdx.json")
+	if err != nil {
+		panic(err)
+	}
+
+	sig, err := common.GetSignatureBundle(context.Background(), data, signature, publicKey)
+	if err != nil {
+		panic(err)
 	}
+	return sig
+}
+
+func spdxDocWithExternalSignatureVerificationSuccessfully() sbom.Document {
+	sig := spdxSignature()
 	doc := sbom.SpdxDoc{
 		SignatureDetail: &sig,
 	}
@@ -309,14 +318,8 @@ func spdxDocWithExternalSignatureVerificationSuccessfully() sbom.Document {
 
 // nolint:unused
 func spdxDocWithExternalSignatureVerificationFailed() sbom.Document {
-	signature := "../../samples/signature-test-data/sbom.sig"
-	publicKey := "../../samples/signature-test-data/public_key.pem"
-	blob := "../../samples/signature-test-data/SPDXJSONExample-v2.3.spdx.json"
-	sig := sbom.Signature{
-		SigValue:  signature,
-		PublicKey: publicKey,
-		Blob:      blob,
-	}
+	sig := spdxSignature()
+	sig.Blob += " "
 	doc := sbom.SpdxDoc{
 		SignatureDetail: &sig,
 	}
@@ -350,14 +353,12 @@ func TestSpdxSBOMWithSignature(t *testing.T) {
 
 func cdxDocWithEmbeddedSignature() sbom.Document {
 	context := context.Background()
-	sbomFile := "../../samples/signature-test-data/stree-cdxgen-signed-sbom.cdx.json"
-	standaloneSBOMFile, signatureRetrieved, publicKeyRetrieved, _ := common.RetrieveSignatureFromSBOM(context, sbomFile)
-
-	sig := sbom.Signature{
-		SigValue:  signatureRetrieved,
-		PublicKey: publicKeyRetrieved,
-		Blob:      standaloneSBOMFile,
+	data, err := os.ReadFile("../../samples/signature-test-data/stree-cdxgen-signed-sbom.cdx.json")
+	if err != nil {
+		panic(err)
 	}
+	sig, _, _ := common.RetrieveSignatureFromSBOM(context, data)
+
 	doc := sbom.SpdxDoc{
 		SignatureDetail: &sig,
 	}
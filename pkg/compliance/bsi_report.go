@@ -23,7 +23,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	db "github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
 	"github.com/olekukonko/tablewriter"
+	"github.com/samber/lo"
 	"sigs.k8s.io/release-utils/version"
 )
 
@@ -54,6 +56,20 @@ var bsiSectionDetails = map[int]bsiSection{
 	SBOM_BOM_LINKS:          {Title: "Optional sboms fields", ID: "8.1.12", Required: false, DataField: "bomlinks"},
 }
 
+// bsiDataFields names the data field of each check, for baselines.
+func bsiDataFields() map[int]string {
+	return lo.MapValues(bsiSectionDetails, func(s bsiSection, _ int) string {
+		return s.DataField
+	})
+}
+
+// bsiChecks describes each check for SARIF and JUnit reports.
+func bsiChecks() map[int]common.Check {
+	return lo.MapValues(bsiSectionDetails, func(s bsiSection, _ int) common.Check {
+		return common.Check{ID: s.ID, Title: s.Title, DataField: s.DataField, Required: s.Required}
+	})
+}
+
 type run struct {
 	ID            string `json:"id"`
 	GeneratedAt   string `json:"generated_at"`
@@ -129,6 +145,27 @@ func bsiJSONReport(dtb *db.DB, fileName string) {
 	fmt.Println(string(o))
 }
 
+func bsiSarifReport(dtb *db.DB, doc sbom.Document, fileName string) {
+	if err := common.SarifReport(os.Stdout, dtb, doc, fileName, BSI_REPORT, bsiChecks()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print sarif report: %v\n", err)
+	}
+}
+
+func bsiJUnitReport(dtb *db.DB, doc sbom.Document, fileName string) {
+	if err := common.JUnitReport(os.Stdout, dtb, doc, fileName, BSI_REPORT, bsiChecks()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print junit report: %v\n", err)
+	}
+}
+
+func bsiHTMLReport(dtb *db.DB, doc sbom.Document, fileName string) {
+	score := bsiAggregateScore(dtb)
+	scores := common.Scores{Total: score.totalScore(), Required: score.totalRequiredScore(), Optional: score.totalOptionalScore()}
+
+	if err := common.HTMLReport(os.Stdout, dtb, doc, fileName, "BSI TR-03183-2 v1.1 Compliance Report", bsiChecks(), scores); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print html report: %v\n", err)
+	}
+}
+
 func constructSections(dtb *db.DB) []bsiSection {
 	var sections []bsiSection
 	allIDs := dtb.GetAllIDs()
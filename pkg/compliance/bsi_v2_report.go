@@ -19,7 +19,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	db "github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
 	"github.com/olekukonko/tablewriter"
 )
 
@@ -43,6 +45,27 @@ func bsiV2JSONReport(dtb *db.DB, fileName string) {
 	fmt.Println(string(o))
 }
 
+func bsiV2SarifReport(dtb *db.DB, doc sbom.Document, fileName string) {
+	if err := common.SarifReport(os.Stdout, dtb, doc, fileName, BSI_V2_REPORT, bsiChecks()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print sarif report: %v\n", err)
+	}
+}
+
+func bsiV2JUnitReport(dtb *db.DB, doc sbom.Document, fileName string) {
+	if err := common.JUnitReport(os.Stdout, dtb, doc, fileName, BSI_V2_REPORT, bsiChecks()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print junit report: %v\n", err)
+	}
+}
+
+func bsiV2HTMLReport(dtb *db.DB, doc sbom.Document, fileName string) {
+	score := bsiAggregateScore(dtb)
+	scores := common.Scores{Total: score.totalScore(), Required: score.totalRequiredScore(), Optional: score.totalOptionalScore()}
+
+	if err := common.HTMLReport(os.Stdout, dtb, doc, fileName, "BSI TR-03183-2 v2.0.0 Compliance Report", bsiChecks(), scores); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print html report: %v\n", err)
+	}
+}
+
 func bsiV2DetailedReport(dtb *db.DB, fileName string) {
 	table := tablewriter.NewWriter(os.Stdout)
 	score := bsiAggregateScore(dtb)
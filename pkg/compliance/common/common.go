@@ -16,16 +16,20 @@ package common
 
 import (
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
-	"os"
 	"path"
 	"strings"
 	"time"
 
+	"github.com/interlynk-io/sbomqs/pkg/baseline"
+	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
 	"github.com/interlynk-io/sbomqs/pkg/cpe"
 	"github.com/interlynk-io/sbomqs/pkg/licenses"
 	"github.com/interlynk-io/sbomqs/pkg/omniborid"
@@ -376,6 +380,22 @@ func UniqueElementID(component sbom.GetComponent) string {
 	return componentName + "-" + version
 }
 
+// WithComposition adds the aggregate a CycloneDX composition declares for
+// the dependencies of component to the result of its dependencies record.
+// The score is left as it is.
+func WithComposition(r *db.Record, component sbom.GetComponent) *db.Record {
+	aggregate := component.GetDependencyComposition()
+	if aggregate == "" {
+		return r
+	}
+	if r.CheckValue == "" {
+		r.CheckValue = "composition: " + aggregate
+	} else {
+		r.CheckValue += " (composition: " + aggregate + ")"
+	}
+	return r
+}
+
 func IsComponentPartOfPrimaryDependency(primaryCompDeps []string, comp string) bool {
 	for _, item := range primaryCompDeps {
 		if item == comp {
@@ -503,7 +523,10 @@ func AreLicensesValid(licenses []licenses.License) bool {
 	return spdx+aboutcode+custom == len(licenses)
 }
 
-func VerifySignature(pubKeyData []byte, sbomPath, signaturePath string) (bool, error) {
+// VerifySignature checks signature over blob with the PEM encoded public key:
+// RSA PKCS#1 v1.5 and ECDSA signatures are over the SHA-256 of blob, Ed25519
+// signatures over blob itself.
+func VerifySignature(pubKeyData, blob, signature []byte) (bool, error) {
 	block, _ := pem.Decode(pubKeyData)
 	if block == nil || block.Type != "PUBLIC KEY" {
 		return false, fmt.Errorf("invalid public key")
@@ -514,48 +537,73 @@ func VerifySignature(pubKeyData []byte, sbomPath, signaturePath string) (bool, e
 		return false, err
 	}
 
-	rsaPubKey, ok := pubKey.(*rsa.PublicKey)
-	if !ok {
-		return false, fmt.Errorf("not an RSA public key")
+	// Verify the signature
+	switch key := pubKey.(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(key, crypto.SHA256, HashSBOM(blob), signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, HashSBOM(blob), signature) {
+			err = errors.New("ecdsa signature verification failed")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, blob, signature) {
+			err = errors.New("ed25519 signature verification failed")
+		}
+	default:
+		err = fmt.Errorf("unsupported public key type %T", pubKey)
 	}
-
-	// Hash the SBOM
-	hash, err := HashSBOM(sbomPath)
 	if err != nil {
 		return false, err
 	}
 
-	// Load and decode the signature
-	sig, err := os.ReadFile(signaturePath)
-	if err != nil {
-		return false, err
+	return true, nil
+}
+
+func HashSBOM(data []byte) []byte {
+	hash := sha256.Sum256(data)
+	return hash[:]
+}
+
+// ApplyBaseline passes the failing component records the baseline knows,
+// noting it in their result. fields names the data field of each check key
+// of the report, the baseline feature of a record is "<report>: <field>".
+func ApplyBaseline(d *db.DB, doc sbom.Document, b *baseline.Baseline, report string, fields map[int]string) {
+	if b == nil {
+		return
 	}
 
-	// Verify the signature
-	err = rsa.VerifyPKCS1v15(rsaPubKey, crypto.SHA256, hash, sig)
-	if err != nil {
-		return false, err
+	comps := map[string]sbom.GetComponent{}
+	for _, c := range doc.Components() {
+		if _, ok := comps[UniqueElementID(c)]; !ok {
+			comps[UniqueElementID(c)] = c
+		}
 	}
 
-	return true, err
+	for key, field := range fields {
+		for _, r := range d.GetRecords(key) {
+			c, ok := comps[r.ID]
+			if !ok {
+				continue
+			}
+			if b.Observe(report+": "+field, c, Failed(r)) {
+				r.Score = 10.0
+				r.CheckValue = strings.TrimSpace(r.CheckValue + " (baselined)")
+			}
+		}
+	}
 }
 
-func HashSBOM(sbomPath string) ([]byte, error) {
-	sbomData, err := os.ReadFile(sbomPath)
-	if err != nil {
-		return nil, err
-	}
-	hash := sha256.Sum256(sbomData)
-	return hash[:], nil
+// Check describes a check of a compliance report, for the SARIF and JUnit
+// reports.
+type Check struct {
+	ID        string
+	Title     string
+	DataField string
+	Required  bool
 }
 
-func RemoveFileIfExists(filePath string) {
-	if _, err := os.Stat(filePath); err == nil {
-		err = os.Remove(filePath)
-		if err != nil {
-			fmt.Printf("Failed to delete file %s: %v\n", filePath, err)
-		}
-	} else if !os.IsNotExist(err) {
-		fmt.Printf("Error checking file %s: %v\n", filePath, err)
-	}
+// Failed tells whether a record falls short of its check, partially met
+// checks included.
+func Failed(r *db.Record) bool {
+	return r.Score < 10.0
 }
@@ -0,0 +1,111 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/htmlreport"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/samber/lo"
+)
+
+// Scores are the aggregate scores of a compliance report.
+type Scores struct {
+	Total    float64
+	Required float64
+	Optional float64
+}
+
+// HTMLReport renders the compliance of fileName with report, titled title,
+// as an HTML page. Each check is scored by the share of its records passing,
+// the records are listed by element.
+func HTMLReport(w io.Writer, d *db.DB, doc sbom.Document, fileName, title string, checks map[int]Check, s Scores) error {
+	names := map[string]string{"doc": "SBOM"}
+	for _, c := range doc.Components() {
+		if _, ok := names[UniqueElementID(c)]; !ok {
+			names[UniqueElementID(c)] = c.GetName() + "@" + c.GetVersion()
+		}
+	}
+
+	f := &htmlreport.File{
+		Name:          fileName,
+		Spec:          doc.Spec().GetSpecType(),
+		SpecVersion:   doc.Spec().GetVersion(),
+		Components:    len(doc.Components()),
+		Score:         s.Total,
+		MaxScore:      10.0,
+		Categories:    []htmlreport.Category{{Name: "Required", Score: s.Required}},
+		FeaturesTitle: "Checks",
+	}
+	if lo.SomeBy(lo.Values(checks), func(c Check) bool { return !c.Required }) {
+		f.Categories = append(f.Categories, htmlreport.Category{Name: "Optional", Score: s.Optional})
+	}
+
+	keys := lo.Keys(checks)
+	sort.Ints(keys)
+
+	for _, key := range keys {
+		records := d.GetRecords(key)
+		if len(records) == 0 {
+			continue
+		}
+		c := checks[key]
+
+		failed := 0
+		for _, r := range records {
+			name, ok := names[r.ID]
+			if !ok {
+				name = r.ID
+			}
+			f.Records = append(f.Records, htmlreport.Record{
+				Element:  name,
+				Section:  c.ID,
+				Field:    c.DataField,
+				Result:   r.CheckValue,
+				Required: c.Required,
+				Score:    r.Score,
+				Failed:   Failed(r),
+			})
+			if Failed(r) {
+				failed++
+			}
+		}
+
+		passed := len(records) - failed
+		f.Features = append(f.Features, htmlreport.Feature{
+			Category:    strings.TrimSpace(c.Title),
+			Name:        c.ID + " " + c.DataField,
+			Score:       10.0 * float64(passed) / float64(len(records)),
+			Description: fmt.Sprintf("%d/%d passed", passed, len(records)),
+			Failed:      failed > 0,
+		})
+	}
+
+	// elements in order, the SBOM first
+	sort.SliceStable(f.Records, func(i, j int) bool {
+		a, b := f.Records[i], f.Records[j]
+		if (a.Element == "SBOM") != (b.Element == "SBOM") {
+			return a.Element == "SBOM"
+		}
+		return a.Element < b.Element
+	})
+
+	return htmlreport.New(title, f).Write(w)
+}
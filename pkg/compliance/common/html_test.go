@@ -0,0 +1,71 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLReport(t *testing.T) {
+	doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "version": "1.0"},
+    {"type": "library", "bom-ref": "b", "name": "b", "version": "2.0"}
+  ]
+}`), sbom.Signature{})
+	require.NoError(t, err)
+	a, b := doc.Components()[0], doc.Components()[1]
+
+	const (
+		author = iota
+		supplier
+	)
+	checks := map[int]Check{
+		author:   {ID: "2.1", Title: "SBOM Level", DataField: "Author", Required: true},
+		supplier: {ID: "2.6", Title: "Component Level", DataField: "Supplier", Required: true},
+	}
+
+	d := db.NewDB()
+	d.AddRecord(db.NewRecordStmt(supplier, UniqueElementID(b), "", 0.0, ""))
+	d.AddRecord(db.NewRecordStmt(supplier, UniqueElementID(a), "Acme", 10.0, ""))
+	d.AddRecord(db.NewRecordStmt(author, "doc", "Jane", 10.0, ""))
+
+	var buf bytes.Buffer
+	require.NoError(t, HTMLReport(&buf, d, doc, "sbom.json", "Test Compliance Report", checks, Scores{Total: 7.5, Required: 7.5}))
+	out := buf.String()
+
+	assert.Contains(t, out, "<title>Test Compliance Report</title>")
+	assert.Contains(t, out, "<h3>Checks</h3>")
+	assert.Contains(t, out, "<th>Required</th>")
+	assert.NotContains(t, out, "<th>Optional</th>", "no optional checks")
+	assert.Contains(t, out, "1/2 passed")
+
+	// records are listed by element, the SBOM first
+	top, first, second := strings.Index(out, "<td>SBOM</td>"), strings.Index(out, "<td>a@1.0</td>"), strings.Index(out, "<td>b@2.0</td>")
+	require.True(t, top > 0 && first > 0 && second > 0)
+	assert.Less(t, top, first)
+	assert.Less(t, first, second)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/junit"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/samber/lo"
+)
+
+// JUnitReport writes the compliance of fileName with report as a test suite,
+// with a test case per check. A required check fails when any of its records
+// failed, failed optional checks only list their failures in the output.
+func JUnitReport(w io.Writer, d *db.DB, doc sbom.Document, fileName, report string, checks map[int]Check) error {
+	names := map[string]string{"doc": "SBOM"}
+	for _, c := range doc.Components() {
+		if _, ok := names[UniqueElementID(c)]; !ok {
+			names[UniqueElementID(c)] = c.GetName() + "@" + c.GetVersion()
+		}
+	}
+
+	r := junit.New()
+	suite := r.AddSuite(fileName, time.Now().UTC().Format("2006-01-02T15:04:05"))
+	suite.AddProperty("report", report)
+
+	keys := lo.Keys(checks)
+	sort.Ints(keys)
+
+	for _, key := range keys {
+		records := d.GetRecords(key)
+		if len(records) == 0 {
+			continue
+		}
+		c := checks[key]
+
+		var failures []string
+		for _, r := range records {
+			if !Failed(r) {
+				continue
+			}
+			name, ok := names[r.ID]
+			if !ok {
+				name = r.ID
+			}
+			value := r.CheckValue
+			if value == "" {
+				value = "missing"
+			}
+			failures = append(failures, fmt.Sprintf("%s: %s", name, value))
+		}
+
+		tc := junit.TestCase{
+			Name:      c.ID + " " + c.DataField,
+			Classname: report + " " + strings.TrimSpace(c.Title),
+		}
+		summary := fmt.Sprintf("%d/%d failed", len(failures), len(records))
+
+		switch {
+		case len(failures) == 0:
+			tc.SystemOut = fmt.Sprintf("%d/%d passed", len(records), len(records))
+		case c.Required:
+			tc.Failure = &junit.Result{Message: summary, Type: "required", Text: strings.Join(failures, "\n")}
+		default:
+			tc.SystemOut = summary + ", optional\n" + strings.Join(failures, "\n")
+		}
+
+		suite.AddCase(tc)
+	}
+
+	return r.Write(w)
+}
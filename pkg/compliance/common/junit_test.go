@@ -0,0 +1,85 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/junit"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnitReport(t *testing.T) {
+	doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "version": "1.0"},
+    {"type": "library", "bom-ref": "b", "name": "b", "version": "2.0"}
+  ]
+}`), sbom.Signature{})
+	require.NoError(t, err)
+	a, b := doc.Components()[0], doc.Components()[1]
+
+	const (
+		author = iota
+		supplier
+		license
+		hash
+	)
+	checks := map[int]Check{
+		author:   {ID: "2.1", Title: "SBOM Level ", DataField: "Author", Required: true},
+		supplier: {ID: "2.6", Title: "Component Level", DataField: "Supplier", Required: true},
+		license:  {ID: "3.1", Title: "Component Level", DataField: "License", Required: false},
+		hash:     {ID: "3.2", Title: "Component Level", DataField: "Hash", Required: false},
+	}
+
+	d := db.NewDB()
+	d.AddRecord(db.NewRecordStmt(author, "doc", "Jane", 10.0, ""))
+	d.AddRecord(db.NewRecordStmt(supplier, UniqueElementID(a), "Acme", 10.0, ""))
+	d.AddRecord(db.NewRecordStmt(supplier, UniqueElementID(b), "", 0.0, ""))
+	d.AddRecord(db.NewRecordStmtOptional(license, UniqueElementID(a), "", 0.0))
+
+	var buf bytes.Buffer
+	require.NoError(t, JUnitReport(&buf, d, doc, "sbom.json", "TEST", checks))
+
+	var r junit.TestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &r))
+	require.Len(t, r.Suites, 1)
+	s := r.Suites[0]
+
+	assert.Equal(t, "sbom.json", s.Name)
+	assert.Equal(t, 3, s.Tests, "checks without records are left out")
+	assert.Equal(t, 1, s.Failures)
+
+	assert.Equal(t, "2.1 Author", s.Cases[0].Name)
+	assert.Equal(t, "TEST SBOM Level", s.Cases[0].Classname)
+	assert.Nil(t, s.Cases[0].Failure)
+
+	require.NotNil(t, s.Cases[1].Failure)
+	assert.Equal(t, "1/2 failed", s.Cases[1].Failure.Message)
+	assert.Equal(t, "b@2.0: missing", s.Cases[1].Failure.Text)
+
+	assert.Nil(t, s.Cases[2].Failure, "optional checks don't fail")
+	assert.Equal(t, "1/1 failed, optional\na@1.0: missing", s.Cases[2].SystemOut)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/sarif"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/samber/lo"
+)
+
+// SarifRuleID is the rule of a check of report, e.g. NTIA-2.6-package-supplier.
+// The section ID alone isn't unique, several checks share a section.
+func SarifRuleID(report string, c Check) string {
+	field := strings.Map(func(r rune) rune {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(c.DataField))
+
+	return report + "-" + c.ID + "-" + strings.Trim(field, "-")
+}
+
+// SarifReport writes every failed record of d as a SARIF result of its
+// check. Results of required checks are errors, of optional ones warnings.
+// Component records are located at their component, the others at the SBOM.
+func SarifReport(w io.Writer, d *db.DB, doc sbom.Document, fileName, report string, checks map[int]Check) error {
+	comps := map[string]sbom.GetComponent{}
+	for _, c := range doc.Components() {
+		if _, ok := comps[UniqueElementID(c)]; !ok {
+			comps[UniqueElementID(c)] = c
+		}
+	}
+
+	l := sarif.NewLog()
+	run := l.Run()
+
+	keys := lo.Keys(checks)
+	sort.Ints(keys)
+
+	for _, key := range keys {
+		c := checks[key]
+		ruleID := SarifRuleID(report, c)
+		level := sarif.LevelWarning
+		if c.Required {
+			level = sarif.LevelError
+		}
+
+		for _, r := range d.GetRecords(key) {
+			if !Failed(r) {
+				continue
+			}
+
+			run.AddRule(sarif.Rule{
+				ID:                   ruleID,
+				ShortDescription:     sarif.Message{Text: fmt.Sprintf("%s %s: %s", report, c.ID, c.DataField)},
+				DefaultConfiguration: sarif.Configuration{Level: level},
+				Properties:           map[string]any{"section": c.Title, "required": c.Required},
+			})
+
+			value := r.CheckValue
+			if value == "" {
+				value = "missing"
+			}
+
+			var loc *sarif.LogicalLocation
+			msg := fmt.Sprintf("%s: %s", c.DataField, value)
+			if comp, ok := comps[r.ID]; ok {
+				loc = sarif.Component(comp)
+				msg = fmt.Sprintf("%s of %s: %s", c.DataField, loc.Name, value)
+			}
+			run.AddResult(ruleID, level, msg, fileName, loc)
+		}
+	}
+
+	return l.Write(w)
+}
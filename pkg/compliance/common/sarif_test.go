@@ -0,0 +1,105 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/sarif"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSarifRuleID(t *testing.T) {
+	assert.Equal(t, "NTIA-2.6-package-supplier", SarifRuleID("NTIA", Check{ID: "2.6", DataField: "Package Supplier"}))
+	assert.Equal(t, "BSI-V2-5.3.2-uri-of-the-executable-form-of-the-component", SarifRuleID("BSI-V2", Check{ID: "5.3.2", DataField: "URI of the executable form of the component"}))
+	assert.Equal(t, "BSI-5.3.1-sbom-uri", SarifRuleID("BSI", Check{ID: "5.3.1", DataField: "SBOM-URI"}))
+}
+
+func TestSarifReport(t *testing.T) {
+	doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "version": "1.0", "purl": "pkg:npm/a@1.0"},
+    {"type": "library", "bom-ref": "b", "name": "b", "version": "2.0"}
+  ]
+}`), sbom.Signature{})
+	require.NoError(t, err)
+	a, b := doc.Components()[0], doc.Components()[1]
+
+	const (
+		author = iota
+		supplier
+		license
+	)
+	checks := map[int]Check{
+		author:   {ID: "2.1", Title: "SBOM Level", DataField: "Author", Required: true},
+		supplier: {ID: "2.6", Title: "Component Level", DataField: "Supplier", Required: true},
+		license:  {ID: "3.1", Title: "Component Level", DataField: "License", Required: false},
+	}
+
+	d := db.NewDB()
+	d.AddRecord(db.NewRecordStmt(author, "doc", "", 0.0, ""))
+	d.AddRecord(db.NewRecordStmt(supplier, UniqueElementID(a), "Acme", 10.0, ""))
+	d.AddRecord(db.NewRecordStmt(supplier, UniqueElementID(b), "", 0.0, ""))
+	d.AddRecord(db.NewRecordStmtOptional(license, UniqueElementID(a), "MIT", 5.0))
+
+	var buf bytes.Buffer
+	require.NoError(t, SarifReport(&buf, d, doc, "sbom.json", "TEST", checks))
+
+	var l sarif.Log
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &l))
+	require.Len(t, l.Runs, 1)
+	run := l.Runs[0]
+
+	rules := []string{}
+	for _, r := range run.Tool.Driver.Rules {
+		rules = append(rules, r.ID)
+	}
+	assert.Equal(t, []string{"TEST-2.1-author", "TEST-2.6-supplier", "TEST-3.1-license"}, rules)
+
+	tests := []struct {
+		rule    string
+		level   string
+		message string
+		comp    string
+	}{
+		{"TEST-2.1-author", sarif.LevelError, "Author: missing", ""},
+		{"TEST-2.6-supplier", sarif.LevelError, "Supplier of b@2.0: missing", "b"},
+		{"TEST-3.1-license", sarif.LevelWarning, "License of a@1.0: MIT", "pkg:npm/a@1.0"},
+	}
+
+	require.Len(t, run.Results, len(tests))
+	for i, tt := range tests {
+		r := run.Results[i]
+		assert.Equal(t, tt.rule, r.RuleID)
+		assert.Equal(t, i, r.RuleIndex)
+		assert.Equal(t, tt.level, r.Level)
+		assert.Equal(t, tt.message, r.Message.Text)
+		if tt.comp == "" {
+			assert.Empty(t, r.Locations[0].LogicalLocations)
+		} else {
+			assert.Equal(t, tt.comp, r.Locations[0].LogicalLocations[0].FullyQualifiedName)
+		}
+	}
+}
@@ -25,9 +25,9 @@ import (
 	"fmt"
 	"math/big"
 	"os"
-	"strings"
 
 	"github.com/interlynk-io/sbomqs/pkg/logger"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
 	"github.com/tidwall/sjson"
 )
 
@@ -48,53 +48,42 @@ type PublicKey struct {
 	E   string `json:"e"`
 }
 
-func RetrieveSignatureFromSBOM(ctx context.Context, sbomFile string) (string, string, string, error) {
+// RetrieveSignatureFromSBOM extracts the signature embedded in a CycloneDX
+// SBOM. The returned blob is the SBOM without its signature section, which
+// is the content the signature was made over. ok is false if the SBOM
+// carries no signature.
+func RetrieveSignatureFromSBOM(ctx context.Context, data []byte) (sig sbom.Signature, ok bool, err error) {
 	log := logger.FromContext(ctx)
 	log.Debugf("common.RetrieveSignatureFromSBOM()")
-	var err error
 
-	data, err := os.ReadFile(sbomFile)
-	if err != nil {
-		log.Debug("error reading SBOM file: %w", err)
-		return "", "", "", fmt.Errorf("error reading SBOM file: %w", err)
-	}
-
-	var sbom SBOM
-
-	// nolint
-	extracted_signature := "extracted_signature.bin"
-
-	// nolint
-	extracted_publick_key := "extracted_public_key.pem"
-
-	if err := json.Unmarshal(data, &sbom); err != nil {
+	var doc SBOM
+	if err := json.Unmarshal(data, &doc); err != nil {
 		log.Debug("Error parsing SBOM JSON: %w", err)
-		return "", "", "", fmt.Errorf("error unmarshalling SBOM JSON: %w", err)
+		return sig, false, fmt.Errorf("error unmarshalling SBOM JSON: %w", err)
 	}
 
-	if sbom.Signature == nil {
+	if doc.Signature == nil {
 		log.Debug("signature and public key are not embedded in the SBOM")
-		return sbomFile, "", "", nil
+		return sig, false, nil
 	}
 	log.Debug("signature and public key are present in the SBOM")
 
-	signatureValue, err := base64.StdEncoding.DecodeString(sbom.Signature.Value)
+	signatureValue, err := base64.StdEncoding.DecodeString(doc.Signature.Value)
 	if err != nil {
 		log.Debug("error decoding signature: %w", err)
-		return "", "", "", fmt.Errorf("error decoding signature: %w", err)
+		return sig, false, fmt.Errorf("error decoding signature: %w", err)
 	}
 
-	if err := os.WriteFile(extracted_signature, signatureValue, 0o600); err != nil {
-		log.Debug("Error writing signature to file:", err)
+	if doc.Signature.PublicKey == nil {
+		return sig, false, fmt.Errorf("embedded signature has no public key")
 	}
-	log.Debug("Signature written to file: extracted_signature.bin")
 
 	// extract the public key modulus and exponent
-	modulus, err := base64.StdEncoding.DecodeString(sbom.Signature.PublicKey.N)
+	modulus, err := base64.StdEncoding.DecodeString(doc.Signature.PublicKey.N)
 	if err != nil {
-		return "", "", "", fmt.Errorf("error decoding public key modulus: %w", err)
+		return sig, false, fmt.Errorf("error decoding public key modulus: %w", err)
 	}
-	exponent := DecodeBase64URLEncodingToInt(sbom.Signature.PublicKey.E)
+	exponent := DecodeBase64URLEncodingToInt(doc.Signature.PublicKey.E)
 	if exponent == 0 {
 		log.Debug("Invalid public key exponent.")
 	}
@@ -105,11 +94,6 @@ func RetrieveSignatureFromSBOM(ctx context.Context, sbomFile string) (string, st
 		E: exponent,
 	}
 
-	pubKeyPEM := PublicKeyToPEM(pubKey)
-	if err := os.WriteFile(extracted_publick_key, pubKeyPEM, 0o600); err != nil {
-		log.Debug("error writing public key to file: %w", err)
-	}
-
 	// remove the "signature" section
 	modifiedSBOM, err := sjson.DeleteBytes(data, "signature")
 	if err != nil {
@@ -121,14 +105,14 @@ func RetrieveSignatureFromSBOM(ctx context.Context, sbomFile string) (string, st
 		log.Debug("Error normalizing SBOM JSON: %w", err)
 	}
 
-	// save the modified SBOM to a new file without a trailing newline
-	standaloneSBOMFile := "standalone_sbom.json"
-	if err := os.WriteFile(standaloneSBOMFile, bytes.TrimSuffix(normalizedSBOM.Bytes(), []byte("\n")), 0o600); err != nil {
-		return "", "", "", fmt.Errorf("error writing standalone SBOM file: %w", err)
+	sig = sbom.Signature{
+		SigValue:  string(signatureValue),
+		PublicKey: string(PublicKeyToPEM(pubKey)),
+		// the signature was made without a trailing newline
+		Blob: string(bytes.TrimSuffix(normalizedSBOM.Bytes(), []byte("\n"))),
 	}
 
-	log.Debug("Standalone SBOM saved to:", standaloneSBOMFile)
-	return standaloneSBOMFile, extracted_signature, extracted_publick_key, nil
+	return sig, true, nil
 }
 
 func DecodeBase64URLEncodingToInt(input string) int {
@@ -165,54 +149,99 @@ func PublicKeyToPEM(pub *rsa.PublicKey) []byte {
 	return pubPEM
 }
 
-func GetSignatureBundle(ctx context.Context, sbomFile, signature, publicKey string) (string, string, string, error) {
+// GetSignatureBundle returns the signature material for the SBOM in data.
+// For an SBOM in a signed DSSE envelope the envelope signature is used, over
+// the envelope's pre-authentication encoding. A signature embedded in a
+// CycloneDX SBOM is used next, otherwise the detached signature file is read.
+// The public key file, if given, is read in every case but the embedded one.
+func GetSignatureBundle(ctx context.Context, data []byte, signature, publicKey string) (sbom.Signature, error) {
 	log := logger.FromContext(ctx)
 	log.Debugf("common.GetSignatureBundle()")
 
-	// detect SBOM format for signature handling
-	format, err := detectSBOMFormatDirectlyFromSBOMFile(ctx, sbomFile)
+	// A malformed attestation is reported when the SBOM is parsed.
+	unwrapped, att, err := sbom.UnwrapAttestation(data)
 	if err != nil {
-		log.Debugf("failed to detect SBOM format: %w", err)
-		return "", "", "", fmt.Errorf("cannot determine SBOM format for %s: %w", sbomFile, err)
+		log.Debugf("failed to unwrap attestation: %v", err)
+	} else {
+		data = unwrapped
+	}
+
+	if att != nil && att.IsSigned() {
+		log.Debug("signed DSSE envelope detected, using the envelope signature")
+		sig := sbom.Signature{Blob: string(att.PAE())}
+		sig.PublicKey, err = readPublicKey(publicKey)
+		sig.SigValue = string(envelopeSignature(att.Signatures, []byte(sig.PublicKey), []byte(sig.Blob)))
+		return sig, err
 	}
 
-	// handle signature extraction based on format
-	if format == "cyclonedx" {
+	if detectSBOMFormat(ctx, data) == "cyclonedx" {
 		log.Debug("CycloneDX SBOM detected, attempting to retrieve signature and public key from embedded SBOM")
-		standaloneSBOMFile, signatureRetrieved, publicKeyRetrieved, err := RetrieveSignatureFromSBOM(ctx, sbomFile)
+		sig, ok, err := RetrieveSignatureFromSBOM(ctx, data)
+		if err != nil {
+			log.Debugf("failed to retrieve signature and public key from embedded sbom: %v", err)
+		}
+		if ok {
+			return sig, nil
+		}
+	}
+
+	// The blob is only needed to verify a detached signature, and copying
+	// a large SBOM into it for nothing doubles its memory.
+	sig := sbom.Signature{}
+
+	if signature != "" {
+		sig.Blob = string(data)
+		value, err := os.ReadFile(signature)
 		if err != nil {
-			log.Debug("failed to retrieve signature and public key from embedded sbom: %w", err)
+			return sig, fmt.Errorf("failed to read signature %s: %w", signature, err)
 		}
-		return standaloneSBOMFile, signatureRetrieved, publicKeyRetrieved, nil
-	} else if format == "spdx" {
-		return sbomFile, signature, publicKey, nil
+		sig.SigValue = string(value)
 	}
-	log.Debugf("Unknown SBOM format: %s", format)
 
-	return "", "", "", nil
+	sig.PublicKey, err = readPublicKey(publicKey)
+	return sig, err
 }
 
-// detectSBOMFormat attempts to determine if the SBOM is SPDX or CycloneDX by inspecting the file
-func detectSBOMFormatDirectlyFromSBOMFile(ctx context.Context, path string) (string, error) {
-	log := logger.FromContext(ctx)
-	var content []byte
-	var err error
+// envelopeSignature picks the signature of an envelope to verify. An
+// envelope may be signed by several keys, so the first signature that
+// verifies against key is used, or the first signature if none does.
+func envelopeSignature(sigs [][]byte, key, pae []byte) []byte {
+	if len(key) > 0 {
+		for _, s := range sigs {
+			if ok, err := VerifySignature(key, pae, s); err == nil && ok {
+				return s
+			}
+		}
+	}
+	return sigs[0]
+}
 
-	content, err = os.ReadFile(path)
+// readPublicKey returns the contents of the public key file at path, or ""
+// if no path is given.
+func readPublicKey(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	key, err := os.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("failed to read file %s: %w", path, err)
+		return "", fmt.Errorf("failed to read public key %s: %w", path, err)
 	}
+	return string(key), nil
+}
 
-	// check for key fields:
-	contentStr := strings.ToLower(string(content))
-	if strings.Contains(contentStr, `"bomformat": "cyclonedx"`) || strings.Contains(contentStr, `"specversion"`) {
+// detectSBOMFormat determines if the SBOM is SPDX or CycloneDX by looking for their key fields
+func detectSBOMFormat(ctx context.Context, content []byte) string {
+	log := logger.FromContext(ctx)
+
+	switch sbom.DetectSpec(content) {
+	case sbom.SBOMSpecCDX:
 		log.Debugf("Detected CycloneDX SBOM")
-		return "cyclonedx", nil
-	}
-	if strings.Contains(contentStr, "spdxversion") || strings.Contains(contentStr, "spdxid") {
+		return "cyclonedx"
+	case sbom.SBOMSpecSPDX:
 		log.Debugf("Detected SPDX SBOM")
-		return "spdx", nil
+		return "spdx"
 	}
 
-	return "", nil
+	return ""
 }
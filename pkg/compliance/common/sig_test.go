@@ -0,0 +1,105 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSignatureBundleDSSE(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	pubPath := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	statement := `{"_type": "https://in-toto.io/Statement/v1", "subject": [], "predicateType": "https://spdx.dev/Document", "predicate": {"spdxVersion": "SPDX-2.3", "SPDXID": "SPDXRef-DOCUMENT"}}`
+	att := &sbom.Attestation{PayloadType: "application/vnd.in-toto+json", Payload: []byte(statement)}
+	sig, err := ecdsa.SignASN1(rand.Reader, key, HashSBOM(att.PAE()))
+	require.NoError(t, err)
+
+	envelope := func(payload string) []byte {
+		return []byte(`{"payloadType": "application/vnd.in-toto+json", "payload": "` +
+			base64.StdEncoding.EncodeToString([]byte(payload)) + `", "signatures": [{"sig": "` +
+			base64.StdEncoding.EncodeToString(sig) + `"}]}`)
+	}
+
+	bundle, err := GetSignatureBundle(context.Background(), envelope(statement), "", pubPath)
+	require.NoError(t, err)
+	valid, err := VerifySignature([]byte(bundle.PublicKey), []byte(bundle.Blob), []byte(bundle.SigValue))
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	tampered := `{"_type": "https://in-toto.io/Statement/v1", "subject": [], "predicateType": "https://spdx.dev/Document", "predicate": {"spdxVersion": "SPDX-2.2", "SPDXID": "SPDXRef-DOCUMENT"}}`
+	bundle, err = GetSignatureBundle(context.Background(), envelope(tampered), "", pubPath)
+	require.NoError(t, err)
+	valid, err = VerifySignature([]byte(bundle.PublicKey), []byte(bundle.Blob), []byte(bundle.SigValue))
+	assert.Error(t, err)
+	assert.False(t, valid)
+
+	// Without a key the signature is still collected, leaving it to the
+	// signature checks to report that it can't be verified.
+	bundle, err = GetSignatureBundle(context.Background(), envelope(statement), "", "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, bundle.SigValue)
+	assert.Empty(t, bundle.PublicKey)
+}
+
+func TestGetSignatureBundleDSSEMultipleSignatures(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	pubPath := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	statement := `{"_type": "https://in-toto.io/Statement/v1", "subject": [], "predicateType": "https://spdx.dev/Document", "predicate": {"spdxVersion": "SPDX-2.3", "SPDXID": "SPDXRef-DOCUMENT"}}`
+	att := &sbom.Attestation{PayloadType: "application/vnd.in-toto+json", Payload: []byte(statement)}
+	sigOther, err := ecdsa.SignASN1(rand.Reader, other, HashSBOM(att.PAE()))
+	require.NoError(t, err)
+	sigKey, err := ecdsa.SignASN1(rand.Reader, key, HashSBOM(att.PAE()))
+	require.NoError(t, err)
+
+	// the key given signed second
+	envelope := []byte(`{"payloadType": "application/vnd.in-toto+json", "payload": "` +
+		base64.StdEncoding.EncodeToString([]byte(statement)) + `", "signatures": [{"keyid": "other", "sig": "` +
+		base64.StdEncoding.EncodeToString(sigOther) + `"}, {"keyid": "release", "sig": "` +
+		base64.StdEncoding.EncodeToString(sigKey) + `"}]}`)
+
+	bundle, err := GetSignatureBundle(context.Background(), envelope, "", pubPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(sigKey), bundle.SigValue)
+	valid, err := VerifySignature([]byte(bundle.PublicKey), []byte(bundle.Blob), []byte(bundle.SigValue))
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/interlynk-io/sbomqs/pkg/baseline"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/fsct"
 	"github.com/interlynk-io/sbomqs/pkg/logger"
 	"github.com/interlynk-io/sbomqs/pkg/sbom"
@@ -43,8 +44,11 @@ func validReportTypes() map[string]bool {
 	}
 }
 
+// ComplianceResult reports the compliance of doc with reportType. The
+// failing component fields known to bl, if any, are passed.
+//
 //nolint:revive,stylecheck
-func ComplianceResult(ctx context.Context, doc sbom.Document, reportType, fileName, outFormat string, coloredOutput bool) error {
+func ComplianceResult(ctx context.Context, doc sbom.Document, reportType, fileName, outFormat string, coloredOutput bool, bl *baseline.Baseline) error {
 	log := logger.FromContext(ctx)
 	log.Debug("compliance.ComplianceResult()")
 
@@ -70,23 +74,23 @@ func ComplianceResult(ctx context.Context, doc sbom.Document, reportType, fileNa
 
 	switch {
 	case reportType == BSI_REPORT:
-		bsiResult(ctx, doc, fileName, outFormat, coloredOutput)
+		bsiResult(ctx, doc, fileName, outFormat, coloredOutput, bl)
 
 	case reportType == BSI_V2_REPORT:
-		bsiV2Result(ctx, doc, fileName, outFormat)
+		bsiV2Result(ctx, doc, fileName, outFormat, bl)
 
 	case reportType == NTIA_REPORT:
-		ntiaResult(ctx, doc, fileName, outFormat, coloredOutput)
+		ntiaResult(ctx, doc, fileName, outFormat, coloredOutput, bl)
 
 	case reportType == OCT_TELCO:
 		if doc.Spec().GetSpecType() != "spdx" {
 			fmt.Println("The Provided SBOM spec is other than SPDX. Open Chain Telco only support SPDX specs SBOMs.")
 			return nil
 		}
-		octResult(ctx, doc, fileName, outFormat, coloredOutput)
+		octResult(ctx, doc, fileName, outFormat, coloredOutput, bl)
 
 	case reportType == FSCT_V3:
-		fsct.Result(ctx, doc, fileName, outFormat, coloredOutput)
+		fsct.Result(ctx, doc, fileName, outFormat, coloredOutput, bl)
 
 	default:
 		fmt.Println("No compliance type is provided")
@@ -18,6 +18,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/interlynk-io/sbomqs/pkg/baseline"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
 	"github.com/interlynk-io/sbomqs/pkg/logger"
@@ -25,7 +26,7 @@ import (
 	"github.com/samber/lo"
 )
 
-func Result(ctx context.Context, doc sbom.Document, fileName string, outFormat string, coloredOutput bool) {
+func Result(ctx context.Context, doc sbom.Document, fileName string, outFormat string, coloredOutput bool, bl *baseline.Baseline) {
 	log := logger.FromContext(ctx)
 	log.Debug("fsct compliance")
 
@@ -39,11 +40,24 @@ func Result(ctx context.Context, doc sbom.Document, fileName string, outFormat s
 
 	// component Level
 	dtb.AddRecords(Components(doc))
+	common.ApplyBaseline(dtb, doc, bl, "FSCT", fsctDataFields())
 
 	if outFormat == "json" {
 		fsctJSONReport(dtb, fileName)
 	}
 
+	if outFormat == "sarif" {
+		fsctSarifReport(dtb, doc, fileName)
+	}
+
+	if outFormat == "junit" {
+		fsctJUnitReport(dtb, doc, fileName)
+	}
+
+	if outFormat == "html" {
+		fsctHTMLReport(dtb, doc, fileName)
+	}
+
 	if outFormat == "basic" {
 		fsctBasicReport(dtb, fileName)
 	}
@@ -183,7 +197,7 @@ func Components(doc sbom.Document) []*db.Record {
 		records = append(records, fsctPackageSupplier(component))
 		records = append(records, fsctPackageUniqIDs(component))
 		records = append(records, fsctPackageHash(doc, component))
-		records = append(records, fsctPackageDependencies(doc, component))
+		records = append(records, common.WithComposition(fsctPackageDependencies(doc, component), component))
 		records = append(records, fsctPackageLicense(component))
 		records = append(records, fsctPackageCopyright(component))
 	}
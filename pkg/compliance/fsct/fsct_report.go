@@ -24,7 +24,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
 	"github.com/olekukonko/tablewriter"
+	"github.com/samber/lo"
 	"sigs.k8s.io/release-utils/version"
 )
 
@@ -59,6 +61,20 @@ var fsctSectionDetails = map[int]fsctSection{
 	COMP_COPYRIGHT:         {Title: "Component Level", ID: "2.2.2.8", Required: true, DataField: "Component Copyright"},
 }
 
+// fsctDataFields names the data field of each check, for baselines.
+func fsctDataFields() map[int]string {
+	return lo.MapValues(fsctSectionDetails, func(s fsctSection, _ int) string {
+		return s.DataField
+	})
+}
+
+// fsctChecks describes each check for SARIF and JUnit reports.
+func fsctChecks() map[int]common.Check {
+	return lo.MapValues(fsctSectionDetails, func(s fsctSection, _ int) common.Check {
+		return common.Check{ID: s.ID, Title: s.Title, DataField: s.DataField, Required: s.Required}
+	})
+}
+
 type fsctSection struct {
 	Title         string  `json:"section_title"`
 	ID            string  `json:"section_id"`
@@ -134,6 +150,27 @@ func fsctJSONReport(db *db.DB, fileName string) {
 	fmt.Println(string(o))
 }
 
+func fsctSarifReport(db *db.DB, doc sbom.Document, fileName string) {
+	if err := common.SarifReport(os.Stdout, db, doc, fileName, "FSCT", fsctChecks()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print sarif report: %v\n", err)
+	}
+}
+
+func fsctJUnitReport(db *db.DB, doc sbom.Document, fileName string) {
+	if err := common.JUnitReport(os.Stdout, db, doc, fileName, "FSCT", fsctChecks()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print junit report: %v\n", err)
+	}
+}
+
+func fsctHTMLReport(db *db.DB, doc sbom.Document, fileName string) {
+	score := fsctAggregateScore(db)
+	scores := common.Scores{Total: score.totalScore(), Required: score.totalRequiredScore(), Optional: score.totalOptionalScore()}
+
+	if err := common.HTMLReport(os.Stdout, db, doc, fileName, "Framing Software Component Transparency (v3)", fsctChecks(), scores); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print html report: %v\n", err)
+	}
+}
+
 func fsctConstructSections(db *db.DB) []fsctSection {
 	var sections []fsctSection
 	allIDs := db.GetAllIDs()
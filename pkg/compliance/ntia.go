@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/interlynk-io/sbomqs/pkg/baseline"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	db "github.com/interlynk-io/sbomqs/pkg/compliance/db"
 	"github.com/interlynk-io/sbomqs/pkg/logger"
@@ -38,7 +39,7 @@ const (
 	SCORE_ZERO = 0.0
 )
 
-func ntiaResult(ctx context.Context, doc sbom.Document, fileName string, outFormat string, colorOutput bool) {
+func ntiaResult(ctx context.Context, doc sbom.Document, fileName string, outFormat string, colorOutput bool, bl *baseline.Baseline) {
 	log := logger.FromContext(ctx)
 	log.Debug("compliance.ntiaResult()")
 
@@ -49,11 +50,24 @@ func ntiaResult(ctx context.Context, doc sbom.Document, fileName string, outForm
 	db.AddRecord(ntiaSbomCreatedTimestamp(doc))
 	db.AddRecord(ntiaSBOMDependency(doc))
 	db.AddRecords(ntiaComponents(doc))
+	common.ApplyBaseline(db, doc, bl, NTIA_REPORT, ntiaDataFields())
 
 	if outFormat == "json" {
 		ntiaJSONReport(db, fileName)
 	}
 
+	if outFormat == "sarif" {
+		ntiaSarifReport(db, doc, fileName)
+	}
+
+	if outFormat == "junit" {
+		ntiaJUnitReport(db, doc, fileName)
+	}
+
+	if outFormat == "html" {
+		ntiaHTMLReport(db, doc, fileName)
+	}
+
 	if outFormat == "basic" {
 		ntiaBasicReport(db, fileName)
 	}
@@ -250,7 +264,7 @@ func ntiaComponents(doc sbom.Document) []*db.Record {
 		records = append(records, ntiaComponentCreator(doc, component))
 		records = append(records, ntiaComponentVersion(component))
 		records = append(records, ntiaComponentOtherUniqIDs(doc, component))
-		records = append(records, ntiaComponentDependencies(doc, component))
+		records = append(records, common.WithComposition(ntiaComponentDependencies(doc, component), component))
 	}
 	return records
 }
@@ -24,7 +24,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
 	"github.com/olekukonko/tablewriter"
+	"github.com/samber/lo"
 	"sigs.k8s.io/release-utils/version"
 )
 
@@ -41,6 +43,20 @@ var ntiaSectionDetails = map[int]ntiaSection{
 	COMP_OTHER_UNIQ_IDS: {Title: "Required fields component", ID: "2.8", Required: true, DataField: "Other Uniq IDs"},
 }
 
+// ntiaDataFields names the data field of each check, for baselines.
+func ntiaDataFields() map[int]string {
+	return lo.MapValues(ntiaSectionDetails, func(s ntiaSection, _ int) string {
+		return s.DataField
+	})
+}
+
+// ntiaChecks describes each check for SARIF and JUnit reports.
+func ntiaChecks() map[int]common.Check {
+	return lo.MapValues(ntiaSectionDetails, func(s ntiaSection, _ int) common.Check {
+		return common.Check{ID: s.ID, Title: s.Title, DataField: s.DataField, Required: s.Required}
+	})
+}
+
 type ntiaSection struct {
 	Title         string  `json:"section_title"`
 	ID            string  `json:"section_id"`
@@ -98,6 +114,27 @@ func ntiaJSONReport(db *db.DB, fileName string) {
 	fmt.Println(string(o))
 }
 
+func ntiaSarifReport(db *db.DB, doc sbom.Document, fileName string) {
+	if err := common.SarifReport(os.Stdout, db, doc, fileName, NTIA_REPORT, ntiaChecks()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print sarif report: %v\n", err)
+	}
+}
+
+func ntiaJUnitReport(db *db.DB, doc sbom.Document, fileName string) {
+	if err := common.JUnitReport(os.Stdout, db, doc, fileName, NTIA_REPORT, ntiaChecks()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print junit report: %v\n", err)
+	}
+}
+
+func ntiaHTMLReport(db *db.DB, doc sbom.Document, fileName string) {
+	score := ntiaAggregateScore(db)
+	scores := common.Scores{Total: score.totalScore(), Required: score.totalRequiredScore(), Optional: score.totalOptionalScore()}
+
+	if err := common.HTMLReport(os.Stdout, db, doc, fileName, "NTIA-minimum elements Compliance Report", ntiaChecks(), scores); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print html report: %v\n", err)
+	}
+}
+
 func ntiaConstructSections(db *db.DB) []ntiaSection {
 	var sections []ntiaSection
 	allIDs := db.GetAllIDs()
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/interlynk-io/sbomqs/pkg/baseline"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	db "github.com/interlynk-io/sbomqs/pkg/compliance/db"
 	"github.com/interlynk-io/sbomqs/pkg/logger"
@@ -27,7 +28,7 @@ import (
 	"github.com/samber/lo"
 )
 
-func octResult(ctx context.Context, doc sbom.Document, fileName string, outFormat string, colorOutput bool) {
+func octResult(ctx context.Context, doc sbom.Document, fileName string, outFormat string, colorOutput bool, bl *baseline.Baseline) {
 	log := logger.FromContext(ctx)
 	log.Debug("compliance.octResult()")
 	dtb := db.NewDB()
@@ -48,11 +49,24 @@ func octResult(ctx context.Context, doc sbom.Document, fileName string, outForma
 	dtb.AddRecord(octSbomDeliveryTime(doc))
 	dtb.AddRecord(octSbomDeliveryMethod(doc))
 	dtb.AddRecord(octSbomScope(doc))
+	common.ApplyBaseline(dtb, doc, bl, OCT_TELCO, octDataFields())
 
 	if outFormat == "json" {
 		octJSONReport(dtb, fileName)
 	}
 
+	if outFormat == "sarif" {
+		octSarifReport(dtb, doc, fileName)
+	}
+
+	if outFormat == "junit" {
+		octJUnitReport(dtb, doc, fileName)
+	}
+
+	if outFormat == "html" {
+		octHTMLReport(dtb, doc, fileName)
+	}
+
 	if outFormat == "basic" {
 		octBasicReport(dtb, fileName)
 	}
@@ -24,7 +24,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/db"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
 	"github.com/olekukonko/tablewriter"
+	"github.com/samber/lo"
 	"sigs.k8s.io/release-utils/version"
 )
 
@@ -60,6 +62,20 @@ var octSectionDetails = map[int]octSection{
 	PACK_EXT_REF:       {Title: "SPDX Elements", ID: "3.2.12", Required: true, DataField: "Package external References"},
 }
 
+// octDataFields names the data field of each check, for baselines.
+func octDataFields() map[int]string {
+	return lo.MapValues(octSectionDetails, func(s octSection, _ int) string {
+		return s.DataField
+	})
+}
+
+// octChecks describes each check for SARIF and JUnit reports.
+func octChecks() map[int]common.Check {
+	return lo.MapValues(octSectionDetails, func(s octSection, _ int) common.Check {
+		return common.Check{ID: s.ID, Title: s.Title, DataField: s.DataField, Required: s.Required}
+	})
+}
+
 type octSection struct {
 	Title         string  `json:"section_title"`
 	ID            string  `json:"section_id"`
@@ -117,6 +133,27 @@ func octJSONReport(dtb *db.DB, fileName string) {
 	fmt.Println(string(o))
 }
 
+func octSarifReport(dtb *db.DB, doc sbom.Document, fileName string) {
+	if err := common.SarifReport(os.Stdout, dtb, doc, fileName, OCT_TELCO, octChecks()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print sarif report: %v\n", err)
+	}
+}
+
+func octJUnitReport(dtb *db.DB, doc sbom.Document, fileName string) {
+	if err := common.JUnitReport(os.Stdout, dtb, doc, fileName, OCT_TELCO, octChecks()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print junit report: %v\n", err)
+	}
+}
+
+func octHTMLReport(dtb *db.DB, doc sbom.Document, fileName string) {
+	score := octAggregateScore(dtb)
+	scores := common.Scores{Total: score.totalScore(), Required: score.totalRequiredScore(), Optional: score.totalOptionalScore()}
+
+	if err := common.HTMLReport(os.Stdout, dtb, doc, fileName, "Open Chain Telco Report", octChecks(), scores); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print html report: %v\n", err)
+	}
+}
+
 func octConstructSections(dtb *db.DB) []octSection {
 	var sections []octSection
 	allIDs := dtb.GetAllIDs()
@@ -0,0 +1,421 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff compares the quality of two versions of an SBOM: how the
+// scores moved, which components were added, removed or changed, and which
+// components lost a feature they used to have.
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/cpe"
+	"github.com/interlynk-io/sbomqs/pkg/graph"
+	"github.com/interlynk-io/sbomqs/pkg/licenses"
+	"github.com/interlynk-io/sbomqs/pkg/list"
+	"github.com/interlynk-io/sbomqs/pkg/purl"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+	"github.com/package-url/packageurl-go"
+	"github.com/samber/lo"
+)
+
+// tolerance is the smallest change of a score that counts, smaller ones are
+// rounding noise.
+const tolerance = 1e-6
+
+// Delta is a score in both documents. Old or New is nil if the score is
+// missing or N/A in that document.
+type Delta struct {
+	Old   *float64 `json:"old"`
+	New   *float64 `json:"new"`
+	Delta *float64 `json:"delta"`
+}
+
+func newDelta(old, new *float64) Delta {
+	d := Delta{Old: old, New: new}
+	if old != nil && new != nil {
+		d.Delta = lo.ToPtr(*new - *old)
+	}
+	return d
+}
+
+// Regressed reports whether the score dropped.
+func (d Delta) Regressed() bool {
+	return d.Delta != nil && *d.Delta < -tolerance
+}
+
+// Changed reports whether the score moved, appeared or went away.
+func (d Delta) Changed() bool {
+	if d.Delta == nil {
+		return d.Old != nil || d.New != nil
+	}
+	return *d.Delta > tolerance || *d.Delta < -tolerance
+}
+
+type CategoryDelta struct {
+	Category string `json:"category"`
+	Delta
+}
+
+type FeatureDelta struct {
+	Category string `json:"category"`
+	Feature  string `json:"feature"`
+	Delta
+}
+
+// Component identifies a component in the report.
+type Component struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Purl    string `json:"purl,omitempty"`
+}
+
+func (c Component) String() string {
+	if c.Version == "" {
+		return c.Name
+	}
+	return c.Name + "@" + c.Version
+}
+
+// ComponentChange is a component of both documents whose fields differ.
+type ComponentChange struct {
+	Component
+	Fields []string `json:"fields"`
+}
+
+// FeatureFailure is a component of both documents which had a feature in
+// the old one and hasn't in the new one.
+type FeatureFailure struct {
+	Component
+	Feature string `json:"feature"`
+}
+
+type Result struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+
+	Score      Delta           `json:"score"`
+	Categories []CategoryDelta `json:"categories"`
+	Features   []FeatureDelta  `json:"features"`
+
+	Added   []Component       `json:"added"`
+	Removed []Component       `json:"removed"`
+	Changed []ComponentChange `json:"changed"`
+
+	NewlyFailing []FeatureFailure `json:"newly_failing"`
+}
+
+// Regressed reports whether the new document is worse than the old one:
+// its score or the score of a feature dropped, or a component lost a
+// feature.
+func (r *Result) Regressed() bool {
+	if r.Score.Regressed() || len(r.NewlyFailing) > 0 {
+		return true
+	}
+	return lo.SomeBy(r.Features, func(f FeatureDelta) bool {
+		return f.Regressed()
+	})
+}
+
+// Compare compares the new document with the old one, both scored with the
+// same filters.
+func Compare(oldPath, newPath string, oldDoc, newDoc sbom.Document, oldScores, newScores scorer.Scores) *Result {
+	r := &Result{
+		Old:   oldPath,
+		New:   newPath,
+		Score: newDelta(lo.ToPtr(oldScores.AvgScore()), lo.ToPtr(newScores.AvgScore())),
+
+		Added:        []Component{},
+		Removed:      []Component{},
+		Changed:      []ComponentChange{},
+		NewlyFailing: []FeatureFailure{},
+	}
+
+	r.compareCategories(oldScores, newScores)
+	r.compareFeatures(oldScores, newScores)
+	r.compareComponents(oldDoc, newDoc, componentFeatures(oldScores, newScores))
+
+	return r
+}
+
+func (r *Result) compareCategories(oldScores, newScores scorer.Scores) {
+	old := map[string]*float64{}
+	var names []string
+	for _, c := range oldScores.CategoryScores() {
+		old[c.Category()] = lo.ToPtr(c.Score())
+		names = append(names, c.Category())
+	}
+
+	seen := map[string]bool{}
+	for _, c := range newScores.CategoryScores() {
+		seen[c.Category()] = true
+		r.Categories = append(r.Categories, CategoryDelta{c.Category(), newDelta(old[c.Category()], lo.ToPtr(c.Score()))})
+	}
+	for _, name := range names {
+		if !seen[name] {
+			r.Categories = append(r.Categories, CategoryDelta{name, newDelta(old[name], nil)})
+		}
+	}
+}
+
+// featureKey identifies a feature score, a feature can be scored in more
+// than one category.
+type featureKey struct {
+	category string
+	feature  string
+}
+
+func scoreOf(s scorer.Score) *float64 {
+	if s.Ignore() {
+		return nil
+	}
+	return lo.ToPtr(s.Score())
+}
+
+func (r *Result) compareFeatures(oldScores, newScores scorer.Scores) {
+	old := map[featureKey]*float64{}
+	var keys []featureKey
+	for _, s := range oldScores.ScoreList() {
+		k := featureKey{s.Category(), s.Feature()}
+		old[k] = scoreOf(s)
+		keys = append(keys, k)
+	}
+
+	seen := map[featureKey]bool{}
+	for _, s := range newScores.ScoreList() {
+		k := featureKey{s.Category(), s.Feature()}
+		seen[k] = true
+		r.Features = append(r.Features, FeatureDelta{k.category, k.feature, newDelta(old[k], scoreOf(s))})
+	}
+	for _, k := range keys {
+		if !seen[k] {
+			r.Features = append(r.Features, FeatureDelta{k.category, k.feature, newDelta(old[k], nil)})
+		}
+	}
+}
+
+// componentFeatures returns the component features scored in either
+// document.
+func componentFeatures(oldScores, newScores scorer.Scores) []string {
+	var features []string
+	for _, s := range append(oldScores.ScoreList(), newScores.ScoreList()...) {
+		if strings.HasPrefix(s.Feature(), "comp_") {
+			features = append(features, s.Feature())
+		}
+	}
+	return lo.Uniq(features)
+}
+
+func componentOf(c sbom.GetComponent) Component {
+	comp := Component{Name: c.GetName(), Version: c.GetVersion()}
+	if purls := c.GetPurls(); len(purls) > 0 {
+		comp.Purl = purls[0].String()
+	}
+	return comp
+}
+
+func nameVersion(c sbom.GetComponent) string {
+	return c.GetName() + "@" + c.GetVersion()
+}
+
+// matchKeys are the ways a component is identified, most precise first.
+var matchKeys = []func(c sbom.GetComponent) []string{
+	func(c sbom.GetComponent) []string {
+		return lo.Map(c.GetPurls(), func(p purl.PURL, _ int) string { return p.String() })
+	},
+	func(c sbom.GetComponent) []string {
+		return lo.Map(c.GetPurls(), func(p purl.PURL, _ int) string { return versionless(p) })
+	},
+	func(c sbom.GetComponent) []string {
+		return []string{nameVersion(c)}
+	},
+	// components with a purl were matched by it
+	func(c sbom.GetComponent) []string {
+		if len(c.GetPurls()) > 0 {
+			return nil
+		}
+		return []string{c.GetName()}
+	},
+}
+
+// versionless returns p without its version, so a component keeps its
+// identity across an upgrade.
+func versionless(p purl.PURL) string {
+	parsed, err := packageurl.FromString(p.String())
+	if err != nil {
+		return p.String()
+	}
+	parsed.Version = ""
+	return parsed.ToString()
+}
+
+// match pairs the components of the new document with those of the old one,
+// by purl, by purl without its version, by name and version and finally by
+// name for components without a purl. Each key is tried for every component before the next, less precise
+// one, and components sharing a key are paired in document order. It returns
+// the pairs and the components of each document left without one.
+func match(oldComps, newComps []sbom.GetComponent) ([][2]sbom.GetComponent, []sbom.GetComponent, []sbom.GetComponent) {
+	oldMatched := make([]bool, len(oldComps))
+	newMatched := make([]bool, len(newComps))
+	pairOf := make([]int, len(newComps))
+
+	for _, keys := range matchKeys {
+		candidates := map[string][]int{}
+		for i, c := range oldComps {
+			if oldMatched[i] {
+				continue
+			}
+			for _, k := range lo.Uniq(keys(c)) {
+				if k != "" {
+					candidates[k] = append(candidates[k], i)
+				}
+			}
+		}
+
+		for j, c := range newComps {
+			if newMatched[j] {
+				continue
+			}
+			for _, k := range keys(c) {
+				i, ok := lo.Find(candidates[k], func(i int) bool { return !oldMatched[i] })
+				if !ok {
+					continue
+				}
+				oldMatched[i], newMatched[j] = true, true
+				pairOf[j] = i
+				break
+			}
+		}
+	}
+
+	var pairs [][2]sbom.GetComponent
+	var added []sbom.GetComponent
+	for j, c := range newComps {
+		if !newMatched[j] {
+			added = append(added, c)
+			continue
+		}
+		pairs = append(pairs, [2]sbom.GetComponent{oldComps[pairOf[j]], c})
+	}
+
+	removed := lo.Filter(oldComps, func(_ sbom.GetComponent, i int) bool {
+		return !oldMatched[i]
+	})
+
+	return pairs, added, removed
+}
+
+func (r *Result) compareComponents(oldDoc, newDoc sbom.Document, features []string) {
+	pairs, added, removed := match(oldDoc.Components(), newDoc.Components())
+
+	for _, c := range added {
+		r.Added = append(r.Added, componentOf(c))
+	}
+	for _, c := range removed {
+		r.Removed = append(r.Removed, componentOf(c))
+	}
+
+	oldGraph, newGraph := graph.New(oldDoc), graph.New(newDoc)
+	for _, p := range pairs {
+		if fields := changedFields(p[0], p[1]); len(fields) > 0 {
+			r.Changed = append(r.Changed, ComponentChange{componentOf(p[1]), fields})
+		}
+
+		for _, feature := range features {
+			if has(feature, p[0], oldDoc, oldGraph) && !has(feature, p[1], newDoc, newGraph) {
+				r.NewlyFailing = append(r.NewlyFailing, FeatureFailure{componentOf(p[1]), feature})
+			}
+		}
+	}
+}
+
+// has reports whether c passes feature. Features list can't evaluate per
+// component are passed.
+func has(feature string, c sbom.GetComponent, doc sbom.Document, g *graph.Graph) bool {
+	ok, err := list.ComponentPasses(feature, c, doc, g)
+	return ok || err != nil
+}
+
+// changedFields returns the names of the fields which differ between the
+// two versions of a component.
+func changedFields(old, new sbom.GetComponent) []string {
+	var fields []string
+
+	if old.GetVersion() != new.GetVersion() {
+		fields = append(fields, "version")
+	}
+	if supplierOf(old) != supplierOf(new) {
+		fields = append(fields, "supplier")
+	}
+	if !sameSet(licenseNames(old), licenseNames(new)) {
+		fields = append(fields, "licenses")
+	}
+	if !sameSet(checksums(old), checksums(new)) {
+		fields = append(fields, "checksums")
+	}
+	if !sameSet(purls(old), purls(new)) {
+		fields = append(fields, "purls")
+	}
+	if !sameSet(cpes(old), cpes(new)) {
+		fields = append(fields, "cpes")
+	}
+
+	return fields
+}
+
+func supplierOf(c sbom.GetComponent) string {
+	if s := c.Suppliers(); s != nil && s.IsPresent() {
+		return s.GetName() + "," + s.GetEmail()
+	}
+	return ""
+}
+
+func licenseNames(c sbom.GetComponent) []string {
+	return lo.FilterMap(c.Licenses(), func(l licenses.License, _ int) (string, bool) {
+		if l == nil {
+			return "", false
+		}
+		return l.Name(), true
+	})
+}
+
+func checksums(c sbom.GetComponent) []string {
+	return lo.Map(c.GetChecksums(), func(cs sbom.GetChecksum, _ int) string {
+		return cs.GetAlgo() + ":" + cs.GetContent()
+	})
+}
+
+func purls(c sbom.GetComponent) []string {
+	return lo.Map(c.GetPurls(), func(p purl.PURL, _ int) string {
+		return p.String()
+	})
+}
+
+func cpes(c sbom.GetComponent) []string {
+	return lo.Map(c.GetCpes(), func(p cpe.CPE, _ int) string {
+		return p.String()
+	})
+}
+
+func sameSet(a, b []string) bool {
+	a, b = lo.Uniq(a), lo.Uniq(b)
+	if len(a) != len(b) {
+		return false
+	}
+	sort.Strings(a)
+	sort.Strings(b)
+	return lo.Every(a, b)
+}
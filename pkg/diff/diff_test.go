@@ -0,0 +1,169 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oldSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"type": "application", "bom-ref": "app", "name": "app", "version": "1.0"}},
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "version": "1.0", "purl": "pkg:golang/a",
+      "supplier": {"name": "Acme"}},
+    {"type": "library", "bom-ref": "b", "name": "b", "version": "1.0",
+      "hashes": [{"alg": "SHA-256", "content": "7ad3fbba6e9f4b4fdc57c08eb1e0f6e8c1e67e8f9cb2d3f2a4b0b7e6d1f1a3c9"}]},
+    {"type": "library", "bom-ref": "c", "name": "c", "version": "1.0"}
+  ],
+  "dependencies": [{"ref": "app", "dependsOn": ["a", "b", "c"]}]
+}`
+
+// a is upgraded and lost its supplier, b lost its checksum, c was replaced
+// by d
+const newSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"type": "application", "bom-ref": "app", "name": "app", "version": "1.0"}},
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "version": "2.0", "purl": "pkg:golang/a"},
+    {"type": "library", "bom-ref": "b", "name": "b", "version": "1.0"},
+    {"type": "library", "bom-ref": "d", "name": "d", "version": "1.0"}
+  ],
+  "dependencies": [{"ref": "app", "dependsOn": ["a", "b", "d"]}]
+}`
+
+func score(t *testing.T, input string) (sbom.Document, scorer.Scores) {
+	t.Helper()
+	doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(input), sbom.Signature{})
+	require.NoError(t, err)
+
+	return doc, scorer.NewScorer(context.Background(), doc).Score()
+}
+
+func TestCompare(t *testing.T) {
+	oldDoc, oldScores := score(t, oldSBOM)
+	newDoc, newScores := score(t, newSBOM)
+
+	r := Compare("old.json", "new.json", oldDoc, newDoc, oldScores, newScores)
+
+	assert.Equal(t, []Component{{Name: "d", Version: "1.0"}}, r.Added)
+	assert.Equal(t, []Component{{Name: "c", Version: "1.0"}}, r.Removed)
+	assert.Equal(t, []ComponentChange{
+		{Component{Name: "a", Version: "2.0", Purl: "pkg:golang/a"}, []string{"version", "supplier"}},
+		{Component{Name: "b", Version: "1.0"}, []string{"checksums"}},
+	}, r.Changed)
+
+	assert.Contains(t, r.NewlyFailing, FeatureFailure{Component{Name: "a", Version: "2.0", Purl: "pkg:golang/a"}, "comp_with_supplier"})
+	assert.Contains(t, r.NewlyFailing, FeatureFailure{Component{Name: "b", Version: "1.0"}, "comp_with_checksums"})
+
+	assert.True(t, r.Regressed())
+	assert.True(t, r.Score.Regressed())
+}
+
+func TestCompareSame(t *testing.T) {
+	doc, scores := score(t, oldSBOM)
+
+	r := Compare("a.json", "a.json", doc, doc, scores, scores)
+
+	assert.Empty(t, r.Added)
+	assert.Empty(t, r.Removed)
+	assert.Empty(t, r.Changed)
+	assert.Empty(t, r.NewlyFailing)
+	assert.False(t, r.Regressed())
+}
+
+func TestMatchVersionedPurls(t *testing.T) {
+	doc := func(components string) sbom.Document {
+		d, _ := score(t, `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [`+components+`]
+}`)
+		return d
+	}
+
+	oldDoc := doc(`
+    {"type": "library", "bom-ref": "x1", "name": "x", "version": "1.0", "purl": "pkg:npm/x@1.0"},
+    {"type": "library", "bom-ref": "x2", "name": "x", "version": "1.0", "purl": "pkg:npm/x@1.0"},
+    {"type": "library", "bom-ref": "y", "name": "y", "version": "1.0", "purl": "pkg:npm/y@1.0?arch=amd64"}`)
+	newDoc := doc(`
+    {"type": "library", "bom-ref": "y", "name": "y", "version": "1.1", "purl": "pkg:npm/y@1.1?arch=amd64"},
+    {"type": "library", "bom-ref": "x1", "name": "x", "version": "1.0", "purl": "pkg:npm/x@1.0"},
+    {"type": "library", "bom-ref": "x2", "name": "x", "version": "2.0", "purl": "pkg:npm/x@2.0"}`)
+
+	pairs, added, removed := match(oldDoc.Components(), newDoc.Components())
+
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+	require.Len(t, pairs, 3)
+	for _, p := range pairs {
+		assert.Equal(t, p[0].GetName(), p[1].GetName())
+	}
+	assert.Equal(t, "1.0", pairs[1][0].GetVersion(), "the exact purl is matched first")
+	assert.Equal(t, "2.0", pairs[2][1].GetVersion())
+}
+
+func TestDelta(t *testing.T) {
+	one, two := 1.0, 2.0
+
+	tests := []struct {
+		name      string
+		delta     Delta
+		regressed bool
+		changed   bool
+	}{
+		{"improved", newDelta(&one, &two), false, true},
+		{"regressed", newDelta(&two, &one), true, true},
+		{"unchanged", newDelta(&one, &one), false, false},
+		{"not applicable anymore", newDelta(&one, nil), false, true},
+		{"never applicable", newDelta(nil, nil), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.regressed, tt.delta.Regressed())
+			assert.Equal(t, tt.changed, tt.delta.Changed())
+		})
+	}
+}
+
+func TestJSONReport(t *testing.T) {
+	oldDoc, oldScores := score(t, oldSBOM)
+	newDoc, newScores := score(t, newSBOM)
+	r := Compare("old.json", "new.json", oldDoc, newDoc, oldScores, newScores)
+
+	var buf bytes.Buffer
+	NewDiffReport(r, WithFormat("json"), WithWriter(&buf)).Report()
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, true, out["regressed"])
+	assert.Equal(t, "old.json", out["old"])
+	assert.Len(t, out["newly_failing"], 3)
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+	"github.com/olekukonko/tablewriter"
+	"sigs.k8s.io/release-utils/version"
+)
+
+type Option func(r *Report)
+
+func NewDiffReport(result *Result, opts ...Option) *Report {
+	r := &Report{
+		Result: result,
+		Out:    os.Stdout,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func WithFormat(f string) Option {
+	return func(r *Report) {
+		r.Format = f
+	}
+}
+
+func WithWriter(w io.Writer) Option {
+	return func(r *Report) {
+		r.Out = w
+	}
+}
+
+// Report holds the state for reporting the diff command results
+type Report struct {
+	Result *Result
+	Format string
+	Out    io.Writer
+}
+
+// Report renders the diff command results in the specified format
+func (r *Report) Report() {
+	switch r.Format {
+	case "json":
+		r.jsonReport()
+	case "markdown":
+		r.markdownReport()
+	default:
+		r.detailedReport()
+	}
+}
+
+func formatScore(f *float64) string {
+	if f == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%0.1f", *f)
+}
+
+func formatDelta(d Delta) string {
+	if d.Delta == nil {
+		return "N/A"
+	}
+	// don't show a sign for a change too small to show
+	if math.Abs(*d.Delta) < 0.05 {
+		return "0.0"
+	}
+	return fmt.Sprintf("%+0.1f", *d.Delta)
+}
+
+// changedFeatures returns the features whose score moved.
+func (r *Report) changedFeatures() []FeatureDelta {
+	var features []FeatureDelta
+	for _, f := range r.Result.Features {
+		if f.Changed() {
+			features = append(features, f)
+		}
+	}
+	return features
+}
+
+func (r *Report) detailedReport() {
+	res := r.Result
+	fmt.Fprintf(r.Out, "Old: %s\nNew: %s\n", res.Old, res.New)
+	fmt.Fprintf(r.Out, "Score: %s -> %s (%s)\n\n", formatScore(res.Score.Old), formatScore(res.Score.New), formatDelta(res.Score))
+
+	table := tablewriter.NewWriter(r.Out)
+	table.SetHeader([]string{"Category", "Old", "New", "Delta"})
+	for _, c := range res.Categories {
+		table.Append([]string{c.Category, formatScore(c.Old), formatScore(c.New), formatDelta(c.Delta)})
+	}
+	table.Render()
+
+	if features := r.changedFeatures(); len(features) > 0 {
+		fmt.Fprintln(r.Out, "\nChanged features")
+		table = tablewriter.NewWriter(r.Out)
+		table.SetHeader([]string{"Category", "Feature", "Old", "New", "Delta"})
+		table.SetAutoMergeCellsByColumnIndex([]int{0})
+		for _, f := range features {
+			table.Append([]string{f.Category, f.Feature, formatScore(f.Old), formatScore(f.New), formatDelta(f.Delta)})
+		}
+		table.Render()
+	}
+
+	fmt.Fprintf(r.Out, "\nComponents: %d added, %d removed, %d changed\n", len(res.Added), len(res.Removed), len(res.Changed))
+	if len(res.Added)+len(res.Removed)+len(res.Changed) > 0 {
+		table = tablewriter.NewWriter(r.Out)
+		table.SetHeader([]string{"Change", "Component", "Fields"})
+		table.SetAutoMergeCellsByColumnIndex([]int{0})
+		for _, c := range res.Added {
+			table.Append([]string{"added", c.String(), ""})
+		}
+		for _, c := range res.Removed {
+			table.Append([]string{"removed", c.String(), ""})
+		}
+		for _, c := range res.Changed {
+			table.Append([]string{"changed", c.String(), strings.Join(c.Fields, ", ")})
+		}
+		table.Render()
+	}
+
+	if len(res.NewlyFailing) > 0 {
+		fmt.Fprintln(r.Out, "\nNewly failing components")
+		table = tablewriter.NewWriter(r.Out)
+		table.SetHeader([]string{"Feature", "Component"})
+		table.SetAutoMergeCellsByColumnIndex([]int{0})
+		for _, f := range res.NewlyFailing {
+			table.Append([]string{f.Feature, f.Component.String()})
+		}
+		table.Render()
+	}
+}
+
+func (r *Report) markdownReport() {
+	res := r.Result
+	fmt.Fprintf(r.Out, "## SBOM quality diff\n\n")
+	fmt.Fprintf(r.Out, "`%s` → `%s`\n\n", res.Old, res.New)
+	fmt.Fprintf(r.Out, "**Score:** %s → %s (%s)\n\n", formatScore(res.Score.Old), formatScore(res.Score.New), formatDelta(res.Score))
+
+	fmt.Fprintf(r.Out, "| Category | Old | New | Delta |\n|---|---|---|---|\n")
+	for _, c := range res.Categories {
+		fmt.Fprintf(r.Out, "| %s | %s | %s | %s |\n", c.Category, formatScore(c.Old), formatScore(c.New), formatDelta(c.Delta))
+	}
+
+	if features := r.changedFeatures(); len(features) > 0 {
+		fmt.Fprintf(r.Out, "\n### Changed features\n\n| Category | Feature | Old | New | Delta |\n|---|---|---|---|---|\n")
+		for _, f := range features {
+			fmt.Fprintf(r.Out, "| %s | `%s` | %s | %s | %s |\n", f.Category, f.Feature, formatScore(f.Old), formatScore(f.New), formatDelta(f.Delta))
+		}
+	}
+
+	fmt.Fprintf(r.Out, "\n### Components\n\n%d added, %d removed, %d changed\n", len(res.Added), len(res.Removed), len(res.Changed))
+	if len(res.Added)+len(res.Removed)+len(res.Changed) > 0 {
+		fmt.Fprintf(r.Out, "\n| Change | Component | Fields |\n|---|---|---|\n")
+		for _, c := range res.Added {
+			fmt.Fprintf(r.Out, "| added | %s | |\n", c)
+		}
+		for _, c := range res.Removed {
+			fmt.Fprintf(r.Out, "| removed | %s | |\n", c)
+		}
+		for _, c := range res.Changed {
+			fmt.Fprintf(r.Out, "| changed | %s | %s |\n", c, strings.Join(c.Fields, ", "))
+		}
+	}
+
+	if len(res.NewlyFailing) > 0 {
+		fmt.Fprintf(r.Out, "\n### Newly failing components\n\n| Feature | Component |\n|---|---|\n")
+		for _, f := range res.NewlyFailing {
+			fmt.Fprintf(r.Out, "| `%s` | %s |\n", f.Feature, f.Component)
+		}
+	}
+}
+
+type creation struct {
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	ScoringEngine string `json:"scoring_engine_version"`
+	Vendor        string `json:"vendor"`
+}
+
+type jsonReport struct {
+	RunID        string   `json:"run_id"`
+	TimeStamp    string   `json:"timestamp"`
+	CreationInfo creation `json:"creation_info"`
+	Regressed    bool     `json:"regressed"`
+	*Result
+}
+
+func (r *Report) jsonReport() {
+	jr := jsonReport{
+		RunID:     uuid.New().String(),
+		TimeStamp: time.Now().UTC().Format(time.RFC3339),
+		CreationInfo: creation{
+			Name:          "sbomqs",
+			Version:       version.GetVersionInfo().GitVersion,
+			ScoringEngine: scorer.EngineVersion,
+			Vendor:        "Interlynk (support@interlynk.io)",
+		},
+		Regressed: r.Result.Regressed(),
+		Result:    r.Result,
+	}
+
+	o, err := json.MarshalIndent(jr, "", "  ")
+	if err != nil {
+		fmt.Fprintf(r.Out, "Failed to print JSON report: %v\n", err)
+		return
+	}
+	fmt.Fprintln(r.Out, string(o))
+}
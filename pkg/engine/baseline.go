@@ -0,0 +1,86 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/interlynk-io/sbomqs/pkg/baseline"
+	"github.com/interlynk-io/sbomqs/pkg/graph"
+	"github.com/interlynk-io/sbomqs/pkg/list"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+)
+
+// loadBaseline reads the baseline at ep.BaselinePath into ep.Baseline. A
+// baseline which doesn't exist yet is empty when it's about to be written.
+func loadBaseline(ep *Params) error {
+	if ep.BaselinePath == "" {
+		return nil
+	}
+
+	b, err := baseline.Read(ep.BaselinePath)
+	if errors.Is(err, fs.ErrNotExist) && ep.WriteBaseline {
+		b, err = baseline.New(), nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	for _, e := range b.Expired() {
+		fmt.Fprintf(os.Stderr, "%s: entry for %s of %s expired on %s\n", ep.BaselinePath, e.Feature, e.Component, e.Expires)
+	}
+
+	ep.Baseline = b
+	return nil
+}
+
+// saveBaseline writes the failures of the run to ep.BaselinePath if asked to.
+func saveBaseline(ep *Params) error {
+	if !ep.WriteBaseline || ep.Baseline == nil {
+		return nil
+	}
+
+	if err := ep.Baseline.Write(ep.BaselinePath); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "wrote %d entries to baseline %s\n", len(ep.Baseline.Failures()), ep.BaselinePath)
+	return nil
+}
+
+// baselineFilter tells the scorer which components of doc are known
+// failures of a feature.
+type baselineFilter struct {
+	b   *baseline.Baseline
+	doc sbom.Document
+	g   *graph.Graph
+}
+
+func newBaselineFilter(b *baseline.Baseline, doc sbom.Document) *baselineFilter {
+	return &baselineFilter{b: b, doc: doc, g: graph.New(doc)}
+}
+
+// Suppressed reports whether c fails feature and the failure is known.
+// Features list can't evaluate per component aren't baselined.
+func (f *baselineFilter) Suppressed(feature string, c sbom.GetComponent) bool {
+	passes, err := list.ComponentPasses(feature, c, f.doc, f.g)
+	if err != nil {
+		return false
+	}
+	return f.b.Observe(feature, c, !passes)
+}
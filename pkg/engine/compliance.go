@@ -20,9 +20,9 @@ import (
 	"os"
 
 	"github.com/interlynk-io/sbomqs/pkg/compliance"
-	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	"github.com/interlynk-io/sbomqs/pkg/logger"
 	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/walk"
 	"github.com/spf13/afero"
 )
 
@@ -65,19 +65,37 @@ func ComplianceRun(ctx context.Context, ep *Params) error {
 		outFormat = "basic"
 	case ep.JSON:
 		outFormat = "json"
+	case ep.SARIF:
+		outFormat = "sarif"
+	case ep.JUnit:
+		outFormat = "junit"
+	case ep.HTML:
+		outFormat = "html"
 	default:
 		outFormat = "detailed"
 	}
 
 	coloredOutput := ep.Color
 
-	err = compliance.ComplianceResult(ctx, *doc, reportType, ep.Path[0], outFormat, coloredOutput)
+	if err := loadBaseline(ep); err != nil {
+		return err
+	}
+
+	err = compliance.ComplianceResult(ctx, *doc, reportType, ep.Path[0], outFormat, coloredOutput, ep.Baseline.For(ep.Path[0]))
 	if err != nil {
 		log.Debugf("compliance.ComplianceResult failed for file :%s\n", ep.Path[0])
 		fmt.Printf("failed to get compliance result for %s\n", ep.Path[0])
 		return err
 	}
 
+	if err := saveBaseline(ep); err != nil {
+		return err
+	}
+
+	if ep.ShowDiagnostics {
+		sbom.WriteDiagnostics(os.Stderr, ep.Path[0], (*doc).Diagnostics())
+	}
+
 	log.Debugf("Compliance Report: %s\n", ep.Path[0])
 	return nil
 }
@@ -88,19 +106,7 @@ func getSbomDocument(ctx context.Context, ep *Params) (*sbom.Document, error) {
 
 	path := ep.Path[0]
 
-	blob, signature, publicKey, err := common.GetSignatureBundle(ctx, path, ep.Signature, ep.PublicKey)
-	if err != nil {
-		log.Debugf("common.GetSignatureBundle failed for file :%s\n", path)
-		fmt.Printf("failed to get signature bundle for %s\n", path)
-		return nil, err
-	}
-
-	sig := sbom.Signature{
-		SigValue:  signature,
-		PublicKey: publicKey,
-		Blob:      blob,
-	}
-	var doc sbom.Document
+	var data []byte
 
 	if IsURL(path) {
 		log.Debugf("Processing Git URL path :%s\n", path)
@@ -110,7 +116,7 @@ func getSbomDocument(ctx context.Context, ep *Params) (*sbom.Document, error) {
 		if IsGit(url) {
 			sbomFilePath, url, err = handleURL(path)
 			if err != nil {
-				log.Fatal("failed to get sbomFilePath, rawURL: %w", err)
+				return nil, fmt.Errorf("failed to get sbomFilePath, rawURL: %w", err)
 			}
 		}
 		fs := afero.NewMemMapFs()
@@ -120,37 +126,24 @@ func getSbomDocument(ctx context.Context, ep *Params) (*sbom.Document, error) {
 			return nil, err
 		}
 
-		f, err := ProcessURL(url, file)
+		data, err = fetchURL(url, file)
 		if err != nil {
 			return nil, err
 		}
-
-		doc, err = sbom.NewSBOMDocument(ctx, f, sig)
-		if err != nil {
-			log.Fatalf("failed to parse SBOM document: %w", err)
-		}
 	} else {
-		if _, err := os.Stat(path); err != nil {
-			log.Debugf("os.Stat failed for file :%s\n", path)
-			fmt.Printf("failed to stat %s\n", path)
-			return nil, err
-		}
-
-		f, err := os.Open(path)
+		var err error
+		data, err = walk.ReadFile(path)
 		if err != nil {
-			log.Debugf("os.Open failed for file :%s\n", path)
-			fmt.Printf("failed to open %s\n", path)
+			log.Debugf("failed to read file :%s\n", path)
+			fmt.Printf("failed to read %s\n", path)
 			return nil, err
 		}
-		defer f.Close()
+	}
 
-		doc, err = sbom.NewSBOMDocument(ctx, f, sig)
-		if err != nil {
-			log.Debugf("failed to create sbom document for  :%s\n", path)
-			log.Debugf("%s\n", err)
-			fmt.Printf("failed to parse %s : %s\n", path, err)
-			return nil, err
-		}
+	doc, err := parseSBOM(ctx, ep, path, data)
+	if err != nil {
+		fmt.Printf("failed to parse %s : %s\n", path, err.Err)
+		return nil, err
 	}
 
 	return &doc, nil
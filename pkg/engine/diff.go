@@ -0,0 +1,83 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/interlynk-io/sbomqs/pkg/diff"
+	"github.com/interlynk-io/sbomqs/pkg/logger"
+)
+
+// ExitRegression is the exit code used when the new document of a diff is
+// worse than the old one.
+const ExitRegression = 4
+
+// RegressionError is returned by DiffRun when the new document regressed
+// and FailOnRegression is set.
+type RegressionError struct {
+	Old string
+	New string
+}
+
+func (e *RegressionError) Error() string {
+	return fmt.Sprintf("%s regressed from %s", e.New, e.Old)
+}
+
+// ExitCode returns the process exit code for the error.
+func (e *RegressionError) ExitCode() int {
+	return ExitRegression
+}
+
+// DiffRun scores the two documents in ep.Path with the same filters and
+// reports how the second one differs from the first.
+func DiffRun(ctx context.Context, ep *Params) error {
+	log := logger.FromContext(ctx)
+	log.Debug("engine.DiffRun()")
+	log.Debug(ep)
+
+	if len(ep.Path) != 2 {
+		return fmt.Errorf("diff requires exactly two paths, got %d", len(ep.Path))
+	}
+
+	oldPath, newPath := ep.Path[0], ep.Path[1]
+
+	oldDoc, oldScores, err := processFile(ctx, ep, oldPath, nil)
+	if err != nil {
+		return err
+	}
+
+	newDoc, newScores, err := processFile(ctx, ep, newPath, nil)
+	if err != nil {
+		return err
+	}
+
+	result := diff.Compare(oldPath, newPath, oldDoc, newDoc, oldScores, newScores)
+
+	format := "detailed"
+	if ep.JSON {
+		format = "json"
+	} else if ep.Markdown {
+		format = "markdown"
+	}
+	diff.NewDiffReport(result, diff.WithFormat(format)).Report()
+
+	if ep.FailOnRegression && result.Regressed() {
+		return &RegressionError{Old: oldPath, New: newPath}
+	}
+
+	return nil
+}
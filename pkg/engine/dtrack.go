@@ -41,6 +41,8 @@ type DtParams struct {
 
 	TagProjectWithScore bool
 	Timeout             int // handle cutom timeout
+
+	ConfigPath string
 }
 
 func DtrackScore(ctx context.Context, dtP *DtParams) error {
@@ -88,6 +90,7 @@ func DtrackScore(ctx context.Context, dtP *DtParams) error {
 			}
 
 			ep := &Params{}
+			ep.ConfigPath = dtP.ConfigPath
 			ep.Path = append(ep.Path, f.Name())
 			doc, scores, err := processFile(ctx, ep, ep.Path[0], nil)
 			if err != nil {
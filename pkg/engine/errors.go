@@ -0,0 +1,123 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/interlynk-io/sbomqs/pkg/reporter"
+)
+
+// Stage is the step of the pipeline a file failed in.
+type Stage string
+
+const (
+	StageFetch     Stage = "fetch"
+	StageSignature Stage = "signature"
+	StageParse     Stage = "parse"
+	StageScore     Stage = "score"
+)
+
+// FileError records why a single file couldn't be scored.
+type FileError struct {
+	Path  string
+	Stage Stage
+	Err   error
+
+	// arg is the position of the argument the file was given as or found
+	// under, errors are reported in argument order
+	arg int
+}
+
+func newFileError(path string, stage Stage, err error) *FileError {
+	return &FileError{Path: path, Stage: stage, Err: err}
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%s: %s failed: %v", e.Path, e.Stage, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// Error policies decide whether per file errors make the run fail.
+const (
+	ErrorPolicyAny   = "any"   // fail if any file failed
+	ErrorPolicyAll   = "all"   // fail only if no file could be scored
+	ErrorPolicyNever = "never" // report errors but never fail on them
+)
+
+var ErrorPolicies = []string{ErrorPolicyAny, ErrorPolicyAll, ErrorPolicyNever}
+
+// ExitFileErrors is the exit code used when the error policy is triggered.
+const ExitFileErrors = 3
+
+// FilesError is returned by Run when per file errors trigger the error policy.
+type FilesError struct {
+	Failed int
+	Total  int
+}
+
+func (e *FilesError) Error() string {
+	return fmt.Sprintf("%d of %d files could not be scored", e.Failed, e.Total)
+}
+
+// ExitCode returns the process exit code for the error.
+func (e *FilesError) ExitCode() int {
+	return ExitFileErrors
+}
+
+// checkErrorPolicy returns a FilesError if errs breach the policy, scored is
+// the number of files that were scored successfully.
+func checkErrorPolicy(policy string, errs []*FileError, scored int) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	total := scored + len(errs)
+
+	switch policy {
+	case ErrorPolicyNever:
+		return nil
+	case ErrorPolicyAny:
+		return &FilesError{Failed: len(errs), Total: total}
+	default:
+		if scored == 0 {
+			return &FilesError{Failed: len(errs), Total: total}
+		}
+		return nil
+	}
+}
+
+// sortErrors orders errs by argument, keeping the order of the files found
+// under each, so errors follow the order the files were given in like the
+// scored results.
+func sortErrors(errs []*FileError) {
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].arg < errs[j].arg })
+}
+
+func toReporterErrors(errs []*FileError) []reporter.FileError {
+	out := make([]reporter.FileError, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, reporter.FileError{
+			Path:  e.Path,
+			Stage: string(e.Stage),
+			Error: e.Err.Error(),
+		})
+	}
+	return out
+}
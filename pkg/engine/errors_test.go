@@ -0,0 +1,113 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckErrorPolicy(t *testing.T) {
+	errs := []*FileError{newFileError("a.json", StageParse, errors.New("boom"))}
+
+	testCases := []struct {
+		policy string
+		errs   []*FileError
+		scored int
+		fail   bool
+	}{
+		{ErrorPolicyAll, nil, 0, false},
+		{ErrorPolicyAll, errs, 1, false},
+		{ErrorPolicyAll, errs, 0, true},
+		{ErrorPolicyAny, errs, 1, true},
+		{ErrorPolicyNever, errs, 0, false},
+	}
+
+	for _, tc := range testCases {
+		err := checkErrorPolicy(tc.policy, tc.errs, tc.scored)
+		if !tc.fail {
+			assert.NoError(t, err, tc.policy)
+			continue
+		}
+
+		var fe *FilesError
+		require.ErrorAs(t, err, &fe, tc.policy)
+		assert.Equal(t, ExitFileErrors, fe.ExitCode())
+		assert.Equal(t, 1, fe.Failed)
+		assert.Equal(t, tc.scored+1, fe.Total)
+	}
+}
+
+func TestProcessFileStages(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5", "components": 7}`), 0o600))
+	unknown := filepath.Join(dir, "unknown.json")
+	require.NoError(t, os.WriteFile(unknown, []byte(`{"hello": "world"}`), 0o600))
+
+	testCases := []struct {
+		name  string
+		path  string
+		stage Stage
+	}{
+		{"missing file", filepath.Join(dir, "missing.json"), StageFetch},
+		{"unknown format", unknown, StageParse},
+		{"malformed cdx", bad, StageParse},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := processFile(context.Background(), &Params{}, tc.path, nil)
+
+			var fe *FileError
+			require.ErrorAs(t, err, &fe)
+			assert.Equal(t, tc.path, fe.Path)
+			assert.Equal(t, tc.stage, fe.Stage)
+		})
+	}
+}
+
+func TestScoreFilesCollectsErrors(t *testing.T) {
+	files := append(samplePaths(t), filepath.Join(t.TempDir(), "missing.json"))
+
+	results := scoreFiles(context.Background(), &Params{Jobs: 2}, files)
+	require.Len(t, results, len(files))
+
+	last := results[len(results)-1]
+	require.NotNil(t, last.err)
+	assert.Equal(t, files[len(files)-1], last.err.Path)
+	for _, r := range results[:len(results)-1] {
+		assert.Nil(t, r.err, r.path)
+	}
+}
+
+func TestSortErrors(t *testing.T) {
+	errs := []*FileError{
+		{Path: "dir/b.json", arg: 1},
+		{Path: "dir/a.json", arg: 1},
+		{Path: "z.json", arg: 0},
+		{Path: "https://example.com/sbom.json", arg: 2},
+	}
+
+	sortErrors(errs)
+	assert.Equal(t, []string{"z.json", "dir/b.json", "dir/a.json", "https://example.com/sbom.json"}, lo.Map(errs, func(e *FileError, _ int) string { return e.Path }))
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/interlynk-io/sbomqs/pkg/history"
+	"github.com/interlynk-io/sbomqs/pkg/logger"
+	"github.com/interlynk-io/sbomqs/pkg/reporter"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+)
+
+// productName returns the name runs of the same product are recorded under.
+func productName(doc sbom.Document, path string) string {
+	if pc := doc.PrimaryComp(); pc != nil && pc.IsPresent() && pc.GetName() != "" {
+		return pc.GetName()
+	}
+	// the name of a CycloneDX document is the spec's
+	if doc.Spec().GetSpecType() != string(sbom.SBOMSpecCDX) && doc.Spec().GetName() != "" {
+		return doc.Spec().GetName()
+	}
+	return filepath.Base(path)
+}
+
+// recordHistory adds the scored documents of a run to the history store,
+// digests holding the SHA-256 of the bytes each was parsed from.
+func recordHistory(ctx context.Context, ep *Params, docs []sbom.Document, scores []scorer.Scores, paths, digests []string) error {
+	log := logger.FromContext(ctx)
+
+	store, err := history.New(ep.HistoryPath)
+	if err != nil {
+		return err
+	}
+
+	runID := uuid.New().String()
+	now := time.Now().UTC()
+
+	var records []history.Record
+	for i, path := range paths {
+		report, err := reporter.FileJSON(path, docs[i], scores[i])
+		if err != nil {
+			return fmt.Errorf("%s: failed to record history: %w", path, err)
+		}
+
+		records = append(records, history.Record{
+			RunID:         runID,
+			Timestamp:     now,
+			Product:       productName(docs[i], path),
+			Namespace:     docs[i].Spec().GetNamespace(),
+			SHA256:        digests[i],
+			Path:          path,
+			EngineVersion: scorer.EngineVersion,
+			Report:        report,
+		})
+	}
+
+	log.Debugf("recording %d runs in %s", len(records), store.Path())
+	return store.Add(records...)
+}
+
+// HistoryRun reports the recorded runs of the product in ep.Path, or the
+// products in the history store if there is none.
+func HistoryRun(ctx context.Context, ep *Params) error {
+	log := logger.FromContext(ctx)
+	log.Debug("engine.HistoryRun()")
+	log.Debug(ep)
+
+	store, err := history.New(ep.HistoryPath)
+	if err != nil {
+		return err
+	}
+
+	format := "detailed"
+	if ep.JSON {
+		format = "json"
+	}
+	opts := []history.Option{history.WithFormat(format), history.WithFeatures(ep.Features)}
+
+	if len(ep.Path) == 0 {
+		products, err := store.Products()
+		if err != nil {
+			return err
+		}
+		return history.NewHistoryReport("", nil, products, opts...).Report()
+	}
+
+	product := ep.Path[0]
+	runs, err := store.Runs(product, ep.Last)
+	if err != nil {
+		return err
+	}
+
+	return history.NewHistoryReport(product, runs, nil, opts...).Report()
+}
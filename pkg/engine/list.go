@@ -24,14 +24,23 @@ import (
 func parseListParams(ep *Params) *list.Params {
 	return &list.Params{
 		Path:     ep.Path,
+		Recurse:  ep.Recurse,
+		Include:  ep.Include,
+		Exclude:  ep.Exclude,
 		Features: ep.Features,
 		JSON:     ep.JSON,
 		Basic:    ep.Basic,
 		Detailed: ep.Detailed,
+		SARIF:    ep.SARIF,
 		Color:    ep.Color,
 		Missing:  ep.Missing,
 		Debug:    ep.Debug,
 		Show:     ep.Show,
+
+		ShowDiagnostics: ep.ShowDiagnostics,
+		Strict:          ep.Strict,
+
+		Baseline: ep.Baseline,
 	}
 }
 
@@ -39,6 +48,10 @@ func ListRun(ctx context.Context, ep *Params) error {
 	log := logger.FromContext(ctx)
 	log.Debug("engine.ListRun()")
 
+	if err := loadBaseline(ep); err != nil {
+		return err
+	}
+
 	lep := parseListParams(ep)
 
 	// Process the SBOMs and features
@@ -48,5 +61,5 @@ func ListRun(ctx context.Context, ep *Params) error {
 		return err
 	}
 
-	return nil
+	return saveBaseline(ep)
 }
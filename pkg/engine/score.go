@@ -15,22 +15,29 @@
 package engine
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/interlynk-io/sbomqs/pkg/baseline"
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	"github.com/interlynk-io/sbomqs/pkg/logger"
 	"github.com/interlynk-io/sbomqs/pkg/reporter"
 	"github.com/interlynk-io/sbomqs/pkg/sbom"
 	"github.com/interlynk-io/sbomqs/pkg/scorer"
+	"github.com/interlynk-io/sbomqs/pkg/walk"
 	"github.com/spf13/afero"
 )
 
@@ -44,17 +51,58 @@ type Params struct {
 	Basic    bool
 	Detailed bool
 	Pdf      bool
+	Markdown bool
+	SARIF    bool
+	JUnit    bool
+	HTML     bool
 
 	Spdx bool
 	Cdx  bool
 
 	Recurse bool
+	Include []string
+	Exclude []string
 	Missing bool
 
+	// number of files scored concurrently, zero means one per CPU
+	Jobs int
+
+	// when per file errors fail the run, one of ErrorPolicies
+	ErrorPolicy string
+
 	Debug bool
 	Show  bool
 
+	// print parse diagnostics to stderr
+	ShowDiagnostics bool
+
+	// validate JSON documents against the schema of their spec version
+	Strict bool
+
 	ConfigPath string
+	// filters read from ConfigPath, compiled once per run by loadConfig
+	filters []scorer.Filter
+
+	// quality gates, a value of zero means unset
+	FailBelow         float64
+	FailBelowCategory map[string]float64
+	FailBelowFeature  map[string]float64
+
+	// fail a diff whose new document is worse than the old one
+	FailOnRegression bool
+
+	// record the scores in the history store at HistoryPath, the user's
+	// cache directory if empty
+	Record      bool
+	HistoryPath string
+	// number of recorded runs reported by history, zero means all
+	Last int
+
+	// known failures, read from BaselinePath, left out of the results;
+	// with WriteBaseline the failures of the run are written back to it
+	BaselinePath  string
+	WriteBaseline bool
+	Baseline      *baseline.Baseline
 
 	Ntia  bool
 	Bsi   bool
@@ -74,7 +122,7 @@ func Run(ctx context.Context, ep *Params) error {
 	log.Debug(ep)
 
 	if len(ep.Path) <= 0 {
-		log.Fatal("path is required")
+		return fmt.Errorf("path is required")
 	}
 
 	return handlePaths(ctx, ep)
@@ -149,180 +197,351 @@ func handlePaths(ctx context.Context, ep *Params) error {
 	log := logger.FromContext(ctx)
 	log.Debug("engine.handlePaths()")
 
+	if err := loadConfig(ep); err != nil {
+		return err
+	}
+
+	if err := loadBaseline(ep); err != nil {
+		return err
+	}
+
 	var docs []sbom.Document
 	var paths []string
 	var scores []scorer.Scores
+	var digests []string
+	var files []string
+	var errs []*FileError
 
-	for _, path := range ep.Path {
-		if IsURL(path) {
-			log.Debugf("Processing Git URL path :%s\n", path)
-
-			url, sbomFilePath := path, path
-			var err error
+	// args holds the index of the argument each file was found under
+	var args []int
 
-			if IsGit(url) {
-				sbomFilePath, url, err = handleURL(path)
-				if err != nil {
-					log.Fatal("failed to get sbomFilePath, rawURL: %w", err)
-				}
-			}
-			fs := afero.NewMemMapFs()
-
-			file, err := fs.Create(sbomFilePath)
-			if err != nil {
-				return err
-			}
-
-			f, err := ProcessURL(url, file)
-			if err != nil {
-				return err
-			}
-
-			blob, signature, publicKey, err := common.GetSignatureBundle(ctx, path, ep.Signature, ep.PublicKey)
-			if err != nil {
-				log.Debugf("common.GetSignatureBundle failed for file :%s\n", path)
-				fmt.Printf("failed to get signature bundle for %s\n", path)
-				return err
-			}
-
-			sig := sbom.Signature{
-				SigValue:  signature,
-				PublicKey: publicKey,
-				Blob:      blob,
-			}
-
-			doc, err := sbom.NewSBOMDocument(ctx, f, sig)
-			if err != nil {
-				log.Fatalf("failed to parse SBOM document: %w", err)
-			}
-
-			sr := scorer.NewScorer(ctx, doc)
-			score := sr.Score()
-
-			docs = append(docs, doc)
-			scores = append(scores, score)
-			paths = append(paths, sbomFilePath)
-		} else {
-			log.Debugf("Processing path :%s\n", path)
-			pathInfo, err := os.Stat(path)
-			if err != nil {
-				log.Debugf("os.Stat failed for path:%s\n", path)
-				log.Infof("%s\n", err)
-				continue
-			}
+	for i, path := range ep.Path {
+		if IsURL(path) {
+			files = append(files, path)
+			args = append(args, i)
+			continue
+		}
 
-			if pathInfo.IsDir() {
-				files, err := os.ReadDir(path)
-				if err != nil {
-					log.Debugf("os.ReadDir failed for path:%s\n", path)
-					log.Debugf("%s\n", err)
-					continue
-				}
-				for _, file := range files {
-					log.Debugf("Processing file :%s\n", file.Name())
-					if file.IsDir() {
-						continue
-					}
-					path := filepath.Join(path, file.Name())
-					doc, scs, err := processFile(ctx, ep, path, nil)
-					if err != nil {
-						continue
-					}
-					docs = append(docs, doc)
-					scores = append(scores, scs)
-					paths = append(paths, path)
-				}
-				continue
-			}
+		log.Debugf("Processing path :%s\n", path)
+		walked, err := walk.Paths(ctx, path, walkOptions(ep))
+		if err != nil {
+			log.Debugf("walk.Paths failed for path:%s\n", path)
+			ferr := newFileError(path, StageFetch, err)
+			ferr.arg = i
+			errs = append(errs, ferr)
+			continue
+		}
+		files = append(files, walked...)
+		for range walked {
+			args = append(args, i)
+		}
+	}
 
-			doc, scs, err := processFile(ctx, ep, path, nil)
-			if err != nil {
-				continue
-			}
-			docs = append(docs, doc)
-			scores = append(scores, scs)
-			paths = append(paths, path)
+	for i, r := range scoreFiles(ctx, ep, files) {
+		if r.err != nil {
+			r.err.arg = args[i]
+			errs = append(errs, r.err)
+			continue
 		}
+		docs = append(docs, r.doc)
+		scores = append(scores, r.scores)
+		paths = append(paths, r.path)
+		digests = append(digests, r.sha256)
 	}
 
+	sortErrors(errs)
+
 	reportFormat := "detailed"
 	if ep.Basic {
 		reportFormat = "basic"
 	} else if ep.JSON {
 		reportFormat = "json"
+	} else if ep.SARIF {
+		reportFormat = "sarif"
+	} else if ep.JUnit {
+		reportFormat = "junit"
+	} else if ep.HTML {
+		reportFormat = "html"
 	}
 	coloredOutput := ep.Color
 
+	t, err := thresholds(ep)
+	if err != nil {
+		return err
+	}
+
 	nr := reporter.NewReport(ctx,
 		docs,
 		scores,
 		paths,
-		reporter.WithFormat(strings.ToLower(reportFormat)), reporter.WithColor(coloredOutput))
+		reporter.WithFormat(strings.ToLower(reportFormat)), reporter.WithColor(coloredOutput),
+		reporter.WithErrors(toReporterErrors(errs)),
+		reporter.WithDiagnostics(ep.ShowDiagnostics),
+		reporter.WithThresholds(t))
 
 	nr.Report()
 
-	return nil
+	if ep.Record {
+		if err := recordHistory(ctx, ep, docs, scores, paths, digests); err != nil {
+			return err
+		}
+	}
+
+	if err := saveBaseline(ep); err != nil {
+		return err
+	}
+
+	if err := checkErrorPolicy(ep.ErrorPolicy, errs, len(docs)); err != nil {
+		return err
+	}
+
+	return checkThresholds(ep, paths, scores)
+}
+
+// downloadSBOM downloads the SBOM at path, a GitHub blob URL or any other
+// http(s) URL. It returns the path the SBOM is reported under, the path of
+// the file in the repository for a GitHub URL, and its contents.
+func downloadSBOM(ctx context.Context, path string) (string, []byte, error) {
+	log := logger.FromContext(ctx)
+	log.Debugf("Processing URL :%s\n", path)
+
+	url, sbomFilePath := path, path
+	var err error
+
+	if IsGit(url) {
+		sbomFilePath, url, err = handleURL(path)
+		if err != nil {
+			return path, nil, newFileError(path, StageFetch, err)
+		}
+	}
+	fs := afero.NewMemMapFs()
+
+	file, err := fs.Create(sbomFilePath)
+	if err != nil {
+		return sbomFilePath, nil, newFileError(sbomFilePath, StageFetch, err)
+	}
+
+	data, err := fetchURL(url, file)
+	if err != nil {
+		return sbomFilePath, nil, newFileError(sbomFilePath, StageFetch, err)
+	}
+
+	return sbomFilePath, data, nil
+}
+
+type scoredFile struct {
+	path   string
+	doc    sbom.Document
+	scores scorer.Scores
+	// hex SHA-256 of the bytes the document was parsed from
+	sha256 string
+	err    *FileError
+}
+
+// digest returns the hex SHA-256 of data.
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// scoreFiles parses and scores files, or downloads and scores URLs, on a
+// bounded pool of workers. The results are in the same order as files.
+func scoreFiles(ctx context.Context, ep *Params, files []string) []scoredFile {
+	results := make([]scoredFile, len(files))
+
+	jobs := ep.Jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+	if jobs > len(files) {
+		jobs = len(files)
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if r, ok := streamSBOM(ctx, ep, files[i]); ok {
+					results[i] = r
+					continue
+				}
+
+				r := scoredFile{path: files[i]}
+				var data []byte
+				var err error
+				if IsURL(files[i]) {
+					r.path, data, err = downloadSBOM(ctx, files[i])
+				} else {
+					data, err = readSBOM(ctx, files[i], nil)
+				}
+				if err == nil {
+					r.sha256 = digest(data)
+					r.doc, r.scores, err = scoreSBOM(ctx, ep, r.path, data)
+				}
+				if err != nil {
+					r.err = asFileError(r.path, err)
+				}
+				results[i] = r
+			}
+		}()
+	}
+
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return results
+}
+
+// streamMinSize is the size from which a local file is scored from a
+// component stream, when it can be, see streamSBOM.
+var streamMinSize int64 = 64 << 20
+
+// streamSBOM scores the large CycloneDX JSON file at path from a component
+// stream, see sbom.WithComponentStream, so its components are never held in
+// memory together. ok is false if the file has to be read whole instead:
+// it's small, compressed or not a local file, it's to be verified against a
+// signature, schema or baseline, a selected check needs all of the
+// components at once, or the document can't be streamed.
+func streamSBOM(ctx context.Context, ep *Params, path string) (r scoredFile, ok bool) {
+	log := logger.FromContext(ctx)
+	r.path = path
+
+	if ep.Strict || ep.Signature != "" || ep.PublicKey != "" || ep.Baseline != nil || IsURL(path) {
+		return r, false
+	}
+	filters, err := scoreFilters(ep)
+	if err != nil || !scorer.Streamable(filters) {
+		return r, false
+	}
+
+	f, ok, err := walk.OpenFile(path)
+	if err != nil || !ok {
+		return r, false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() || info.Size() < streamMinSize {
+		return r, false
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return r, false
+	}
+
+	doc, err := sbom.NewSBOMDocument(ctx, f, sbom.Signature{}, sbom.WithComponentStream(true))
+	if errors.Is(err, sbom.ErrNotStreamable) {
+		log.Debugf("%s can't be streamed, reading it whole", path)
+		return r, false
+	}
+	if err != nil {
+		r.err = newFileError(path, StageParse, err)
+		return r, true
+	}
+	log.Debugf("scoring %s from a component stream", path)
+
+	r.sha256 = hex.EncodeToString(h.Sum(nil))
+	r.scores, err = scoreDocument(ctx, ep, path, doc)
+	if err != nil {
+		r.err = asFileError(path, err)
+		return r, true
+	}
+	r.doc = doc
+	return r, true
+}
+
+func walkOptions(ep *Params) walk.Options {
+	return walk.Options{
+		Recurse: ep.Recurse,
+		Include: ep.Include,
+		Exclude: ep.Exclude,
+	}
 }
 
 func processFile(ctx context.Context, ep *Params, path string, fs billy.Filesystem) (sbom.Document, scorer.Scores, error) {
+	data, err := readSBOM(ctx, path, fs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return scoreSBOM(ctx, ep, path, data)
+}
+
+// readSBOM reads the file at path, from fs unless it is nil.
+func readSBOM(ctx context.Context, path string, fs billy.Filesystem) ([]byte, error) {
 	log := logger.FromContext(ctx)
 	log.Debugf("Processing file :%s\n", path)
-	var doc sbom.Document
 
-	blob, signature, publicKey, err := common.GetSignatureBundle(ctx, path, ep.Signature, ep.PublicKey)
+	var data []byte
+	var err error
+
+	if fs != nil {
+		data, err = util.ReadFile(fs, path)
+	} else {
+		data, err = walk.ReadFile(path)
+	}
+	if err != nil {
+		log.Debugf("failed to read file :%s\n", path)
+		return nil, newFileError(path, StageFetch, err)
+	}
+
+	return data, nil
+}
+
+// scoreSBOM parses and scores data, read from path.
+func scoreSBOM(ctx context.Context, ep *Params, path string, data []byte) (sbom.Document, scorer.Scores, error) {
+	doc, ferr := parseSBOM(ctx, ep, path, data)
+	if ferr != nil {
+		return nil, nil, ferr
+	}
+
+	scores, err := scoreDocument(ctx, ep, path, doc)
 	if err != nil {
-		log.Debugf("common.GetSignatureBundle failed for file :%s\n", path)
-		fmt.Printf("failed to get signature bundle for %s\n", path)
 		return nil, nil, err
 	}
+	return doc, scores, nil
+}
 
-	sig := sbom.Signature{
-		SigValue:  signature,
-		PublicKey: publicKey,
-		Blob:      blob,
+// scoreDocument scores doc, read from path.
+func scoreDocument(ctx context.Context, ep *Params, path string, doc sbom.Document) (scorer.Scores, error) {
+	filters, err := scoreFilters(ep)
+	if err != nil {
+		return nil, newFileError(path, StageScore, err)
 	}
 
-	if fs != nil {
-		f, err := fs.Open(path)
-		if err != nil {
-			log.Debugf("os.Open failed for file :%s\n", path)
-			fmt.Printf("failed to open %s\n", path)
-			return nil, nil, err
-		}
-		defer f.Close()
+	sr := scorer.NewScorer(ctx, doc)
 
-		doc, err = sbom.NewSBOMDocument(ctx, f, sig)
-		if err != nil {
-			log.Debugf("failed to create sbom document for  :%s\n", path)
-			log.Debugf("%s\n", err)
-			fmt.Printf("failed to parse %s : %s\n", path, err)
-			return nil, nil, err
-		}
-	} else {
-		if _, err := os.Stat(path); err != nil {
-			log.Debugf("os.Stat failed for file :%s\n", path)
-			fmt.Printf("failed to stat %s\n", path)
-			return nil, nil, err
-		}
+	if ep.Baseline != nil {
+		sr.SetBaseline(newBaselineFilter(ep.Baseline.For(path), doc))
+	}
 
-		f, err := os.Open(path)
-		if err != nil {
-			log.Debugf("os.Open failed for file :%s\n", path)
-			fmt.Printf("failed to open %s\n", path)
-			return nil, nil, err
-		}
-		defer f.Close()
+	for _, filter := range filters {
+		sr.AddFilter(filter)
+	}
 
-		doc, err = sbom.NewSBOMDocument(ctx, f, sig)
-		if err != nil {
-			log.Debugf("failed to create sbom document for  :%s\n", path)
-			log.Debugf("%s\n", err)
-			fmt.Printf("failed to parse %s : %s\n", path, err)
-			return nil, nil, err
-		}
+	scores, err := score(sr)
+	if err != nil {
+		return nil, newFileError(path, StageScore, err)
+	}
+	// the components of a streamed document are decoded while it's scored
+	if err := sr.Err(); err != nil {
+		return nil, newFileError(path, StageParse, err)
 	}
+	return scores, nil
+}
 
-	sr := scorer.NewScorer(ctx, doc)
+// scoreFilters returns the filters selecting the checks to score, from the
+// categories and features of ep, then from its config file.
+func scoreFilters(ep *Params) ([]scorer.Filter, error) {
+	var filters []scorer.Filter
 
 	if len(ep.Categories) > 0 {
 		if len(ep.Features) == 0 {
@@ -334,7 +553,7 @@ func processFile(ctx context.Context, ep *Params, path string, fs billy.Filesyst
 					Name:  category,
 					Ftype: scorer.Category,
 				}
-				sr.AddFilter(filter)
+				filters = append(filters, filter)
 			}
 		} else if len(ep.Features) > 0 {
 			for _, cat := range ep.Categories {
@@ -350,7 +569,7 @@ func processFile(ctx context.Context, ep *Params, path string, fs billy.Filesyst
 						Ftype:    scorer.Mix,
 						Category: cat,
 					}
-					sr.AddFilter(filter)
+					filters = append(filters, filter)
 				}
 			}
 		}
@@ -363,25 +582,103 @@ func processFile(ctx context.Context, ep *Params, path string, fs billy.Filesyst
 				Name:  feature,
 				Ftype: scorer.Feature,
 			}
-			sr.AddFilter(filter)
+			filters = append(filters, filter)
 		}
 	}
 
 	if ep.ConfigPath != "" {
-		filters, er := scorer.ReadConfigFile(ep.ConfigPath)
-		if er != nil {
-			log.Fatalf("failed to read config file %s : %s", ep.ConfigPath, er)
+		if err := loadConfig(ep); err != nil {
+			return nil, err
 		}
 
-		if len(filters) <= 0 {
-			log.Fatalf("no enabled filters found in config file %s", ep.ConfigPath)
-		}
+		filters = append(filters, ep.filters...)
+	}
+
+	return filters, nil
+}
 
-		for _, filter := range filters {
-			sr.AddFilter(filter)
+// score runs the scorer, turning a panic in a check into an error so a
+// single malformed document can't abort a whole batch.
+func score(sr *scorer.Scorer) (scores scorer.Scores, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("scoring panicked: %v", r)
 		}
+	}()
+
+	return sr.Score(), nil
+}
+
+// parseSBOM collects the signature bundle for data and parses it.
+func parseSBOM(ctx context.Context, ep *Params, path string, data []byte) (sbom.Document, *FileError) {
+	log := logger.FromContext(ctx)
+
+	sig, err := common.GetSignatureBundle(ctx, data, ep.Signature, ep.PublicKey)
+	if err != nil {
+		log.Debugf("common.GetSignatureBundle failed for file :%s\n", path)
+		return nil, newFileError(path, StageSignature, err)
 	}
 
-	scores := sr.Score()
-	return doc, scores, nil
+	doc, err := sbom.NewSBOMDocument(ctx, bytes.NewReader(data), sig, sbom.WithSchemaValidation(ep.Strict))
+	if err != nil {
+		log.Debugf("failed to create sbom document for  :%s\n", path)
+		log.Debugf("%s\n", err)
+		return nil, newFileError(path, StageParse, err)
+	}
+
+	return doc, nil
+}
+
+// fetchURL downloads url into file and returns its contents.
+func fetchURL(url string, file afero.File) ([]byte, error) {
+	f, err := ProcessURL(url, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(f)
+}
+
+// loadConfig reads the filters at ep.ConfigPath into ep.filters, unless they
+// have been read already, so custom checks are compiled once per run rather
+// than once per file.
+func loadConfig(ep *Params) error {
+	if ep.ConfigPath == "" || ep.filters != nil {
+		return nil
+	}
+
+	filters, err := configFilters(ep.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	ep.filters = filters
+	return nil
+}
+
+func configFilters(path string) ([]scorer.Filter, error) {
+	filters, err := scorer.ReadConfigFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	if len(filters) <= 0 {
+		return nil, fmt.Errorf("no enabled filters found in config file %s", path)
+	}
+
+	return filters, nil
+}
+
+// asFileError returns err as a FileError, attributing unknown errors to the
+// score stage.
+func asFileError(path string, err error) *FileError {
+	var fe *FileError
+	if errors.As(err, &fe) {
+		return fe
+	}
+	return newFileError(path, StageScore, err)
 }
@@ -15,13 +15,24 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
+	"github.com/interlynk-io/sbomqs/pkg/history"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+	"github.com/interlynk-io/sbomqs/pkg/walk"
+	"github.com/samber/lo"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandleURL(t *testing.T) {
@@ -120,3 +131,176 @@ func TestProcessURL(t *testing.T) {
 		})
 	}
 }
+
+func samplePaths(tb testing.TB) []string {
+	tb.Helper()
+	paths, err := walk.Paths(context.Background(), filepath.Join("..", "..", "samples"), walk.Options{Include: []string{"*.json"}})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return paths
+}
+
+func TestScoreFilesOrder(t *testing.T) {
+	files := samplePaths(t)
+
+	sequential := scoreFiles(context.Background(), &Params{Jobs: 1}, files)
+	concurrent := scoreFiles(context.Background(), &Params{Jobs: 4}, files)
+
+	assert.Len(t, concurrent, len(files))
+	for i := range files {
+		assert.Equal(t, files[i], concurrent[i].path)
+		assert.Equal(t, sequential[i].err, concurrent[i].err)
+		if sequential[i].err == nil {
+			assert.InDelta(t, sequential[i].scores.AvgScore(), concurrent[i].scores.AvgScore(), 1e-9, files[i])
+		}
+	}
+}
+
+func TestLoadConfigOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "features.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+categories:
+- name: Policy
+  features:
+  - name: named
+    custom:
+      predicate: 'name != ""'
+`), 0o600))
+
+	ep := &Params{ConfigPath: path}
+	assert.NoError(t, loadConfig(ep))
+	assert.Len(t, ep.filters, 1)
+
+	// the compiled filters are reused rather than read again
+	assert.NoError(t, os.Remove(path))
+	assert.NoError(t, loadConfig(ep))
+	assert.Len(t, ep.filters, 1)
+}
+
+func TestScoreFilesArgumentOrder(t *testing.T) {
+	files := lo.Reverse(samplePaths(t))
+
+	results := scoreFiles(context.Background(), &Params{Jobs: 4}, files)
+	assert.Equal(t, files, lo.Map(results, func(r scoredFile, _ int) string { return r.path }))
+}
+
+func TestScoreFilesDigest(t *testing.T) {
+	files := samplePaths(t)[:1]
+	data, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+
+	results := scoreFiles(context.Background(), &Params{}, files)
+	assert.Equal(t, digest(data), results[0].sha256)
+}
+
+func TestScoreFilesStreamed(t *testing.T) {
+	files := samplePaths(t)
+	want := scoreFiles(context.Background(), &Params{}, files)
+
+	defer func(size int64) { streamMinSize = size }(streamMinSize)
+	streamMinSize = 0
+
+	streamed := 0
+	for i, r := range scoreFiles(context.Background(), &Params{}, files) {
+		require.Nil(t, r.err, files[i])
+		if sbom.Streamed(r.doc) != nil {
+			streamed++
+		}
+		assert.Equal(t, want[i].sha256, r.sha256, files[i])
+		assert.Equal(t, want[i].scores, r.scores, files[i])
+	}
+	// the CycloneDX JSON samples, but for the signed one, whose signature
+	// is verified over the whole document
+	assert.Equal(t, 6, streamed)
+
+	// the dependency graph needs all of the components
+	ep := &Params{Categories: []string{"Semantic"}, Features: []string{"comp_reachable"}}
+	for _, r := range scoreFiles(context.Background(), ep, files) {
+		assert.Nil(t, sbom.Streamed(r.doc), r.path)
+	}
+}
+
+func TestRecordHistoryURL(t *testing.T) {
+	data, err := os.ReadFile(samplePaths(t)[0])
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	historyPath := filepath.Join(t.TempDir(), "history.jsonl")
+	ep := &Params{HistoryPath: historyPath}
+	results := scoreFiles(context.Background(), ep, []string{srv.URL + "/sbom.json"})
+	assert.Nil(t, results[0].err)
+
+	r := results[0]
+	assert.NoError(t, recordHistory(context.Background(), ep, []sbom.Document{r.doc}, []scorer.Scores{r.scores}, []string{r.path}, []string{r.sha256}))
+
+	store, err := history.New(historyPath)
+	assert.NoError(t, err)
+	runs, err := store.Runs(digest(data), 0)
+	assert.NoError(t, err)
+	assert.Len(t, runs, 1)
+}
+
+func TestScoreURLWithConfigAndBaseline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "supplier": {"name": "Acme"}},
+    {"type": "library", "bom-ref": "b", "name": "b"}
+  ]
+}`))
+	}))
+	defer srv.Close()
+	url := srv.URL + "/sbom.json"
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "features.yaml")
+	assert.NoError(t, os.WriteFile(configPath, []byte(`
+categories:
+- name: NTIA-minimum-elements
+  features:
+  - name: comp_with_supplier
+- name: Policy
+  features:
+  - name: named
+    custom:
+      predicate: 'name != ""'
+`), 0o600))
+	baselinePath := filepath.Join(dir, "baseline.json")
+	assert.NoError(t, os.WriteFile(baselinePath, []byte(`{"entries": [
+  {"document": "`+url+`", "feature": "comp_with_supplier", "component": {"id": "b", "name": "b"}}
+]}`), 0o600))
+
+	ep := &Params{ConfigPath: configPath, BaselinePath: baselinePath}
+	assert.NoError(t, loadConfig(ep))
+	assert.NoError(t, loadBaseline(ep))
+
+	results := scoreFiles(context.Background(), ep, []string{url})
+	require.Nil(t, results[0].err)
+
+	scores := results[0].scores.ScoreList()
+	require.Len(t, scores, 2)
+	assert.Equal(t, "comp_with_supplier", scores[0].Feature())
+	assert.InDelta(t, 10.0, scores[0].Score(), 0.001, "the known failure is baselined")
+	assert.Equal(t, "named", scores[1].Feature())
+}
+
+func BenchmarkScoreSamples(b *testing.B) {
+	files := samplePaths(b)
+
+	for _, jobs := range lo.Uniq([]int{1, 4, runtime.NumCPU()}) {
+		b.Run(fmt.Sprintf("jobs=%d", jobs), func(b *testing.B) {
+			ep := &Params{Jobs: jobs}
+			for i := 0; i < b.N; i++ {
+				scoreFiles(context.Background(), ep, files)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+)
+
+// ExitThresholdBreached is the exit code used when a scored document falls
+// below one of the configured minimums.
+const ExitThresholdBreached = 2
+
+// ThresholdError is returned by Run when at least one document breached a
+// quality threshold.
+type ThresholdError struct {
+	Files    int
+	Breaches int
+}
+
+func (e *ThresholdError) Error() string {
+	return fmt.Sprintf("%d quality thresholds breached in %d files", e.Breaches, e.Files)
+}
+
+// ExitCode returns the process exit code for the error.
+func (e *ThresholdError) ExitCode() int {
+	return ExitThresholdBreached
+}
+
+func thresholds(ep *Params) (scorer.Thresholds, error) {
+	t := scorer.Thresholds{}
+
+	if ep.ConfigPath != "" {
+		ct, err := scorer.ReadThresholdsFile(ep.ConfigPath)
+		if err != nil {
+			return t, fmt.Errorf("failed to read thresholds from %s: %w", ep.ConfigPath, err)
+		}
+		t = ct
+	}
+
+	t.Merge(scorer.Thresholds{
+		Overall:    ep.FailBelow,
+		Categories: ep.FailBelowCategory,
+		Features:   ep.FailBelowFeature,
+	})
+
+	return t, nil
+}
+
+func checkThresholds(ep *Params, paths []string, scores []scorer.Scores) error {
+	t, err := thresholds(ep)
+	if err != nil {
+		return err
+	}
+
+	if t.IsZero() {
+		return nil
+	}
+
+	return reportBreaches(os.Stderr, t, paths, scores)
+}
+
+func reportBreaches(w io.Writer, t scorer.Thresholds, paths []string, scores []scorer.Scores) error {
+	files, total := 0, 0
+
+	for i, s := range scores {
+		breaches := t.Check(s)
+		if len(breaches) == 0 {
+			continue
+		}
+
+		if files == 0 {
+			fmt.Fprintln(w, "Quality thresholds breached:")
+		}
+		files++
+		total += len(breaches)
+
+		for _, b := range breaches {
+			fmt.Fprintf(w, "  %s: %s\n", paths[i], b)
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	return &ThresholdError{Files: files, Breaches: total}
+}
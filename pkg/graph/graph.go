@@ -0,0 +1,313 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graph builds the dependency graph of an SBOM from its components
+// and relationships, and answers questions about its shape: dependencies
+// that point at nothing, components the primary component can't reach,
+// dependency cycles and how deep the transitive dependencies go.
+package graph
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/samber/lo"
+)
+
+// Graph is the dependency graph of an SBOM. Nodes are component ids,
+// edges are dependencies of any scope. Containment, by relationship or by
+// nesting, is kept apart and only used for reachability.
+type Graph struct {
+	// ids are the component ids in document order
+	ids   []string
+	index map[string]int
+	deps  map[string][]string
+	parts map[string][]string
+	// dangling are the dependency targets of a component that aren't
+	// components of the SBOM
+	dangling map[string][]string
+	root     string
+
+	reachable map[string]bool
+	depths    map[string]int
+	cycles    [][]string
+	inCycle   map[string]bool
+}
+
+// New builds the graph of doc. Components without an id can't be the end
+// of a relationship and are left out, as are repeated ids.
+func New(doc sbom.Document) *Graph {
+	g := &Graph{
+		index:    map[string]int{},
+		deps:     map[string][]string{},
+		parts:    map[string][]string{},
+		dangling: map[string][]string{},
+	}
+
+	for _, c := range doc.Components() {
+		id := c.GetID()
+		if _, ok := g.index[id]; ok || id == "" {
+			continue
+		}
+		g.index[id] = len(g.ids)
+		g.ids = append(g.ids, id)
+	}
+
+	for _, c := range doc.Components() {
+		for _, child := range c.GetChildIDs() {
+			g.parts[c.GetID()] = appendUnique(g.parts[c.GetID()], child)
+		}
+	}
+
+	for _, r := range doc.Relations() {
+		kind := r.GetKind()
+		if !kind.IsDependency() && kind != sbom.RelationContains {
+			continue
+		}
+		// relationships of the document itself or of SPDX files
+		from, ok := g.resolve(r.GetFrom())
+		if !ok {
+			continue
+		}
+		to, ok := g.resolve(r.GetTo())
+		if !ok {
+			if kind.IsDependency() && !external(r.GetTo()) {
+				g.dangling[from] = appendUnique(g.dangling[from], r.GetTo())
+			}
+			continue
+		}
+		if kind.IsDependency() {
+			g.deps[from] = appendUnique(g.deps[from], to)
+		} else {
+			g.parts[from] = appendUnique(g.parts[from], to)
+		}
+	}
+
+	if pc := doc.PrimaryComp(); pc != nil && pc.IsPresent() {
+		g.root, _ = g.resolve(pc.GetID())
+	}
+
+	g.walk()
+	g.findCycles()
+
+	return g
+}
+
+// resolve returns the component id a relationship end refers to. SPDX 2
+// relationships and primary components carry the SPDXRef- prefix that
+// the component ids don't, as does the primary component of SPDX 3.
+func (g *Graph) resolve(id string) (string, bool) {
+	if _, ok := g.index[id]; ok {
+		return id, true
+	}
+	if trimmed, ok := strings.CutPrefix(id, "SPDXRef-"); ok {
+		if _, ok := g.index[trimmed]; ok {
+			return trimmed, true
+		}
+	}
+	return "", false
+}
+
+// external reports whether id is an element of another SPDX document,
+// which can't be resolved here but isn't dangling.
+func external(id string) bool {
+	return strings.HasPrefix(id, "DocumentRef-")
+}
+
+func appendUnique(ids []string, id string) []string {
+	if lo.Contains(ids, id) {
+		return ids
+	}
+	return append(ids, id)
+}
+
+// Root returns the id of the primary component, or "" if the SBOM has
+// none or it isn't one of the components.
+func (g *Graph) Root() string {
+	return g.root
+}
+
+// Dependencies returns the components id depends on.
+func (g *Graph) Dependencies(id string) []string {
+	return g.deps[id]
+}
+
+// HasDependencies reports whether the SBOM records any dependency, to a
+// component of the SBOM or not.
+func (g *Graph) HasDependencies() bool {
+	return len(g.deps) > 0 || len(g.dangling) > 0
+}
+
+// Dangling returns the dependencies of id that aren't components of the
+// SBOM. Elements of other SPDX documents aren't counted.
+func (g *Graph) Dangling(id string) []string {
+	return g.dangling[id]
+}
+
+// walk records the components reachable from the root, through
+// dependencies or containment, and the dependency depth of each
+// component reachable through dependencies alone.
+func (g *Graph) walk() {
+	g.reachable = map[string]bool{}
+	g.depths = map[string]int{}
+	if g.root == "" {
+		return
+	}
+
+	g.reachable[g.root] = true
+	queue := []string{g.root}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range slices.Concat(g.deps[id], g.parts[id]) {
+			if !g.reachable[next] {
+				g.reachable[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	g.depths[g.root] = 0
+	queue = []string{g.root}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range g.deps[id] {
+			if _, ok := g.depths[next]; !ok {
+				g.depths[next] = g.depths[id] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// Reachable reports whether id can be reached from the primary component
+// through dependencies or containment. Nothing is reachable without one.
+func (g *Graph) Reachable(id string) bool {
+	return g.reachable[id]
+}
+
+// Orphans returns the components that can't be reached from the primary
+// component, in document order, or nil if there is no primary component.
+func (g *Graph) Orphans() []string {
+	if g.root == "" {
+		return nil
+	}
+	return lo.Filter(g.ids, func(id string, _ int) bool {
+		return !g.reachable[id]
+	})
+}
+
+// Depth returns the length of the shortest dependency path from the
+// primary component to id, and false if there is none.
+func (g *Graph) Depth(id string) (int, bool) {
+	d, ok := g.depths[id]
+	return d, ok
+}
+
+// Depths returns the largest and the average depth of the transitive
+// dependencies of the primary component. Both are zero if it has none.
+func (g *Graph) Depths() (int, float64) {
+	var maxDepth, total, n int
+	for id, d := range g.depths {
+		if id == g.root {
+			continue
+		}
+		maxDepth = max(maxDepth, d)
+		total += d
+		n++
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	return maxDepth, float64(total) / float64(n)
+}
+
+// findCycles finds the strongly connected components of the dependency
+// edges with Tarjan's algorithm. A component that depends on itself is a
+// cycle too.
+func (g *Graph) findCycles() {
+	g.inCycle = map[string]bool{}
+
+	index := map[string]int{}
+	low := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+
+	var connect func(id string)
+	connect = func(id string) {
+		index[id] = len(index)
+		low[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, next := range g.deps[id] {
+			if _, ok := index[next]; !ok {
+				connect(next)
+				low[id] = min(low[id], low[next])
+			} else if onStack[next] {
+				low[id] = min(low[id], index[next])
+			}
+		}
+
+		if low[id] != index[id] {
+			return
+		}
+
+		var scc []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			scc = append(scc, top)
+			if top == id {
+				break
+			}
+		}
+		if len(scc) > 1 || lo.Contains(g.deps[id], id) {
+			sort.Slice(scc, func(i, j int) bool {
+				return g.index[scc[i]] < g.index[scc[j]]
+			})
+			g.cycles = append(g.cycles, scc)
+		}
+	}
+
+	for _, id := range g.ids {
+		if _, ok := index[id]; !ok {
+			connect(id)
+		}
+	}
+
+	sort.Slice(g.cycles, func(i, j int) bool {
+		return g.index[g.cycles[i][0]] < g.index[g.cycles[j][0]]
+	})
+	for _, cycle := range g.cycles {
+		for _, id := range cycle {
+			g.inCycle[id] = true
+		}
+	}
+}
+
+// Cycles returns the sets of components that depend on each other,
+// directly or transitively, each in document order.
+func (g *Graph) Cycles() [][]string {
+	return g.cycles
+}
+
+// InCycle reports whether id is part of a dependency cycle.
+func (g *Graph) InCycle(id string) bool {
+	return g.inCycle[id]
+}
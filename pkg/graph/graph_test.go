@@ -0,0 +1,136 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, input string) sbom.Document {
+	t.Helper()
+	doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(input), sbom.Signature{})
+	require.NoError(t, err)
+	return doc
+}
+
+func TestCDXGraph(t *testing.T) {
+	doc := parse(t, `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"type": "application", "bom-ref": "app", "name": "app",
+    "components": [{"type": "library", "bom-ref": "plugin", "name": "plugin"}]}},
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a"},
+    {"type": "library", "bom-ref": "b", "name": "b"},
+    {"type": "library", "bom-ref": "c", "name": "c"},
+    {"type": "library", "bom-ref": "d", "name": "d"},
+    {"type": "library", "bom-ref": "e", "name": "e"},
+    {"type": "library", "bom-ref": "f", "name": "f"}
+  ],
+  "dependencies": [
+    {"ref": "app", "dependsOn": ["a", "b"]},
+    {"ref": "a", "dependsOn": ["c", "missing"]},
+    {"ref": "c", "dependsOn": ["a"]},
+    {"ref": "d", "dependsOn": ["e"]},
+    {"ref": "f", "dependsOn": ["f"]}
+  ]
+}`)
+
+	g := New(doc)
+
+	assert.Equal(t, "app", g.Root())
+	assert.Equal(t, []string{"c"}, g.Dependencies("a"))
+	assert.Equal(t, []string{"missing"}, g.Dangling("a"))
+	assert.Empty(t, g.Dangling("app"))
+
+	assert.True(t, g.Reachable("plugin"), "nested components are reachable")
+	assert.True(t, g.Reachable("c"))
+	assert.Equal(t, []string{"d", "e", "f"}, g.Orphans())
+
+	assert.Equal(t, [][]string{{"a", "c"}, {"f"}}, g.Cycles())
+	assert.True(t, g.InCycle("c"))
+	assert.False(t, g.InCycle("b"))
+
+	depth, ok := g.Depth("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, depth)
+	_, ok = g.Depth("plugin")
+	assert.False(t, ok, "containment isn't a dependency")
+
+	maxDepth, avgDepth := g.Depths()
+	assert.Equal(t, 2, maxDepth)
+	assert.InDelta(t, 4.0/3.0, avgDepth, 0.001)
+}
+
+func TestSPDXGraph(t *testing.T) {
+	doc := parse(t, `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "test",
+  "documentNamespace": "https://example.com/test",
+  "creationInfo": {"created": "2024-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-app", "name": "app", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-lib", "name": "lib", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-tool", "name": "tool", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-stray", "name": "stray", "downloadLocation": "NOASSERTION"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relatedSpdxElement": "SPDXRef-app", "relationshipType": "DESCRIBES"},
+    {"spdxElementId": "SPDXRef-app", "relatedSpdxElement": "SPDXRef-lib", "relationshipType": "DEPENDS_ON"},
+    {"spdxElementId": "SPDXRef-tool", "relatedSpdxElement": "SPDXRef-app", "relationshipType": "BUILD_DEPENDENCY_OF"},
+    {"spdxElementId": "SPDXRef-lib", "relatedSpdxElement": "SPDXRef-gone", "relationshipType": "DEPENDS_ON"},
+    {"spdxElementId": "SPDXRef-lib", "relatedSpdxElement": "DocumentRef-other:SPDXRef-x", "relationshipType": "DEPENDS_ON"}
+  ]
+}`)
+
+	g := New(doc)
+
+	assert.Equal(t, "app", g.Root())
+	assert.Equal(t, []string{"lib", "tool"}, g.Dependencies("app"))
+	assert.Equal(t, []string{"SPDXRef-gone"}, g.Dangling("lib"), "external documents don't dangle")
+	assert.Equal(t, []string{"stray"}, g.Orphans())
+	assert.Empty(t, g.Cycles())
+}
+
+func TestGraphWithoutPrimaryComponent(t *testing.T) {
+	doc := parse(t, `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a"},
+    {"type": "library", "bom-ref": "b", "name": "b"}
+  ],
+  "dependencies": [{"ref": "a", "dependsOn": ["b"]}]
+}`)
+
+	g := New(doc)
+
+	assert.Equal(t, "", g.Root())
+	assert.Nil(t, g.Orphans())
+	assert.False(t, g.Reachable("a"))
+	maxDepth, avgDepth := g.Depths()
+	assert.Zero(t, maxDepth)
+	assert.Zero(t, avgDepth)
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history keeps the score reports of past runs, so the quality of a
+// product's SBOMs can be followed from release to release.
+//
+// The store is a file with one JSON record per line. Records are only ever
+// appended, so a store can be shared by runs and read with standard tools.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Record is a single scored SBOM.
+type Record struct {
+	RunID     string    `json:"run_id"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Product is the name runs of the same product are found by: the name
+	// of the primary component, or of the document when there is none.
+	Product   string `json:"product"`
+	Namespace string `json:"namespace,omitempty"`
+	SHA256    string `json:"sha256"`
+	Path      string `json:"path"`
+
+	EngineVersion string `json:"scoring_engine_version"`
+
+	// Report is the file's entry of the json score report.
+	Report json.RawMessage `json:"report"`
+}
+
+// Matches reports whether the record is of product, given as the product
+// name, the document namespace or the SBOM hash.
+func (r Record) Matches(product string) bool {
+	return product == r.Product || product == r.Namespace || product == r.SHA256
+}
+
+// Store is a history file.
+type Store struct {
+	path string
+}
+
+// DefaultPath returns the history file in the user's cache directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the cache directory: %w", err)
+	}
+	return filepath.Join(dir, "sbomqs", "history.jsonl"), nil
+}
+
+// New returns the store in the file at path, or at DefaultPath if path is
+// empty. The file is created by the first Add.
+func New(path string) (*Store, error) {
+	if path == "" {
+		p, err := DefaultPath()
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+	return &Store{path: path}, nil
+}
+
+// Path returns the file of the store.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Add appends records to the store.
+func (s *Store) Add(records ...Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("failed to encode history record: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history %s: %w", s.path, err)
+	}
+
+	// a single write, so concurrent runs don't interleave their records
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write history %s: %w", s.path, err)
+	}
+	return f.Close()
+}
+
+// Records returns all records in the order they were added. A store which
+// doesn't exist yet has none.
+func (s *Store) Records() ([]Record, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history %s: %w", s.path, err)
+	}
+	defer f.Close()
+
+	var records []Record
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 64*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid history record: %w", s.path, line, err)
+		}
+		records = append(records, r)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history %s: %w", s.path, err)
+	}
+
+	return records, nil
+}
+
+// Runs returns the last n records of product, oldest first. All records of
+// the product are returned if n is zero.
+func (s *Store) Runs(product string, n int) ([]Record, error) {
+	records, err := s.Records()
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []Record
+	for _, r := range records {
+		if r.Matches(product) {
+			runs = append(runs, r)
+		}
+	}
+
+	if n > 0 && len(runs) > n {
+		runs = runs[len(runs)-n:]
+	}
+	return runs, nil
+}
+
+// Product summarizes the records of a product.
+type Product struct {
+	Name  string    `json:"product"`
+	Runs  int       `json:"runs"`
+	Last  time.Time `json:"last_run"`
+	Score float64   `json:"last_score"`
+}
+
+// Products returns the products in the store, in the order they were first
+// recorded.
+func (s *Store) Products() ([]Product, error) {
+	records, err := s.Records()
+	if err != nil {
+		return nil, err
+	}
+
+	var products []Product
+	index := map[string]int{}
+	for _, r := range records {
+		i, ok := index[r.Product]
+		if !ok {
+			i = len(products)
+			index[r.Product] = i
+			products = append(products, Product{Name: r.Product})
+		}
+
+		p := &products[i]
+		p.Runs++
+		p.Last = r.Timestamp
+		if rep, err := r.scores(); err == nil {
+			p.Score = rep.AvgScore
+		}
+	}
+
+	return products, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func record(product, hash string, score float64, supplier string) Record {
+	return Record{
+		RunID:     hash,
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Product:   product,
+		SHA256:    hash,
+		Report: []byte(`{"avg_score": ` + formatScore(&score) + `,
+			"categories": [{"category": "NTIA-minimum-elements", "score": ` + formatScore(&score) + `}],
+			"scores": [
+				{"category": "NTIA-minimum-elements", "feature": "comp_with_supplier", "score": ` + supplier + `},
+				{"category": "Semantic", "feature": "comp_reachable", "score": 0, "ignored": true}
+			]}`),
+	}
+}
+
+func TestStore(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "sbomqs", "history.jsonl"))
+	require.NoError(t, err)
+
+	records, err := store.Records()
+	require.NoError(t, err)
+	assert.Empty(t, records, "a new store is empty")
+
+	require.NoError(t, store.Add(record("app", "h1", 6, "5")))
+	require.NoError(t, store.Add(record("lib", "h2", 9, "10"), record("app", "h3", 7, "10")))
+	require.NoError(t, store.Add(record("app", "h4", 8, "10")))
+
+	runs, err := store.Runs("app", 2)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "h3", runs[0].SHA256)
+	assert.Equal(t, "h4", runs[1].SHA256)
+
+	runs, err = store.Runs("app", 0)
+	require.NoError(t, err)
+	assert.Len(t, runs, 3)
+
+	runs, err = store.Runs("h2", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 1, "runs are found by hash")
+	assert.Equal(t, "lib", runs[0].Product)
+
+	products, err := store.Products()
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	assert.Equal(t, "app", products[0].Name)
+	assert.Equal(t, 3, products[0].Runs)
+	assert.InDelta(t, 8.0, products[0].Score, 1e-9)
+}
+
+func TestInvalidStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{}\nnot json\n"), 0o600))
+
+	store, err := New(path)
+	require.NoError(t, err)
+
+	_, err = store.Records()
+	assert.ErrorContains(t, err, "history.jsonl:2")
+}
+
+func TestTrends(t *testing.T) {
+	runs := []Record{record("app", "h1", 6, "5"), record("app", "h2", 8, "10")}
+
+	trends, err := NewTrends(runs)
+	require.NoError(t, err)
+
+	d, ok := trends.Overall.Delta()
+	assert.True(t, ok)
+	assert.InDelta(t, 2.0, d, 1e-9)
+
+	require.Len(t, trends.Features, 2)
+	d, ok = trends.Features[0].Delta()
+	assert.True(t, ok)
+	assert.InDelta(t, 5.0, d, 1e-9)
+
+	_, ok = trends.Features[1].Delta()
+	assert.False(t, ok, "an ignored feature has no trend")
+
+	var buf bytes.Buffer
+	require.NoError(t, NewHistoryReport("app", runs, nil, WithWriter(&buf)).Report())
+	assert.Contains(t, buf.String(), "comp_with_supplier")
+	assert.NotContains(t, buf.String(), "comp_reachable", "only changed features are shown")
+}
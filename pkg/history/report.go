@@ -0,0 +1,239 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type Option func(r *Report)
+
+// NewHistoryReport returns a report of the runs of a product, or of the
+// products in the store if runs is nil.
+func NewHistoryReport(product string, runs []Record, products []Product, opts ...Option) *Report {
+	r := &Report{
+		Product:  product,
+		Runs:     runs,
+		Products: products,
+		Out:      os.Stdout,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func WithFormat(f string) Option {
+	return func(r *Report) {
+		r.Format = f
+	}
+}
+
+func WithWriter(w io.Writer) Option {
+	return func(r *Report) {
+		r.Out = w
+	}
+}
+
+// WithFeatures limits the feature trends to features, by default only
+// features whose score changed are shown.
+func WithFeatures(features []string) Option {
+	return func(r *Report) {
+		r.Features = features
+	}
+}
+
+// Report holds the state for reporting the history command results
+type Report struct {
+	Product  string
+	Runs     []Record
+	Products []Product
+	Features []string
+	Format   string
+	Out      io.Writer
+}
+
+// Report renders the history command results in the specified format
+func (r *Report) Report() error {
+	if r.Product == "" {
+		if r.Format == "json" {
+			return r.writeJSON(struct {
+				Products []Product `json:"products"`
+			}{r.Products})
+		}
+		r.productsReport()
+		return nil
+	}
+
+	trends, err := NewTrends(r.Runs)
+	if err != nil {
+		return err
+	}
+	trends.Features = r.features(trends.Features)
+
+	if r.Format == "json" {
+		return r.writeJSON(r.jsonReport(trends))
+	}
+	r.runsReport(trends)
+	return nil
+}
+
+func (r *Report) features(trends []Trend) []Trend {
+	out := []Trend{}
+	for _, t := range trends {
+		if len(r.Features) > 0 {
+			for _, f := range r.Features {
+				if f == t.Feature {
+					out = append(out, t)
+					break
+				}
+			}
+			continue
+		}
+		if d, ok := t.Delta(); ok && math.Abs(d) > 1e-6 {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func (r *Report) productsReport() {
+	if len(r.Products) == 0 {
+		fmt.Fprintln(r.Out, "No runs recorded")
+		return
+	}
+
+	table := tablewriter.NewWriter(r.Out)
+	table.SetHeader([]string{"Product", "Runs", "Last Run", "Last Score"})
+	for _, p := range r.Products {
+		table.Append([]string{p.Name, fmt.Sprint(p.Runs), p.Last.Local().Format(time.DateTime), fmt.Sprintf("%0.1f", p.Score)})
+	}
+	table.Render()
+}
+
+func formatScore(f *float64) string {
+	if f == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%0.1f", *f)
+}
+
+func formatDelta(t Trend) string {
+	d, ok := t.Delta()
+	if !ok {
+		return "N/A"
+	}
+	// don't show a sign for a change too small to show
+	if math.Abs(d) < 0.05 {
+		return "0.0"
+	}
+	return fmt.Sprintf("%+0.1f", d)
+}
+
+func shortHash(h string) string {
+	if len(h) > 12 {
+		return h[:12]
+	}
+	return h
+}
+
+func (r *Report) runsReport(trends *Trends) {
+	if len(r.Runs) == 0 {
+		fmt.Fprintf(r.Out, "No runs recorded for %s\n", r.Product)
+		return
+	}
+
+	fmt.Fprintf(r.Out, "Product: %s\n", r.Product)
+	table := tablewriter.NewWriter(r.Out)
+	table.SetHeader([]string{"Run", "Timestamp", "SHA256", "File", "Score"})
+	for i, run := range r.Runs {
+		table.Append([]string{fmt.Sprint(i + 1), run.Timestamp.Local().Format(time.DateTime), shortHash(run.SHA256), run.Path, formatScore(trends.Overall.Scores[i])})
+	}
+	table.Render()
+
+	header := []string{"Category", "Feature"}
+	for i := range r.Runs {
+		header = append(header, fmt.Sprintf("Run %d", i+1))
+	}
+	header = append(header, "Trend")
+
+	row := func(t Trend, category, feature string) []string {
+		cols := []string{category, feature}
+		for _, s := range t.Scores {
+			cols = append(cols, formatScore(s))
+		}
+		return append(cols, formatDelta(t))
+	}
+
+	fmt.Fprintln(r.Out)
+	table = tablewriter.NewWriter(r.Out)
+	table.SetHeader(header)
+	table.SetAutoMergeCellsByColumnIndex([]int{0})
+	table.Append(row(trends.Overall, "Overall", ""))
+	for _, t := range trends.Categories {
+		table.Append(row(t, t.Category, ""))
+	}
+	for _, t := range trends.Features {
+		table.Append(row(t, t.Category, t.Feature))
+	}
+	table.Render()
+}
+
+type jsonRun struct {
+	RunID     string    `json:"run_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Namespace string    `json:"namespace,omitempty"`
+	SHA256    string    `json:"sha256"`
+	Path      string    `json:"path"`
+	Score     *float64  `json:"score"`
+}
+
+type jsonReport struct {
+	Product string    `json:"product"`
+	Runs    []jsonRun `json:"runs"`
+	Trends  *Trends   `json:"trends"`
+}
+
+func (r *Report) jsonReport(trends *Trends) jsonReport {
+	jr := jsonReport{Product: r.Product, Runs: []jsonRun{}, Trends: trends}
+	for i, run := range r.Runs {
+		jr.Runs = append(jr.Runs, jsonRun{
+			RunID:     run.RunID,
+			Timestamp: run.Timestamp,
+			Namespace: run.Namespace,
+			SHA256:    run.SHA256,
+			Path:      run.Path,
+			Score:     trends.Overall.Scores[i],
+		})
+	}
+	return jr
+}
+
+func (r *Report) writeJSON(v any) error {
+	o, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to print JSON report: %w", err)
+	}
+	fmt.Fprintln(r.Out, string(o))
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// report is the part of a recorded json report trends are built from.
+type report struct {
+	AvgScore   float64 `json:"avg_score"`
+	Categories []struct {
+		Category string  `json:"category"`
+		Score    float64 `json:"score"`
+	} `json:"categories"`
+	Scores []struct {
+		Category string  `json:"category"`
+		Feature  string  `json:"feature"`
+		Score    float64 `json:"score"`
+		Ignored  bool    `json:"ignored"`
+	} `json:"scores"`
+}
+
+func (r Record) scores() (*report, error) {
+	var rep report
+	if err := json.Unmarshal(r.Report, &rep); err != nil {
+		return nil, fmt.Errorf("invalid report in run %s: %w", r.RunID, err)
+	}
+	return &rep, nil
+}
+
+// Trend is a score over a series of runs. A score is nil for a run it
+// wasn't scored in, or in which it was N/A.
+type Trend struct {
+	Category string     `json:"category,omitempty"`
+	Feature  string     `json:"feature,omitempty"`
+	Scores   []*float64 `json:"scores"`
+}
+
+// Delta returns the change from the first to the last run the score was
+// scored in, and false if there are less than two.
+func (t Trend) Delta() (float64, bool) {
+	var first, last *float64
+	for _, s := range t.Scores {
+		if s == nil {
+			continue
+		}
+		if first == nil {
+			first = s
+		}
+		last = s
+	}
+	if first == nil || first == last {
+		return 0, false
+	}
+	return *last - *first, true
+}
+
+// Trends holds the scores of a series of runs.
+type Trends struct {
+	Overall    Trend   `json:"overall"`
+	Categories []Trend `json:"categories"`
+	Features   []Trend `json:"features"`
+}
+
+type trendKey struct {
+	category string
+	feature  string
+}
+
+// NewTrends returns the trends of the overall, category and feature scores
+// over runs. Categories and features are in the order they were first
+// scored.
+func NewTrends(runs []Record) (*Trends, error) {
+	t := &Trends{
+		Overall:    Trend{Scores: make([]*float64, len(runs))},
+		Categories: []Trend{},
+		Features:   []Trend{},
+	}
+
+	categories := map[string]int{}
+	features := map[trendKey]int{}
+
+	for i, run := range runs {
+		rep, err := run.scores()
+		if err != nil {
+			return nil, err
+		}
+
+		t.Overall.Scores[i] = &rep.AvgScore
+
+		for _, c := range rep.Categories {
+			j, ok := categories[c.Category]
+			if !ok {
+				j = len(t.Categories)
+				categories[c.Category] = j
+				t.Categories = append(t.Categories, Trend{Category: c.Category, Scores: make([]*float64, len(runs))})
+			}
+			t.Categories[j].Scores[i] = &c.Score
+		}
+
+		for _, s := range rep.Scores {
+			k := trendKey{s.Category, s.Feature}
+			j, ok := features[k]
+			if !ok {
+				j = len(t.Features)
+				features[k] = j
+				t.Features = append(t.Features, Trend{Category: s.Category, Feature: s.Feature, Scores: make([]*float64, len(runs))})
+			}
+			if !s.Ignored {
+				t.Features[j].Scores[i] = &s.Score
+			}
+		}
+	}
+
+	return t, nil
+}
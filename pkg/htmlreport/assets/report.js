@@ -0,0 +1,30 @@
+// Sorts tables by the clicked column, numerically when the cells carry a
+// data-value, alphabetically otherwise.
+(function () {
+  function value(cell) {
+    var v = cell.getAttribute("data-value");
+    return v === null ? cell.textContent.trim() : parseFloat(v);
+  }
+
+  document.querySelectorAll("table.sortable").forEach(function (table) {
+    var headers = table.querySelectorAll("thead th");
+
+    headers.forEach(function (th, index) {
+      th.addEventListener("click", function () {
+        var ascending = th.getAttribute("aria-sort") !== "ascending";
+        headers.forEach(function (h) { h.removeAttribute("aria-sort"); });
+        th.setAttribute("aria-sort", ascending ? "ascending" : "descending");
+
+        var body = table.tBodies[0];
+        var rows = Array.prototype.slice.call(body.rows);
+        rows.sort(function (a, b) {
+          var x = value(a.cells[index]);
+          var y = value(b.cells[index]);
+          var order = typeof x === "number" && typeof y === "number" ? x - y : String(x).localeCompare(String(y));
+          return ascending ? order : -order;
+        });
+        rows.forEach(function (row) { body.appendChild(row); });
+      });
+    });
+  });
+})();
@@ -0,0 +1,164 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package htmlreport renders score and compliance results as a single HTML
+// page, with its styles and scripts embedded so it can be viewed offline.
+package htmlreport
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"time"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+//go:embed assets
+var assets embed.FS
+
+// circumference of the gauge circle, its radius is 52
+const circumference = 2 * math.Pi * 52
+
+// Report is the page, an index of the files when there are several and the
+// results of each file.
+type Report struct {
+	Title     string
+	Generated string
+	Version   string
+	Files     []*File
+}
+
+// File is the result of a scored SBOM, or of a compliance report of one.
+type File struct {
+	ID          string
+	Name        string
+	Spec        string
+	SpecVersion string
+	Components  int
+
+	Score    float64
+	MaxScore float64
+
+	// Categories are shown as bars under the overall score
+	Categories []Category
+
+	// Features are the features scored, or the checks of a compliance report
+	Features []Feature
+
+	// FeaturesTitle heads the features, "Features" when empty
+	FeaturesTitle string
+
+	// Records are the results of each compliance check for each element
+	Records []Record
+
+	// Error is why the file couldn't be scored
+	Error string
+}
+
+type Category struct {
+	Name  string
+	Score float64
+}
+
+type Feature struct {
+	Category    string
+	Name        string
+	Score       float64
+	Description string
+	Ignored     bool
+	Failed      bool
+}
+
+type Record struct {
+	Element  string
+	Section  string
+	Field    string
+	Result   string
+	Required bool
+	Score    float64
+	Failed   bool
+}
+
+// New returns the report titled title of files.
+func New(title string, files ...*File) *Report {
+	for i, f := range files {
+		f.ID = fmt.Sprintf("file-%d", i+1)
+	}
+
+	return &Report{
+		Title:     title,
+		Generated: time.Now().UTC().Format(time.RFC3339),
+		Version:   version.GetVersionInfo().GitVersion,
+		Files:     files,
+	}
+}
+
+var funcs = template.FuncMap{
+	"score": func(s float64) string {
+		return fmt.Sprintf("%0.1f", s)
+	},
+	// percent is the share of maximum score s reached, for bars
+	"percent": func(s, maximum float64) string {
+		if maximum <= 0 {
+			return "0"
+		}
+		return fmt.Sprintf("%0.1f", math.Max(0, math.Min(100, s/maximum*100)))
+	},
+	// dash is the length of the gauge arc for score s
+	"dash": func(s, maximum float64) string {
+		if maximum <= 0 {
+			return "0"
+		}
+		return fmt.Sprintf("%0.2f %0.2f", math.Max(0, math.Min(1, s/maximum))*circumference, circumference)
+	},
+	// level grades score s, for colors
+	"level": func(s, maximum float64) string {
+		switch {
+		case maximum <= 0:
+			return "poor"
+		case s/maximum >= 0.8:
+			return "good"
+		case s/maximum >= 0.5:
+			return "fair"
+		default:
+			return "poor"
+		}
+	},
+}
+
+// Write renders the report as HTML.
+func (r *Report) Write(w io.Writer) error {
+	css, err := assets.ReadFile("assets/report.css")
+	if err != nil {
+		return err
+	}
+	js, err := assets.ReadFile("assets/report.js")
+	if err != nil {
+		return err
+	}
+
+	t, err := template.New("report.html.tmpl").Funcs(funcs).ParseFS(assets, "assets/report.html.tmpl")
+	if err != nil {
+		return err
+	}
+
+	return t.Execute(w, struct {
+		*Report
+		CSS template.CSS
+		JS  template.JS
+	}{r, template.CSS(css), template.JS(js)}) //nolint:gosec // embedded assets
+}
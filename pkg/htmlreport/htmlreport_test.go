@@ -0,0 +1,88 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package htmlreport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func file(name string) *File {
+	return &File{
+		Name:        name,
+		Spec:        "cyclonedx",
+		SpecVersion: "1.5",
+		Components:  2,
+		Score:       7.5,
+		MaxScore:    10,
+		Categories:  []Category{{Name: "Structural", Score: 9}},
+		Features: []Feature{
+			{Category: "Structural", Name: "sbom_spec", Score: 10, Description: "provided sbom is in a supported sbom format"},
+			{Category: "Structural", Name: "comp_with_supplier", Score: 5, Description: "1/2 <have> suppliers", Failed: true},
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []*File
+		index bool
+	}{
+		{"single file", []*File{file("a.json")}, false},
+		{"several files", []*File{file("a.json"), file("b.json"), {Name: "c.json", Error: "parse: unsupported format"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, New("SBOM Quality Score", tt.files...).Write(&buf))
+			out := buf.String()
+
+			assert.True(t, strings.HasPrefix(out, "<!DOCTYPE html>"))
+			assert.Contains(t, out, "<title>SBOM Quality Score</title>")
+			assert.Equal(t, tt.index, strings.Contains(out, `<section id="index">`))
+			assert.Equal(t, len(tt.files), strings.Count(out, `<section class="file"`))
+			for _, f := range tt.files {
+				assert.Contains(t, out, `id="`+f.ID+`"`)
+				if tt.index {
+					assert.Contains(t, out, `<a href="#`+f.ID+`">`+f.Name+`</a>`)
+				}
+			}
+
+			// everything is inlined
+			assert.NotContains(t, out, "<link")
+			assert.NotContains(t, out, "<script src")
+			assert.Contains(t, out, `table.sortable`)
+
+			assert.Contains(t, out, `stroke-dasharray="245.04 326.73"`)
+			assert.Contains(t, out, `style="width: 90.0%"`)
+			assert.Contains(t, out, "1/2 &lt;have&gt; suppliers", "text is escaped")
+			assert.Contains(t, out, `<tr class="failed">`)
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, New("SBOM Quality Score", &File{Name: "c.json", Error: "parse: unsupported format"}).Write(&buf))
+
+	assert.Contains(t, buf.String(), `<p class="error">parse: unsupported format</p>`)
+	assert.NotContains(t, buf.String(), "<svg")
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package junit writes JUnit XML reports, which CI servers render as test
+// results.
+package junit
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+type TestSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Suites   []*TestSuite `xml:"testsuite"`
+}
+
+type TestSuite struct {
+	Name       string      `xml:"name,attr"`
+	Tests      int         `xml:"tests,attr"`
+	Failures   int         `xml:"failures,attr"`
+	Errors     int         `xml:"errors,attr"`
+	Skipped    int         `xml:"skipped,attr"`
+	Timestamp  string      `xml:"timestamp,attr,omitempty"`
+	Properties *Properties `xml:"properties,omitempty"`
+	Cases      []TestCase  `xml:"testcase"`
+}
+
+type Properties struct {
+	Properties []Property `xml:"property"`
+}
+
+type Property struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	Classname string   `xml:"classname,attr"`
+	Failure   *Result  `xml:"failure,omitempty"`
+	Error     *Result  `xml:"error,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+// Result is the failure or error of a test case.
+type Result struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+type Skipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// New returns an empty report.
+func New() *TestSuites {
+	return &TestSuites{Name: "sbomqs", Suites: []*TestSuite{}}
+}
+
+// AddSuite adds a test suite to the report and returns it.
+func (t *TestSuites) AddSuite(name, timestamp string) *TestSuite {
+	s := &TestSuite{Name: name, Timestamp: timestamp, Cases: []TestCase{}}
+	t.Suites = append(t.Suites, s)
+	return s
+}
+
+// AddProperty adds a property of the tested SBOM to the suite.
+func (s *TestSuite) AddProperty(name, value string) {
+	if s.Properties == nil {
+		s.Properties = &Properties{}
+	}
+	s.Properties.Properties = append(s.Properties.Properties, Property{Name: name, Value: value})
+}
+
+// AddCase adds a test case to the suite.
+func (s *TestSuite) AddCase(c TestCase) {
+	s.Cases = append(s.Cases, c)
+}
+
+// count totals the test cases of every suite.
+func (t *TestSuites) count() {
+	t.Tests, t.Failures, t.Errors, t.Skipped = 0, 0, 0, 0
+
+	for _, s := range t.Suites {
+		s.Tests, s.Failures, s.Errors, s.Skipped = len(s.Cases), 0, 0, 0
+		for _, c := range s.Cases {
+			switch {
+			case c.Failure != nil:
+				s.Failures++
+			case c.Error != nil:
+				s.Errors++
+			case c.Skipped != nil:
+				s.Skipped++
+			}
+		}
+
+		t.Tests += s.Tests
+		t.Failures += s.Failures
+		t.Errors += s.Errors
+		t.Skipped += s.Skipped
+	}
+}
+
+// Write writes the report as indented XML.
+func (t *TestSuites) Write(w io.Writer) error {
+	t.count()
+
+	o, err := xml.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = w.Write(append(o, '\n'))
+	return err
+}
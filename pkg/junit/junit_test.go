@@ -0,0 +1,59 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package junit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	r := New()
+
+	s := r.AddSuite("a.json", "2024-01-02T03:04:05")
+	s.AddProperty("spec", "spdx")
+	s.AddCase(TestCase{Name: "comp_with_name", Classname: "Structural"})
+	s.AddCase(TestCase{Name: "comp_with_supplier", Classname: "Structural", Failure: &Result{Message: "score 0.0 is below 10.0"}})
+	s.AddCase(TestCase{Name: "sbom_dependencies", Classname: "Structural", Skipped: &Skipped{}})
+
+	e := r.AddSuite("b.json", "")
+	e.AddCase(TestCase{Name: "parse", Classname: "sbomqs", Error: &Result{Message: "unsupported format"}})
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Write(&buf))
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var got TestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &got))
+
+	assert.Equal(t, "sbomqs", got.Name)
+	assert.Equal(t, [4]int{4, 1, 1, 1}, [4]int{got.Tests, got.Failures, got.Errors, got.Skipped})
+
+	require.Len(t, got.Suites, 2)
+	a := got.Suites[0]
+	assert.Equal(t, "a.json", a.Name)
+	assert.Equal(t, [4]int{3, 1, 0, 1}, [4]int{a.Tests, a.Failures, a.Errors, a.Skipped})
+	assert.Equal(t, []Property{{Name: "spec", Value: "spdx"}}, a.Properties.Properties)
+	assert.Equal(t, "score 0.0 is below 10.0", a.Cases[1].Failure.Message)
+
+	b := got.Suites[1]
+	assert.Equal(t, [4]int{1, 0, 1, 0}, [4]int{b.Tests, b.Failures, b.Errors, b.Skipped})
+	assert.Nil(t, b.Properties)
+}
@@ -15,14 +15,16 @@
 package list
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
+	"github.com/interlynk-io/sbomqs/pkg/graph"
 	"github.com/interlynk-io/sbomqs/pkg/logger"
 	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/walk"
 	"github.com/samber/lo" // Added for lo.Contains
 )
 
@@ -64,7 +66,7 @@ func processPaths(ctx context.Context, ep *Params) ([]*Result, error) {
 
 	for _, path := range ep.Path {
 		// Get all file paths (handles files and directories)
-		paths, err := getFilePaths(ctx, path)
+		paths, err := getFilePaths(ctx, path, ep)
 		if err != nil {
 			log.Debugf("failed to get file paths for %s: %v", path, err)
 			continue
@@ -73,7 +75,7 @@ func processPaths(ctx context.Context, ep *Params) ([]*Result, error) {
 		// Process each file path
 		for _, filePath := range paths {
 			// Parse the SBOM document
-			currentDoc, err := parseSBOMDocument(ctx, filePath)
+			currentDoc, err := parseSBOMDocument(ctx, filePath, ep.Strict)
 			if err != nil {
 				log.Debugf("failed to parse SBOM document for %s: %v", filePath, err)
 				continue
@@ -85,6 +87,7 @@ func processPaths(ctx context.Context, ep *Params) ([]*Result, error) {
 					log.Debugf("failed to process feature %s for %s: %v", feature, filePath, err)
 					continue
 				}
+				featureResult.Diagnostics = currentDoc.Diagnostics()
 				results = append(results, featureResult)
 			}
 		}
@@ -94,48 +97,27 @@ func processPaths(ctx context.Context, ep *Params) ([]*Result, error) {
 }
 
 // getFilePaths returns a list of local file paths to process (handles files and directories)
-func getFilePaths(ctx context.Context, path string) ([]string, error) {
+func getFilePaths(ctx context.Context, path string, ep *Params) ([]string, error) {
 	log := logger.FromContext(ctx)
-	var paths []string
-
 	log.Debugf("Processing path: %s", path)
-	pathInfo, err := os.Stat(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to stat path %s: %w", path, err)
-	}
-
-	if pathInfo.IsDir() {
-		// Process all files in the directory
-		files, err := os.ReadDir(path)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read directory %s: %w", path, err)
-		}
-		for _, file := range files {
-			if file.IsDir() {
-				continue
-			}
-			filePath := filepath.Join(path, file.Name())
-			paths = append(paths, filePath)
-		}
-	} else {
-		// Single file
-		paths = append(paths, path)
-	}
 
-	return paths, nil
+	return walk.Paths(ctx, path, walk.Options{
+		Recurse: ep.Recurse,
+		Include: ep.Include,
+		Exclude: ep.Exclude,
+	})
 }
 
 // parseSBOMDocument parses an SBOM document from a local file path
-func parseSBOMDocument(ctx context.Context, filePath string) (sbom.Document, error) {
+func parseSBOMDocument(ctx context.Context, filePath string, strict bool) (sbom.Document, error) {
 	// log := logger.FromContext(ctx)
 
-	f, err := os.Open(filePath)
+	data, err := walk.ReadFile(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
-	defer f.Close()
 
-	currentDoc, err := sbom.NewSBOMDocument(ctx, f, sbom.Signature{})
+	currentDoc, err := sbom.NewSBOMDocument(ctx, bytes.NewReader(data), sbom.Signature{}, sbom.WithSchemaValidation(strict))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SBOM document for %s: %w", filePath, err)
 	}
@@ -180,24 +162,43 @@ func processComponentFeature(ctx context.Context, ep *Params, doc sbom.Document,
 	log.Debug("processing component feature: ", result.Feature)
 	result.Components = []ComponentResult{}
 	var totalComponents int
+	g := graph.New(doc)
 
 	// Evaluate the feature for each component
 	for _, comp := range doc.Components() {
 		log.Debugf("evaluating feature %s for component %s", result.Feature, comp.GetName())
 
 		// Evaluate the feature for the component
-		hasFeature, value, err := evaluateComponentFeature(result.Feature, comp, doc)
+		hasFeature, value, err := EvaluateComponentFeature(result.Feature, comp, doc, g)
 		if err != nil {
 			log.Debugf("failed to evaluate feature %s for component: %v", result.Feature, err)
 			result.Errors = append(result.Errors, fmt.Sprintf("failed to evaluate feature %s for component: %v", result.Feature, err))
 			continue
 		}
+
+		// known failures are left out
+		failing := hasFeature == negativeFeatures[result.Feature]
+		if ep.Baseline != nil && ep.Baseline.For(result.FilePath).Observe(result.Feature, comp, failing) {
+			result.Baselined++
+			continue
+		}
+
 		matchesCriteria := (hasFeature && !ep.Missing) || (!hasFeature && ep.Missing)
+		if ep.SARIF {
+			// SARIF reports the failing components, with or without --missing
+			matchesCriteria = failing
+		}
 		if matchesCriteria {
+			purl := ""
+			if purls := comp.GetPurls(); len(purls) > 0 {
+				purl = string(purls[0])
+			}
 			result.Components = append(result.Components, ComponentResult{
 				Name:    comp.GetName(),
 				Version: comp.GetVersion(),
 				Values:  value,
+				ID:      comp.GetID(),
+				Purl:    purl,
 			})
 		}
 		totalComponents++
@@ -244,17 +245,52 @@ func generateReport(ctx context.Context, results []*Result, ep *Params) error {
 		reportFormat = "basic"
 	} else if ep.JSON {
 		reportFormat = "json"
+	} else if ep.SARIF {
+		reportFormat = "sarif"
 	}
 	coloredOutput := ep.Color
 	show := ep.Show
 
 	lnr := NewListReport(ctx, results, WithFormat(strings.ToLower(reportFormat)), WithColor(coloredOutput), WithValues(show))
 	lnr.Report()
+
+	if ep.ShowDiagnostics {
+		// results are per feature, print each file's diagnostics once
+		seen := map[string]bool{}
+		for _, r := range results {
+			if !seen[r.FilePath] {
+				seen[r.FilePath] = true
+				sbom.WriteDiagnostics(os.Stderr, r.FilePath, r.Diagnostics)
+			}
+		}
+	}
 	return nil
 }
 
-// evaluateComponentFeature evaluates a component-based feature for a single component
-func evaluateComponentFeature(feature string, comp sbom.GetComponent, doc sbom.Document) (bool, string, error) {
+// negativeFeatures are the component features a component is better off
+// without.
+var negativeFeatures = map[string]bool{
+	"comp_with_deprecated_licenses":   true,
+	"comp_with_restrictive_licenses":  true,
+	"comp_with_dangling_dependencies": true,
+	"comp_in_dependency_cycle":        true,
+}
+
+// ComponentPasses reports whether comp passes a component feature: it has
+// the feature, or for a feature a component is better off without, it
+// hasn't.
+func ComponentPasses(feature string, comp sbom.GetComponent, doc sbom.Document, g *graph.Graph) (bool, error) {
+	ok, _, err := EvaluateComponentFeature(feature, comp, doc, g)
+	if err != nil {
+		return false, err
+	}
+	return ok != negativeFeatures[feature], nil
+}
+
+// EvaluateComponentFeature evaluates a component-based feature for a single component.
+// It reports whether the component has the feature and the value it has, g is the
+// dependency graph of doc.
+func EvaluateComponentFeature(feature string, comp sbom.GetComponent, doc sbom.Document, g *graph.Graph) (bool, string, error) {
 	switch feature {
 
 	case "comp_with_name":
@@ -320,6 +356,24 @@ func evaluateComponentFeature(feature string, comp sbom.GetComponent, doc sbom.D
 	case "comp_with_licenses":
 		return evaluateCompWithLicenses(comp)
 
+	case "comp_with_dangling_dependencies":
+		return evaluateCompWithDanglingDependencies(g, comp)
+
+	case "comp_reachable":
+		return evaluateCompReachable(g, comp)
+
+	case "comp_in_dependency_cycle":
+		return evaluateCompInDependencyCycle(g, comp)
+
+	case "comp_with_complete_dependencies":
+		return evaluateCompWithCompleteDependencies(comp)
+
+	case "comp_with_dependency_composition":
+		return evaluateCompWithDependencyComposition(comp)
+
+	case "comp_dependency_completeness":
+		return evaluateCompDependencyCompleteness(g, comp)
+
 	default:
 		return false, "", fmt.Errorf("unsupported component feature: %s", feature)
 	}
@@ -351,6 +405,9 @@ func evaluateSBOMFeature(feature string, doc sbom.Document) (bool, string, error
 	case "sbom_parsable":
 		return evaluateSBOMParsable(doc)
 
+	case "sbom_schema_valid":
+		return evaluateSBOMSchemaValid(doc)
+
 	case "sbom_spec":
 		return evaluateSBOMSpec(doc)
 
@@ -375,6 +432,9 @@ func evaluateSBOMFeature(feature string, doc sbom.Document) (bool, string, error
 	case "sbom_with_bomlinks":
 		return evaluateSBOMWithBomLinks(doc)
 
+	case "sbom_dependency_depth":
+		return evaluateSBOMDependencyDepth(doc)
+
 	// case "sbom_with_signature":
 	// 	return evaluateSBOMWithSignature(doc)
 
@@ -411,6 +471,9 @@ func featureToPropertyName(feature string) string {
 	case "sbom_parsable":
 		return "Parsable"
 
+	case "sbom_schema_valid":
+		return "Schema Valid"
+
 	case "sbom_spec":
 		return "Specification"
 
@@ -420,6 +483,9 @@ func featureToPropertyName(feature string) string {
 	case "sbom_spec_version":
 		return "Spec Version"
 
+	case "sbom_dependency_depth":
+		return "Dependency Depth"
+
 	default:
 		return strings.ReplaceAll(strings.TrimPrefix(feature, "sbom_"), "_", " ")
 	}
@@ -746,6 +812,71 @@ func evaluateCompWithDependencies(comp sbom.GetComponent) (bool, string, error)
 	return true, "contains dependencies", nil
 }
 
+// evaluateCompWithDanglingDependencies evaluates if the component depends on ids that aren't components of the SBOM
+func evaluateCompWithDanglingDependencies(g *graph.Graph, comp sbom.GetComponent) (bool, string, error) {
+	dangling := g.Dangling(comp.GetID())
+	return len(dangling) > 0, strings.Join(dangling, ","), nil
+}
+
+// evaluateCompReachable evaluates if the component can be reached from the primary component
+func evaluateCompReachable(g *graph.Graph, comp sbom.GetComponent) (bool, string, error) {
+	if !g.Reachable(comp.GetID()) {
+		return false, "", nil
+	}
+	if depth, ok := g.Depth(comp.GetID()); ok {
+		return true, fmt.Sprintf("dependency depth %d", depth), nil
+	}
+	if parent := comp.GetParentID(); parent != "" {
+		return true, "contained in " + parent, nil
+	}
+	return true, "contained", nil
+}
+
+// evaluateCompInDependencyCycle evaluates if the component is part of a dependency cycle
+func evaluateCompInDependencyCycle(g *graph.Graph, comp sbom.GetComponent) (bool, string, error) {
+	for _, cycle := range g.Cycles() {
+		if lo.Contains(cycle, comp.GetID()) {
+			return true, strings.Join(cycle, ","), nil
+		}
+	}
+	return false, "", nil
+}
+
+// evaluateCompWithCompleteDependencies evaluates if a composition declares the dependencies of the component complete
+func evaluateCompWithCompleteDependencies(comp sbom.GetComponent) (bool, string, error) {
+	aggregate := comp.GetDependencyComposition()
+	return aggregate == "complete", aggregate, nil
+}
+
+// evaluateCompWithDependencyComposition evaluates if a composition declares the completeness of the component's dependencies
+func evaluateCompWithDependencyComposition(comp sbom.GetComponent) (bool, string, error) {
+	aggregate := comp.GetDependencyComposition()
+	if aggregate == "" {
+		return false, "", nil
+	}
+
+	value := "dependencies " + aggregate
+	if assemblies := comp.GetComposition(comp.GetID()); assemblies != "" {
+		value += ", assemblies " + assemblies
+	}
+	return true, value, nil
+}
+
+// evaluateCompDependencyCompleteness evaluates if the component is in the dependency tree of the primary component and a composition declares its dependencies complete
+func evaluateCompDependencyCompleteness(g *graph.Graph, comp sbom.GetComponent) (bool, string, error) {
+	depth, ok := g.Depth(comp.GetID())
+	if !ok {
+		return false, "", nil
+	}
+
+	aggregate := comp.GetDependencyComposition()
+	value := fmt.Sprintf("dependency depth %d", depth)
+	if aggregate != "" {
+		value += ", " + aggregate
+	}
+	return aggregate == "complete", value, nil
+}
+
 // evaluateSBOMAuthors evaluates if the SBOM has authors
 func evaluateSBOMAuthors(doc sbom.Document) (bool, string, error) {
 	authors := doc.Authors()
@@ -828,6 +959,19 @@ func evaluateSBOMParsable(doc sbom.Document) (bool, string, error) {
 	return false, "SBOM is not parsable", nil
 }
 
+// evaluateSBOMSchemaValid evaluates if the SBOM validates against the JSON
+// schema of its spec version, which is only checked with --strict
+func evaluateSBOMSchemaValid(doc sbom.Document) (bool, string, error) {
+	res := doc.Schema()
+	if res == nil {
+		return false, "not validated, run with --strict", nil
+	}
+	if res.Violations > 0 {
+		return false, fmt.Sprintf("%d violations of the %s schema", res.Violations, res.Schema), nil
+	}
+	return true, fmt.Sprintf("valid against the %s schema", res.Schema), nil
+}
+
 // evaluateSBOMSpec evaluates if the SBOM has a specification
 func evaluateSBOMSpec(doc sbom.Document) (bool, string, error) {
 	specType := doc.Spec().GetSpecType()
@@ -938,3 +1082,13 @@ func evaluateSBOMWithBomLinks(doc sbom.Document) (bool, string, error) {
 	}
 	return true, strings.Join(linkValues, ", "), nil
 }
+
+// evaluateSBOMDependencyDepth evaluates if the SBOM records transitive dependencies of the primary component
+func evaluateSBOMDependencyDepth(doc sbom.Document) (bool, string, error) {
+	g := graph.New(doc)
+	if g.Root() == "" {
+		return false, "", nil
+	}
+	maxDepth, avgDepth := g.Depths()
+	return maxDepth > 1, fmt.Sprintf("max depth %d, avg %.2f", maxDepth, avgDepth), nil
+}
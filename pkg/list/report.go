@@ -77,6 +77,8 @@ func (r *Report) Report() {
 		r.detailedReport()
 	} else if r.Format == "json" {
 		r.jsonReport()
+	} else if r.Format == "sarif" {
+		r.sarifReport()
 	} else {
 		r.detailedReport()
 	}
@@ -90,7 +92,7 @@ func (r *Report) basicReport() {
 			presence = "missing"
 		}
 		if strings.HasPrefix(result.Feature, "comp_") {
-			fmt.Printf("\n%s: %s (%s): %d/%d components\n", result.FilePath, result.Feature, presence, len(result.Components), result.TotalComponents)
+			fmt.Printf("\n%s: %s (%s): %d/%d components%s\n", result.FilePath, result.Feature, presence, len(result.Components), result.TotalComponents, baselined(result))
 		} else {
 			fmt.Printf("\n%s: %s (%s): %s\n", result.FilePath, result.Feature, presence, result.DocumentProperty.Value)
 		}
@@ -119,7 +121,7 @@ func (r *Report) detailedReport() {
 			}
 
 			// Component-based feature
-			featureCol := fmt.Sprintf("%s (%d/%d)", result.Feature, len(result.Components), result.TotalComponents)
+			featureCol := fmt.Sprintf("%s (%d/%d%s)", result.Feature, len(result.Components), result.TotalComponents, baselined(result))
 			if len(result.Components) == 0 {
 				// No components to display
 				fmt.Println(" No components found")
@@ -174,6 +176,14 @@ func (r *Report) detailedReport() {
 	}
 }
 
+// baselined notes the components left out as known failures.
+func baselined(result *Result) string {
+	if result.Baselined == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", %d baselined", result.Baselined)
+}
+
 type component struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
@@ -188,6 +198,7 @@ type file struct {
 	Feature          string           `json:"feature"`
 	Missing          bool             `json:"missing"`
 	TotalComponents  int              `json:"total_components,omitempty"`
+	Baselined        int              `json:"baselined,omitempty"`
 	Components       []component      `json:"components,omitempty"`
 	DocumentProperty documentProperty `json:"document_property,omitempty"`
 	Errors           []string         `json:"errors"`
@@ -231,6 +242,7 @@ func (r *Report) jsonReport() {
 		if strings.HasPrefix(result.Feature, "comp_") {
 			// Component-based feature
 			f.TotalComponents = result.TotalComponents
+			f.Baselined = result.Baselined
 			for _, comp := range result.Components {
 				f.Components = append(f.Components, component{
 					Name:    comp.Name,
@@ -0,0 +1,58 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/sarif"
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+)
+
+// sarifReport renders the components failing each feature, and the SBOMs
+// missing each SBOM-based feature, as SARIF results of the feature.
+func (r *Report) sarifReport() {
+	l := sarif.NewLog()
+	run := l.Run()
+
+	for _, result := range r.Results {
+		descr := scorer.FeatureDescription(result.Feature)
+		if descr == "" {
+			descr = result.Feature
+		}
+		rule := sarif.Rule{
+			ID:                   result.Feature,
+			ShortDescription:     sarif.Message{Text: descr},
+			DefaultConfiguration: sarif.Configuration{Level: sarif.LevelWarning},
+		}
+
+		if strings.HasPrefix(result.Feature, "comp_") {
+			for _, comp := range result.Components {
+				run.AddRule(rule)
+				loc := sarif.ComponentLocation(comp.Name, comp.Version, comp.ID, comp.Purl)
+				run.AddResult(result.Feature, sarif.LevelWarning, fmt.Sprintf("%s fails %s", loc.Name, result.Feature), result.FilePath, loc)
+			}
+		} else if !result.DocumentProperty.Present {
+			run.AddRule(rule)
+			run.AddResult(result.Feature, sarif.LevelWarning, fmt.Sprintf("%s is not present", result.DocumentProperty.Key), result.FilePath, nil)
+		}
+	}
+
+	if err := l.Write(os.Stdout); err != nil {
+		fmt.Printf("Failed to print SARIF report: %v\n", err)
+	}
+}
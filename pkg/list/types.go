@@ -14,20 +14,29 @@
 
 package list
 
+import (
+	"github.com/interlynk-io/sbomqs/pkg/baseline"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+)
+
 type Result struct {
 	FilePath         string
 	Feature          string
 	Missing          bool
 	TotalComponents  int
+	Baselined        int               // components left out as known failures
 	Components       []ComponentResult // For component-based features
 	DocumentProperty DocumentResult    // For SBOM-based features
 	Errors           []string
+	Diagnostics      []sbom.Diagnostic // Parse diagnostics of the SBOM
 }
 
 type ComponentResult struct {
 	Name    string
 	Version string
 	Values  string
+	ID      string // SPDX identifier or CycloneDX bom-ref
+	Purl    string
 }
 
 type DocumentResult struct {
@@ -39,6 +48,11 @@ type DocumentResult struct {
 type Params struct {
 	Path []string
 
+	// directory control
+	Recurse bool
+	Include []string
+	Exclude []string
+
 	// input control
 	Features []string
 
@@ -46,10 +60,20 @@ type Params struct {
 	JSON     bool
 	Basic    bool
 	Detailed bool
+	SARIF    bool
 	Color    bool
 	Show     bool
 
 	Missing bool
 
 	Debug bool
+
+	// print parse diagnostics to stderr
+	ShowDiagnostics bool
+
+	// validate JSON documents against the schema of their spec version
+	Strict bool
+
+	// known failures left out of component features
+	Baseline *baseline.Baseline
 }
@@ -0,0 +1,95 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+)
+
+// assemblyFeature is the check which scores the assembly hierarchy, the
+// hierarchy is only reported when it was scored.
+const assemblyFeature = "comp_with_described_parts"
+
+type assembly struct {
+	ID        string      `json:"id,omitempty"`
+	Name      string      `json:"name"`
+	Version   string      `json:"version,omitempty"`
+	Described bool        `json:"described"`
+	Parts     []*assembly `json:"parts,omitempty"`
+}
+
+// scoredAssemblies reports whether the assembly hierarchy is part of scores.
+func scoredAssemblies(scores scorer.Scores) bool {
+	for _, s := range scores.ScoreList() {
+		if s.Feature() == assemblyFeature && !s.Ignore() {
+			return true
+		}
+	}
+	return false
+}
+
+// assemblies returns the top level components of doc which have parts,
+// with their parts.
+func assemblies(doc sbom.Document) []*assembly {
+	var out []*assembly
+	for _, n := range doc.ComponentTree() {
+		if len(n.Children) > 0 {
+			out = append(out, newAssembly(n))
+		}
+	}
+	return out
+}
+
+func newAssembly(n *sbom.ComponentNode) *assembly {
+	a := &assembly{
+		ID:        n.Component.GetID(),
+		Name:      n.Component.GetName(),
+		Version:   n.Component.GetVersion(),
+		Described: scorer.DescribedPart(n.Component),
+	}
+	for _, child := range n.Children {
+		a.Parts = append(a.Parts, newAssembly(child))
+	}
+	return a
+}
+
+// writeAssemblies prints the assemblies indented by depth, marking the
+// parts which aren't described.
+func writeAssemblies(w io.Writer, as []*assembly) {
+	if len(as) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "Assemblies:")
+	var write func(a *assembly, depth int)
+	write = func(a *assembly, depth int) {
+		line := strings.Repeat("  ", depth+1) + strings.TrimSpace(a.Name+" "+a.Version)
+		if depth > 0 && !a.Described {
+			line += " (not described)"
+		}
+		fmt.Fprintln(w, line)
+		for _, part := range a.Parts {
+			write(part, depth+1)
+		}
+	}
+	for _, a := range as {
+		write(a, 0)
+	}
+}
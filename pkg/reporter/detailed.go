@@ -19,6 +19,7 @@ import (
 	"os"
 
 	"github.com/fatih/color"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
 	"github.com/olekukonko/tablewriter"
 )
 
@@ -39,7 +40,7 @@ func (r *Reporter) detailedReport() {
 			outDoc = append(outDoc, l)
 		}
 
-		fmt.Printf("SBOM Quality by Interlynk Score:%0.1f\tcomponents:%d\t%s\n", scores.AvgScore(), len(doc.Components()), path)
+		fmt.Printf("SBOM Quality by Interlynk Score:%0.1f\tcomponents:%d\t%s\n", scores.AvgScore(), sbom.ComponentCount(doc), path)
 
 		// Initialize tablewriter table with borders
 		table := tablewriter.NewWriter(os.Stdout)
@@ -71,6 +72,10 @@ func (r *Reporter) detailedReport() {
 		}
 
 		table.Render()
+
+		if scoredAssemblies(scores) {
+			writeAssemblies(os.Stdout, assemblies(doc))
+		}
 	}
 }
 
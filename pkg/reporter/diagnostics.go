@@ -0,0 +1,55 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporter
+
+import (
+	"os"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+)
+
+type diagnostic struct {
+	Severity    string `json:"severity"`
+	Path        string `json:"path,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	Column      int    `json:"column,omitempty"`
+	ComponentID string `json:"component_id,omitempty"`
+	Message     string `json:"message"`
+}
+
+func toDiagnostics(diags []sbom.Diagnostic) []diagnostic {
+	if len(diags) == 0 {
+		return nil
+	}
+
+	out := make([]diagnostic, 0, len(diags))
+	for _, d := range diags {
+		out = append(out, diagnostic{
+			Severity:    string(d.Severity),
+			Path:        d.Path,
+			Line:        d.Line,
+			Column:      d.Column,
+			ComponentID: d.ComponentID,
+			Message:     d.Message,
+		})
+	}
+	return out
+}
+
+func (r *Reporter) diagnostics() {
+	for i, path := range r.Paths {
+		sbom.WriteDiagnostics(os.Stderr, path, r.Docs[i].Diagnostics())
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporter
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// FileError is a file which couldn't be scored, with the stage it failed in.
+type FileError struct {
+	Path  string `json:"file_name"`
+	Stage string `json:"stage"`
+	Error string `json:"error"`
+}
+
+func (r *Reporter) simpleErrors() {
+	for _, e := range r.Errors {
+		fmt.Fprintf(os.Stderr, "error\t%s\t%s\t%s\n", e.Stage, e.Path, e.Error)
+	}
+}
+
+func (r *Reporter) detailedErrors() {
+	if len(r.Errors) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Failed to score %d files\n", len(r.Errors))
+
+	table := tablewriter.NewWriter(os.Stderr)
+	table.SetHeader([]string{"File", "Stage", "Error"})
+	table.SetRowLine(true)
+	for _, e := range r.Errors {
+		table.Append([]string{e.Path, e.Stage, e.Error})
+	}
+	table.Render()
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporter
+
+import (
+	"os"
+
+	"github.com/interlynk-io/sbomqs/pkg/htmlreport"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+)
+
+// htmlReport renders every scored file, and the files which couldn't be
+// scored, as a single HTML page.
+func (r *Reporter) htmlReport() error {
+	files := []*htmlreport.File{}
+
+	for index, path := range r.Paths {
+		doc, scores := r.Docs[index], r.Scores[index]
+
+		f := &htmlreport.File{
+			Name:        path,
+			Spec:        doc.Spec().GetSpecType(),
+			SpecVersion: doc.Spec().GetVersion(),
+			Components:  sbom.ComponentCount(doc),
+			Score:       scores.AvgScore(),
+			MaxScore:    scorer.MAX_SCORE,
+		}
+
+		for _, cs := range scores.CategoryScores() {
+			f.Categories = append(f.Categories, htmlreport.Category{Name: cs.Category(), Score: cs.Score()})
+		}
+
+		for _, s := range scores.ScoreList() {
+			f.Features = append(f.Features, htmlreport.Feature{
+				Category:    s.Category(),
+				Name:        s.Feature(),
+				Score:       s.Score(),
+				Description: s.Descr(),
+				Ignored:     s.Ignore(),
+				Failed:      !s.Ignore() && s.Score() < s.MaxScore(),
+			})
+		}
+
+		files = append(files, f)
+	}
+
+	for _, e := range r.Errors {
+		files = append(files, &htmlreport.File{Name: e.Path, Error: e.Stage + ": " + e.Error})
+	}
+
+	return htmlreport.New("SBOM Quality Score", files...).Write(os.Stdout)
+}
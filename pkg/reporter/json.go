@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
 	"github.com/interlynk-io/sbomqs/pkg/scorer"
 	"sigs.k8s.io/release-utils/version"
 )
@@ -29,20 +30,44 @@ type score struct {
 	Feature  string  `json:"feature"`
 	Score    float64 `json:"score"`
 	MaxScore float64 `json:"max_score"`
+	Weight   float64 `json:"weight"`
 	Desc     string  `json:"description"`
 	Ignored  bool    `json:"ignored"`
 }
+type categoryScore struct {
+	Category    string  `json:"category"`
+	Score       float64 `json:"score"`
+	MaxScore    float64 `json:"max_score"`
+	Weight      float64 `json:"weight"`
+	NumFeatures int     `json:"num_features"`
+}
+
+type subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type attestation struct {
+	PredicateType string    `json:"predicate_type"`
+	Subjects      []subject `json:"subjects"`
+	Signed        bool      `json:"signed"`
+}
+
 type file struct {
-	Name         string   `json:"file_name"`
-	Spec         string   `json:"spec"`
-	SpecVersion  string   `json:"spec_version"`
-	Format       string   `json:"file_format"`
-	AvgScore     float64  `json:"avg_score"`
-	Components   int      `json:"num_components"`
-	CreationTime string   `json:"creation_time"`
-	ToolName     string   `json:"gen_tool_name"`
-	ToolVersion  string   `json:"gen_tool_version"`
-	Scores       []*score `json:"scores"`
+	Name         string           `json:"file_name"`
+	Spec         string           `json:"spec"`
+	SpecVersion  string           `json:"spec_version"`
+	Format       string           `json:"file_format"`
+	AvgScore     float64          `json:"avg_score"`
+	Components   int              `json:"num_components"`
+	CreationTime string           `json:"creation_time"`
+	ToolName     string           `json:"gen_tool_name"`
+	ToolVersion  string           `json:"gen_tool_version"`
+	Categories   []*categoryScore `json:"categories"`
+	Scores       []*score         `json:"scores"`
+	Attestation  *attestation     `json:"attestation,omitempty"`
+	Diagnostics  []diagnostic     `json:"diagnostics,omitempty"`
+	Assemblies   []*assembly      `json:"assemblies,omitempty"`
 }
 
 type creation struct {
@@ -53,10 +78,11 @@ type creation struct {
 }
 
 type jsonReport struct {
-	RunID        string   `json:"run_id"`
-	TimeStamp    string   `json:"timestamp"`
-	CreationInfo creation `json:"creation_info"`
-	Files        []file   `json:"files"`
+	RunID        string      `json:"run_id"`
+	TimeStamp    string      `json:"timestamp"`
+	CreationInfo creation    `json:"creation_info"`
+	Files        []file      `json:"files"`
+	Errors       []FileError `json:"errors"`
 }
 
 func newJSONReport() *jsonReport {
@@ -69,46 +95,18 @@ func newJSONReport() *jsonReport {
 			ScoringEngine: scorer.EngineVersion,
 			Vendor:        "Interlynk (support@interlynk.io)",
 		},
-		Files: []file{},
+		Files:  []file{},
+		Errors: []FileError{},
 	}
 }
 
 func (r *Reporter) jsonReport(onlyResponse bool) (string, error) {
 	jr := newJSONReport()
 	for index, path := range r.Paths {
-		doc := r.Docs[index]
-		scores := r.Scores[index]
-
-		f := file{}
-		f.AvgScore = scores.AvgScore()
-		f.Components = len(doc.Components())
-		f.Format = doc.Spec().FileFormat()
-		f.Name = path
-		f.Spec = doc.Spec().GetSpecType()
-		f.SpecVersion = doc.Spec().GetVersion()
-		tools := doc.Tools()
-
-		if len(tools) > 0 {
-			// Use the first tool for now
-			f.ToolName = tools[0].GetName()
-			f.ToolVersion = tools[0].GetVersion()
-		}
-		f.CreationTime = doc.Spec().GetCreationTimestamp()
-
-		for _, ss := range scores.ScoreList() {
-			ns := new(score)
-			ns.Category = ss.Category()
-			ns.Feature = ss.Feature()
-			ns.Score = ss.Score()
-			ns.MaxScore = ss.MaxScore()
-			ns.Desc = ss.Descr()
-			ns.Ignored = ss.Ignore()
-
-			f.Scores = append(f.Scores, ns)
-		}
-
-		jr.Files = append(jr.Files, f)
+		jr.Files = append(jr.Files, newFile(path, r.Docs[index], r.Scores[index]))
 	}
+	jr.Errors = append(jr.Errors, r.Errors...)
+
 	o, err := json.MarshalIndent(jr, "", "  ")
 	if err != nil {
 		return "", err
@@ -118,3 +116,69 @@ func (r *Reporter) jsonReport(onlyResponse bool) (string, error) {
 	}
 	return string(o), nil
 }
+
+func newFile(path string, doc sbom.Document, scores scorer.Scores) file {
+	f := file{}
+	f.AvgScore = scores.AvgScore()
+	f.Components = sbom.ComponentCount(doc)
+	f.Format = doc.Spec().FileFormat()
+	f.Name = path
+	f.Spec = doc.Spec().GetSpecType()
+	f.SpecVersion = doc.Spec().GetVersion()
+	tools := doc.Tools()
+
+	if len(tools) > 0 {
+		// Use the first tool for now
+		f.ToolName = tools[0].GetName()
+		f.ToolVersion = tools[0].GetVersion()
+	}
+	f.CreationTime = doc.Spec().GetCreationTimestamp()
+
+	if att := doc.Attestation(); att != nil {
+		f.Attestation = &attestation{
+			PredicateType: att.GetPredicateType(),
+			Subjects:      []subject{},
+			Signed:        att.IsSigned(),
+		}
+		for _, s := range att.GetSubjects() {
+			f.Attestation.Subjects = append(f.Attestation.Subjects, subject{Name: s.Name, Digest: s.Digest})
+		}
+	}
+
+	f.Diagnostics = toDiagnostics(doc.Diagnostics())
+
+	if scoredAssemblies(scores) {
+		f.Assemblies = assemblies(doc)
+	}
+
+	for _, cs := range scores.CategoryScores() {
+		f.Categories = append(f.Categories, &categoryScore{
+			Category:    cs.Category(),
+			Score:       cs.Score(),
+			MaxScore:    scorer.MAX_SCORE,
+			Weight:      cs.Weight(),
+			NumFeatures: cs.Count(),
+		})
+	}
+
+	for _, ss := range scores.ScoreList() {
+		ns := new(score)
+		ns.Category = ss.Category()
+		ns.Feature = ss.Feature()
+		ns.Score = ss.Score()
+		ns.MaxScore = ss.MaxScore()
+		ns.Weight = ss.Weight()
+		ns.Desc = ss.Descr()
+		ns.Ignored = ss.Ignore()
+
+		f.Scores = append(f.Scores, ns)
+	}
+
+	return f
+}
+
+// FileJSON returns the JSON report of a single scored file, as it appears in
+// the files of the json report.
+func FileJSON(path string, doc sbom.Document, scores scorer.Scores) ([]byte, error) {
+	return json.Marshal(newFile(path, doc, scores))
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/interlynk-io/sbomqs/pkg/junit"
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+)
+
+// junitReport reports each scored file as a test suite, with a test case per
+// feature. A feature fails below its minimum in Thresholds, or below the
+// maximum score when it has none. Features which don't apply are skipped and
+// files which couldn't be scored are suites with an error.
+func (r *Reporter) junitReport() error {
+	report := junit.New()
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05")
+
+	for index, path := range r.Paths {
+		doc, scores := r.Docs[index], r.Scores[index]
+
+		suite := report.AddSuite(path, timestamp)
+		suite.AddProperty("spec", doc.Spec().GetSpecType())
+		suite.AddProperty("spec_version", doc.Spec().GetVersion())
+		suite.AddProperty("avg_score", fmt.Sprintf("%0.1f", scores.AvgScore()))
+		suite.AddProperty("scoring_engine_version", scorer.EngineVersion)
+
+		for _, s := range scores.ScoreList() {
+			tc := junit.TestCase{Name: s.Feature(), Classname: s.Category()}
+
+			minimum, ok := r.Thresholds.FeatureMin(s.Category(), s.Feature())
+			if !ok {
+				minimum = s.MaxScore()
+			}
+
+			switch {
+			case s.Ignore():
+				tc.Skipped = &junit.Skipped{Message: s.Descr()}
+			case s.Score() < minimum:
+				tc.Failure = &junit.Result{
+					Message: fmt.Sprintf("score %0.1f is below %0.1f", s.Score(), minimum),
+					Type:    "threshold",
+					Text:    s.Descr(),
+				}
+			default:
+				tc.SystemOut = fmt.Sprintf("score %0.1f: %s", s.Score(), s.Descr())
+			}
+
+			suite.AddCase(tc)
+		}
+	}
+
+	for _, e := range r.Errors {
+		suite := report.AddSuite(e.Path, timestamp)
+		suite.AddCase(junit.TestCase{
+			Name:      e.Stage,
+			Classname: "sbomqs",
+			Error:     &junit.Result{Message: e.Error, Type: e.Stage},
+		})
+	}
+
+	return report.Write(os.Stdout)
+}
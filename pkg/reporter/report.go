@@ -32,9 +32,17 @@ type Reporter struct {
 	// optional params
 	Format string
 	Color  bool
+	Errors []FileError
+
+	// ShowDiagnostics prints the parse diagnostics to stderr
+	ShowDiagnostics bool
+
+	// Thresholds holds the feature minimums of the junit format, and the
+	// required features of the sarif format
+	Thresholds scorer.Thresholds
 }
 
-var ReportFormats = []string{"basic", "detailed", "json"}
+var ReportFormats = []string{"basic", "detailed", "json", "sarif", "junit", "html"}
 
 type Option func(r *Reporter)
 
@@ -50,6 +58,29 @@ func WithColor(c bool) Option {
 	}
 }
 
+// WithErrors adds the files which couldn't be scored to the report.
+func WithErrors(e []FileError) Option {
+	return func(r *Reporter) {
+		r.Errors = e
+	}
+}
+
+// WithDiagnostics prints the parse diagnostics of every SBOM to stderr after
+// the report.
+func WithDiagnostics(show bool) Option {
+	return func(r *Reporter) {
+		r.ShowDiagnostics = show
+	}
+}
+
+// WithThresholds sets the minimums features are tested against in the junit
+// format, and which features are required in the sarif format.
+func WithThresholds(t scorer.Thresholds) Option {
+	return func(r *Reporter) {
+		r.Thresholds = t
+	}
+}
+
 func NewReport(ctx context.Context, doc []sbom.Document, scores []scorer.Scores, paths []string, opts ...Option) *Reporter {
 	r := &Reporter{
 		Ctx:    ctx,
@@ -67,15 +98,35 @@ func NewReport(ctx context.Context, doc []sbom.Document, scores []scorer.Scores,
 func (r *Reporter) Report() {
 	if r.Format == "basic" {
 		r.simpleReport()
+		r.simpleErrors()
 	} else if r.Format == "detailed" {
 		r.detailedReport()
+		r.detailedErrors()
 	} else if r.Format == "json" {
 		_, err := r.jsonReport(false)
 		if err != nil {
 			log.Printf("Failed to print json report: %v", err)
 		}
+	} else if r.Format == "sarif" {
+		if err := r.sarifReport(); err != nil {
+			log.Printf("Failed to print sarif report: %v", err)
+		}
+		r.simpleErrors()
+	} else if r.Format == "junit" {
+		if err := r.junitReport(); err != nil {
+			log.Printf("Failed to print junit report: %v", err)
+		}
+	} else if r.Format == "html" {
+		if err := r.htmlReport(); err != nil {
+			log.Printf("Failed to print html report: %v", err)
+		}
 	} else {
 		r.detailedReport()
+		r.detailedErrors()
+	}
+
+	if r.ShowDiagnostics {
+		r.diagnostics()
 	}
 }
 
@@ -0,0 +1,104 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/graph"
+	"github.com/interlynk-io/sbomqs/pkg/list"
+	"github.com/interlynk-io/sbomqs/pkg/sarif"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/scorer"
+)
+
+// sarifReport reports every feature scoring below the maximum as a result,
+// with the feature as rule. Features with a minimum in Thresholds, of their
+// own or of their category, are required and errors, the others warnings.
+// A component feature has a result per failing component, located at the
+// component, any other feature a single result located at the scored file.
+func (r *Reporter) sarifReport() error {
+	l := sarif.NewLog()
+	run := l.Run()
+
+	for index, path := range r.Paths {
+		doc := r.Docs[index]
+		g := graph.New(doc)
+
+		for _, s := range r.Scores[index].ScoreList() {
+			if s.Ignore() || s.Score() >= s.MaxScore() {
+				continue
+			}
+
+			level := sarif.LevelWarning
+			if r.required(s) {
+				level = sarif.LevelError
+			}
+
+			descr := scorer.FeatureDescription(s.Feature())
+			if descr == "" {
+				descr = s.Feature()
+			}
+			run.AddRule(sarif.Rule{
+				ID:                   s.Feature(),
+				ShortDescription:     sarif.Message{Text: descr},
+				DefaultConfiguration: sarif.Configuration{Level: level},
+			})
+
+			msg := fmt.Sprintf("%s scored %0.1f/%0.1f: %s", s.Feature(), s.Score(), s.MaxScore(), s.Descr())
+			comps := failingComponents(s.Feature(), doc, g)
+			if len(comps) == 0 {
+				run.AddResult(s.Feature(), level, msg, path, nil)
+				continue
+			}
+			for _, c := range comps {
+				run.AddResult(s.Feature(), level, msg, path, sarif.Component(c))
+			}
+		}
+	}
+
+	return l.Write(os.Stdout)
+}
+
+// required reports whether the score of s is gated by a minimum.
+func (r *Reporter) required(s scorer.Score) bool {
+	if _, ok := r.Thresholds.FeatureMin(s.Category(), s.Feature()); ok {
+		return true
+	}
+	_, ok := r.Thresholds.Categories[s.Category()]
+	return ok
+}
+
+// failingComponents returns the components of doc which fail a component
+// feature, none for features which can't be evaluated per component.
+func failingComponents(feature string, doc sbom.Document, g *graph.Graph) []sbom.GetComponent {
+	if !strings.HasPrefix(feature, "comp_") {
+		return nil
+	}
+
+	var comps []sbom.GetComponent
+	for _, c := range doc.Components() {
+		passes, err := list.ComponentPasses(feature, c, doc, g)
+		if err != nil {
+			return nil
+		}
+		if !passes {
+			comps = append(comps, c)
+		}
+	}
+	return comps
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sarif writes SARIF 2.1.0 logs, the format code scanning dashboards
+// ingest, for the failures found in SBOMs.
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"sigs.k8s.io/release-utils/version"
+)
+
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+
+	informationURI = "https://github.com/interlynk-io/sbomqs"
+
+	// fingerprintKey names the fingerprint which keeps results of different
+	// components of the same rule and file apart.
+	fingerprintKey = "sbomqsLocation/v1"
+)
+
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []*Run `json:"runs"`
+}
+
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+
+	rules map[string]int
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri"`
+	Rules          []Rule `json:"rules"`
+}
+
+type Rule struct {
+	ID                   string         `json:"id"`
+	Name                 string         `json:"name,omitempty"`
+	ShortDescription     Message        `json:"shortDescription"`
+	DefaultConfiguration Configuration  `json:"defaultConfiguration"`
+	Properties           map[string]any `json:"properties,omitempty"`
+}
+
+type Configuration struct {
+	Level string `json:"level"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type Result struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             Message           `json:"message"`
+	Locations           []Location        `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type Location struct {
+	PhysicalLocation PhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type LogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// NewLog returns a log with a single run of sbomqs.
+func NewLog() *Log {
+	return &Log{
+		Schema:  Schema,
+		Version: Version,
+		Runs: []*Run{{
+			Tool: Tool{Driver: Driver{
+				Name:           "sbomqs",
+				Version:        version.GetVersionInfo().GitVersion,
+				InformationURI: informationURI,
+				Rules:          []Rule{},
+			}},
+			Results: []Result{},
+			rules:   map[string]int{},
+		}},
+	}
+}
+
+// Run returns the run of the log.
+func (l *Log) Run() *Run {
+	return l.Runs[0]
+}
+
+// AddRule adds a rule to the run, unless a rule with its ID was added before.
+func (r *Run) AddRule(rule Rule) {
+	if _, ok := r.rules[rule.ID]; ok {
+		return
+	}
+	r.rules[rule.ID] = len(r.Tool.Driver.Rules)
+	r.Tool.Driver.Rules = append(r.Tool.Driver.Rules, rule)
+}
+
+// AddResult adds a result of a rule added before, located in the SBOM at path
+// and at the logical location c, usually a component, when c isn't nil.
+func (r *Run) AddResult(ruleID, level, message, path string, c *LogicalLocation) {
+	loc := Location{PhysicalLocation: PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: path}}}
+	fingerprint := "document"
+	if c != nil {
+		loc.LogicalLocations = []LogicalLocation{*c}
+		fingerprint = c.FullyQualifiedName
+		if fingerprint == "" {
+			fingerprint = c.Name
+		}
+	}
+
+	r.Results = append(r.Results, Result{
+		RuleID:              ruleID,
+		RuleIndex:           r.rules[ruleID],
+		Level:               level,
+		Message:             Message{Text: message},
+		Locations:           []Location{loc},
+		PartialFingerprints: map[string]string{fingerprintKey: fingerprint},
+	})
+}
+
+// Write writes the log as indented JSON.
+func (l *Log) Write(w io.Writer) error {
+	o, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(o, '\n'))
+	return err
+}
+
+// Component locates a result at a component of the SBOM, identified by its
+// purl, or by its SPDX identifier or CycloneDX bom-ref without one.
+func Component(c sbom.GetComponent) *LogicalLocation {
+	purl := ""
+	if purls := c.GetPurls(); len(purls) > 0 {
+		purl = string(purls[0])
+	}
+	return ComponentLocation(c.GetName(), c.GetVersion(), c.GetID(), purl)
+}
+
+// ComponentLocation locates a result at the component with name, version, id
+// and purl, see Component.
+func ComponentLocation(name, version, id, purl string) *LogicalLocation {
+	if version != "" {
+		name += "@" + version
+	}
+
+	fqn := id
+	if purl != "" {
+		fqn = purl
+	}
+
+	return &LogicalLocation{Name: name, FullyQualifiedName: fqn, Kind: "module"}
+}
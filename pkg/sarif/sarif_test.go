@@ -0,0 +1,81 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	l := NewLog()
+	run := l.Run()
+
+	run.AddRule(Rule{ID: "comp_with_supplier", DefaultConfiguration: Configuration{Level: LevelWarning}})
+	run.AddRule(Rule{ID: "sbom_authors", DefaultConfiguration: Configuration{Level: LevelError}})
+	run.AddRule(Rule{ID: "comp_with_supplier", DefaultConfiguration: Configuration{Level: LevelError}})
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, LevelWarning, run.Tool.Driver.Rules[0].DefaultConfiguration.Level, "first rule is kept")
+
+	run.AddResult("sbom_authors", LevelError, "no authors", "sbom.json", nil)
+	run.AddResult("comp_with_supplier", LevelWarning, "no supplier", "sbom.json", ComponentLocation("a", "1.0", "SPDXRef-a", "pkg:npm/a@1.0"))
+	run.AddResult("comp_with_supplier", LevelWarning, "no supplier", "sbom.json", ComponentLocation("b", "", "SPDXRef-b", ""))
+
+	var buf bytes.Buffer
+	require.NoError(t, l.Write(&buf))
+
+	var got struct {
+		Schema  string `json:"$schema"`
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID              string            `json:"ruleId"`
+				RuleIndex           int               `json:"ruleIndex"`
+				PartialFingerprints map[string]string `json:"partialFingerprints"`
+				Locations           []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+					LogicalLocations []LogicalLocation `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	assert.Equal(t, Version, got.Version)
+	assert.Equal(t, Schema, got.Schema)
+	require.Len(t, got.Runs, 1)
+
+	results := got.Runs[0].Results
+	require.Len(t, results, 3)
+
+	assert.Equal(t, 1, results[0].RuleIndex)
+	assert.Equal(t, "sbom.json", results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Empty(t, results[0].Locations[0].LogicalLocations)
+	assert.Equal(t, "document", results[0].PartialFingerprints[fingerprintKey])
+
+	assert.Equal(t, 0, results[1].RuleIndex)
+	assert.Equal(t, []LogicalLocation{{Name: "a@1.0", FullyQualifiedName: "pkg:npm/a@1.0", Kind: "module"}}, results[1].Locations[0].LogicalLocations)
+	assert.Equal(t, "pkg:npm/a@1.0", results[1].PartialFingerprints[fingerprintKey])
+
+	assert.Equal(t, []LogicalLocation{{Name: "b", FullyQualifiedName: "SPDXRef-b", Kind: "module"}}, results[2].Locations[0].LogicalLocations)
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// sbomPredicateTypes are the in-toto predicate type prefixes that carry an
+// SBOM, e.g. https://spdx.dev/Document or https://cyclonedx.org/bom/v1.5.
+var sbomPredicateTypes = []string{
+	"https://spdx.dev/",
+	"https://spdx.org/",
+	"https://cyclonedx.org/bom",
+}
+
+type GetAttestation interface {
+	GetPredicateType() string
+	GetSubjects() []Subject
+	IsSigned() bool
+}
+
+// Subject is an artifact an in-toto statement makes claims about, with its
+// digests keyed by algorithm.
+type Subject struct {
+	Name   string
+	Digest map[string]string
+}
+
+// Attestation records the in-toto statement an SBOM was unwrapped from and,
+// when the statement came in a DSSE envelope, what the envelope signed.
+type Attestation struct {
+	PredicateType string
+	Subjects      []Subject
+
+	// PayloadType and Payload are the DSSE payload type and the decoded
+	// payload, and Signatures the decoded signatures over them. They are
+	// empty for a bare statement.
+	PayloadType string
+	Payload     []byte
+	Signatures  [][]byte
+}
+
+func (a *Attestation) GetPredicateType() string {
+	return a.PredicateType
+}
+
+func (a *Attestation) GetSubjects() []Subject {
+	return a.Subjects
+}
+
+func (a *Attestation) IsSigned() bool {
+	return len(a.Signatures) > 0
+}
+
+// PAE returns the DSSE pre-authentication encoding of the payload, which is
+// the message the envelope signatures are made over.
+func (a *Attestation) PAE() []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(a.PayloadType), a.PayloadType, len(a.Payload), a.Payload))
+}
+
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		KeyID string `json:"keyid"`
+		Sig   string `json:"sig"`
+	} `json:"signatures"`
+}
+
+type attestationWrapper struct {
+	dsseEnvelope
+
+	// A Sigstore bundle carries the envelope under dsseEnvelope.
+	DSSEEnvelope *dsseEnvelope `json:"dsseEnvelope"`
+
+	// in-toto statement
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+	Subject       []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+func (w *attestationWrapper) isStatement() bool {
+	return strings.HasPrefix(w.Type, "https://in-toto.io/Statement/") && len(w.Predicate) > 0
+}
+
+func (w *attestationWrapper) envelope() *dsseEnvelope {
+	if w.DSSEEnvelope != nil {
+		return w.DSSEEnvelope
+	}
+	if w.PayloadType != "" && w.Payload != "" {
+		return &w.dsseEnvelope
+	}
+	return nil
+}
+
+// UnwrapAttestation returns the SBOM inside an in-toto statement, either bare
+// or in a DSSE envelope, along with the attestation it came from. Data that
+// isn't an attestation is returned unchanged with a nil attestation.
+func UnwrapAttestation(data []byte) ([]byte, *Attestation, error) {
+	if !sniffJSON(bytes.NewReader(data)).attestation {
+		return data, nil, nil
+	}
+
+	var w attestationWrapper
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&w); err != nil {
+		return data, nil, nil
+	}
+
+	att := &Attestation{}
+
+	if env := w.envelope(); env != nil {
+		payload, err := decodeBase64(env.Payload)
+		if err != nil {
+			// Some producers put the statement in the payload as is.
+			if !json.Valid([]byte(env.Payload)) {
+				return nil, nil, fmt.Errorf("dsse payload is neither base64 nor json: %w", err)
+			}
+			payload = []byte(env.Payload)
+		}
+
+		for i, s := range env.Signatures {
+			sig, err := decodeBase64(s.Sig)
+			if err != nil {
+				return nil, nil, fmt.Errorf("dsse signature %d is not base64: %w", i, err)
+			}
+			att.Signatures = append(att.Signatures, sig)
+		}
+		att.PayloadType = env.PayloadType
+		att.Payload = payload
+
+		w = attestationWrapper{}
+		if err := json.Unmarshal(payload, &w); err != nil {
+			return nil, nil, fmt.Errorf("dsse payload is not an in-toto statement: %w", err)
+		}
+		if !w.isStatement() {
+			return nil, nil, fmt.Errorf("dsse payload type %s is not an in-toto statement", att.PayloadType)
+		}
+	} else if !w.isStatement() {
+		return data, nil, nil
+	}
+
+	if !isSBOMPredicate(w.PredicateType) {
+		return nil, nil, fmt.Errorf("attestation predicate %s is not an SBOM", w.PredicateType)
+	}
+
+	att.PredicateType = w.PredicateType
+	for _, s := range w.Subject {
+		att.Subjects = append(att.Subjects, Subject{Name: s.Name, Digest: s.Digest})
+	}
+
+	predicate := []byte(w.Predicate)
+
+	// Some producers embed the SBOM as a JSON string rather than an object.
+	var embedded string
+	if err := json.Unmarshal(predicate, &embedded); err == nil {
+		predicate = []byte(embedded)
+	}
+
+	return predicate, att, nil
+}
+
+func isSBOMPredicate(predicateType string) bool {
+	for _, p := range sbomPredicateTypes {
+		if strings.HasPrefix(predicateType, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCDX = `{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1, "metadata": {"component": {"bom-ref": "app", "type": "application", "name": "app"}}}`
+
+func testStatement(predicateType string, predicate string) string {
+	return `{
+  "_type": "https://in-toto.io/Statement/v1",
+  "subject": [{"name": "ghcr.io/interlynk-io/app", "digest": {"sha256": "0b4a6f"}}],
+  "predicateType": "` + predicateType + `",
+  "predicate": ` + predicate + `
+}`
+}
+
+func testEnvelope(statement string) string {
+	return `{
+  "payloadType": "application/vnd.in-toto+json",
+  "payload": "` + base64.StdEncoding.EncodeToString([]byte(statement)) + `",
+  "signatures": [{"keyid": "", "sig": "` + base64.StdEncoding.EncodeToString([]byte("sig")) + `"}]
+}`
+}
+
+func TestUnwrapAttestation(t *testing.T) {
+	statement := testStatement("https://cyclonedx.org/bom", testCDX)
+	quoted, err := json.Marshal(testCDX)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		input   string
+		wrapped bool
+		signed  bool
+		wantErr bool
+	}{
+		{"plain sbom", testCDX, false, false, false},
+		{"bare statement", statement, true, false, false},
+		{"dsse envelope", testEnvelope(statement), true, true, false},
+		{"sigstore bundle", `{"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json", "dsseEnvelope": ` + testEnvelope(statement) + `}`, true, true, false},
+		{"predicate as string", testStatement("https://cyclonedx.org/bom/v1.5", string(quoted)), true, false, false},
+		{"not an sbom predicate", testStatement("https://slsa.dev/provenance/v1", `{}`), false, false, true},
+		{"payload not a statement", `{"payloadType": "text/plain", "payload": "aGVsbG8="}`, false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, att, err := UnwrapAttestation([]byte(tt.input))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, testCDX, string(data))
+
+			if !tt.wrapped {
+				assert.Nil(t, att)
+				return
+			}
+			require.NotNil(t, att)
+			assert.Equal(t, []Subject{{Name: "ghcr.io/interlynk-io/app", Digest: map[string]string{"sha256": "0b4a6f"}}}, att.GetSubjects())
+			assert.Equal(t, tt.signed, att.IsSigned())
+		})
+	}
+}
+
+func TestAttestationPAE(t *testing.T) {
+	att := &Attestation{PayloadType: "application/vnd.in-toto+json", Payload: []byte("hello world")}
+
+	assert.Equal(t, "DSSEv1 28 application/vnd.in-toto+json 11 hello world", string(att.PAE()))
+}
+
+func TestNewSBOMDocumentFromAttestation(t *testing.T) {
+	input := testEnvelope(testStatement("https://cyclonedx.org/bom", testCDX))
+
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "cyclonedx", doc.Spec().GetSpecType())
+	assert.Equal(t, "app", doc.PrimaryComp().GetName())
+	require.NotNil(t, doc.Attestation())
+	assert.Equal(t, "https://cyclonedx.org/bom", doc.Attestation().GetPredicateType())
+	assert.Len(t, doc.Attestation().GetSubjects(), 1)
+
+	doc, err = NewSBOMDocument(context.Background(), strings.NewReader(testCDX), Signature{})
+	require.NoError(t, err)
+	assert.Nil(t, doc.Attestation())
+}
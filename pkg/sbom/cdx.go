@@ -24,7 +24,6 @@ import (
 	"io"
 	"log"
 	"math/big"
-	"os"
 	"strings"
 
 	cydx "github.com/CycloneDX/cyclonedx-go"
@@ -55,18 +54,32 @@ type CdxDoc struct {
 	CdxTools         []GetTool
 	rels             []GetRelation
 	logs             []string
+	diagnostics      []Diagnostic
 	Lifecycle        []string
 	CdxSupplier      GetSupplier
 	CdxManufacturer  GetManufacturer
 	compositions     map[string]string
+	depCompositions  map[string]string
 	PrimaryComponent PrimaryComp
 	Dependencies     map[string][]string
-	composition      map[string]string
 	Vuln             []GetVulnerabilities
 	SignatureDetail  GetSignature
-}
-
-func newCDXDoc(ctx context.Context, f io.ReadSeeker, format FileFormat, sig Signature) (Document, error) {
+	attestation      *Attestation
+	schema           *SchemaResult
+
+	// src is the JSON document the top level components are decoded
+	// from, it is only held while parsing.
+	src io.ReadSeeker
+	// dependents is the set of component refs with dependencies, built
+	// on first use.
+	dependents map[string]bool
+	// provides is only decoded from JSON documents
+	provides cdxProvides
+	// stream is set for a JSON document parsed WithComponentStream
+	stream *cdxStream
+}
+
+func newCDXDoc(ctx context.Context, f io.ReadSeeker, format FileFormat, sig Signature, stream bool) (Document, error) {
 	var err error
 
 	_, err = f.Seek(0, io.SeekStart)
@@ -75,12 +88,17 @@ func newCDXDoc(ctx context.Context, f io.ReadSeeker, format FileFormat, sig Sign
 	}
 
 	var bom *cydx.BOM
+	var provides cdxProvides
+	var st *cdxStream
 
 	switch format {
 	case FileFormatJSON:
-		bom = new(cydx.BOM)
-		decoder := cydx.NewBOMDecoder(f, cydx.BOMFileFormatJSON)
-		if err = decoder.Decode(bom); err != nil {
+		if stream {
+			bom, st, err = decodeCDXJSONStream(f)
+		} else {
+			bom, provides, err = decodeCDXJSON(f)
+		}
+		if err != nil {
 			return nil, err
 		}
 	case FileFormatXML:
@@ -98,8 +116,15 @@ func newCDXDoc(ctx context.Context, f io.ReadSeeker, format FileFormat, sig Sign
 		format:          format,
 		ctx:             ctx,
 		SignatureDetail: &sig,
+		provides:        provides,
+		stream:          st,
+	}
+	if format == FileFormatJSON && st == nil {
+		doc.src = f
+	}
+	if err := doc.parse(); err != nil {
+		return nil, err
 	}
-	doc.parse()
 
 	return doc, err
 }
@@ -129,7 +154,11 @@ func (c CdxDoc) Relations() []GetRelation {
 }
 
 func (c CdxDoc) Logs() []string {
-	return c.logs
+	return diagnosticLogs(c.logs, c.diagnostics)
+}
+
+func (c CdxDoc) Diagnostics() []Diagnostic {
+	return c.diagnostics
 }
 
 func (c CdxDoc) Lifecycles() []string {
@@ -149,7 +178,7 @@ func (c CdxDoc) GetRelationships(componentID string) []string {
 }
 
 func (c CdxDoc) GetComposition(componentID string) string {
-	return c.composition[componentID]
+	return c.compositions[componentID]
 }
 
 func (c CdxDoc) Vulnerabilities() []GetVulnerabilities {
@@ -160,7 +189,30 @@ func (c CdxDoc) Signature() GetSignature {
 	return c.SignatureDetail
 }
 
-func (c *CdxDoc) parse() {
+func (c CdxDoc) ComponentTree() []*ComponentNode {
+	return componentTree(c.Comps)
+}
+
+func (c CdxDoc) Attestation() GetAttestation {
+	if c.attestation == nil {
+		return nil
+	}
+	return c.attestation
+}
+
+func (c *CdxDoc) setAttestation(att *Attestation) {
+	c.attestation = att
+}
+
+func (c CdxDoc) Schema() *SchemaResult {
+	return c.schema
+}
+
+func (c *CdxDoc) setSchema(res *SchemaResult) {
+	c.schema = res
+}
+
+func (c *CdxDoc) parse() error {
 	c.parseDoc()
 	c.parseSpec()
 	c.parseAuthors()
@@ -169,18 +221,36 @@ func (c *CdxDoc) parse() {
 	c.parseTool()
 	c.parseCompositions()
 	c.parsePrimaryCompAndRelationships()
+	c.parseRelations()
 	c.parseVulnerabilities()
 	if c.Signature().GetSigValue() == "" && c.Signature().GetPublicKey() == "" {
 		c.addToLogs("extract public key and signature from cylonedx sbom itself")
 		c.parseSignature()
 	}
-	c.parseComps()
+	return c.parseComps()
 }
 
 func (c *CdxDoc) addToLogs(log string) {
+	if c.stream != nil && c.stream.walking {
+		return
+	}
 	c.logs = append(c.logs, log)
 }
 
+func (c *CdxDoc) addDiagnostic(d Diagnostic) {
+	if st := c.stream; st != nil && st.walking {
+		// a later walk finds the same problems again
+		if st.walked {
+			return
+		}
+		if len(c.diagnostics) >= maxStreamDiagnostics {
+			st.dropped++
+			return
+		}
+	}
+	c.diagnostics = append(c.diagnostics, d)
+}
+
 func (c *CdxDoc) parseDoc() {
 	if c.doc == nil {
 		c.addToLogs("cdx doc is not parsable")
@@ -273,13 +343,6 @@ func (c *CdxDoc) parseSignature() {
 				return
 			}
 
-			// write the signature to a file
-			if err := os.WriteFile("extracted_signature.bin", signatureValue, 0o600); err != nil {
-				log.Debug("Error writing signature to file: %s", err)
-				return
-			}
-			c.addToLogs("Signature written to file: extracted_signature.bin")
-
 			// extract the public key modulus and exponent
 			modulus, err := base64.StdEncoding.DecodeString(pubKeyModulus)
 			if err != nil {
@@ -289,7 +352,11 @@ func (c *CdxDoc) parseSignature() {
 
 			exponent := decodeBase64URLEncodingToInt(pubKeyExponent)
 			if exponent == 0 {
-				c.addToLogs("Invalid public key exponent.")
+				c.addDiagnostic(Diagnostic{
+					Severity: SeverityError,
+					Path:     "/declarations/signature/publicKey/e",
+					Message:  "invalid public key exponent",
+				})
 				return
 			}
 
@@ -299,20 +366,13 @@ func (c *CdxDoc) parseSignature() {
 				E: exponent,
 			}
 
-			// write the public key to a PEM file
-			pubKeyPEM := publicKeyToPEM(pubKey)
-			if err := os.WriteFile("extracted_public_key.pem", pubKeyPEM, 0o600); err != nil {
-				log.Debug("Error writing public key to file:", err)
-				return
-			}
-
 			sig := Signature{}
-			sig.PublicKey = string(pubKeyPEM)
+			sig.PublicKey = string(publicKeyToPEM(pubKey))
 			sig.SigValue = string(signatureValue)
 
 			c.SignatureDetail = &sig
 
-			c.addToLogs("Public key written to file: extracted_public_key.pem")
+			c.addToLogs("signature and public key extracted from sbom")
 		}
 	}
 }
@@ -359,65 +419,84 @@ func (c *CdxDoc) requiredFields() bool {
 
 	// This field is only required for JSON not for XML.
 	if c.format == FileFormatJSON && c.doc.BOMFormat == "" {
-		c.addToLogs("cdx doc is missing BOMFormat")
+		c.addDiagnostic(Diagnostic{Severity: SeverityWarning, Path: "/bomFormat", Message: "missing bomFormat"})
 		return false
 	}
 
 	if c.doc.SpecVersion.String() == "" {
-		c.addToLogs("cdx doc is missing specVersion")
+		c.addDiagnostic(Diagnostic{Severity: SeverityWarning, Path: "/specVersion", Message: "missing specVersion"})
 		return false
 	}
 
 	if c.doc.Version < 1 {
-		c.addToLogs("cdx doc is missing doc version")
+		c.addDiagnostic(Diagnostic{Severity: SeverityWarning, Path: "/version", Message: "missing or invalid doc version"})
 		return false
 	}
 
-	if c.doc.Dependencies != nil {
-		deps := lo.CountBy(lo.FromPtr(c.doc.Dependencies), func(d cydx.Dependency) bool {
-			return d.Ref == ""
-		})
-
-		if deps > 0 {
-			c.addToLogs("cdx doc is missing dependencies")
-			return false
+	present := true
+	for i, d := range lo.FromPtr(c.doc.Dependencies) {
+		if d.Ref == "" {
+			c.addDiagnostic(Diagnostic{Severity: SeverityWarning, Path: jsonPointer("dependencies", i, "ref"), Message: "dependency is missing ref"})
+			present = false
 		}
 	}
-	return true
+	if c.stream != nil {
+		for _, i := range c.stream.reflessDeps {
+			c.addDiagnostic(Diagnostic{Severity: SeverityWarning, Path: jsonPointer("dependencies", i, "ref"), Message: "dependency is missing ref"})
+		}
+		if c.stream.refless > 0 {
+			present = false
+		}
+	}
+	return present
 }
 
-func copyC(cdxc *cydx.Component, c *CdxDoc) *Component {
+// copyC converts the component at path in the document.
+func copyC(cdxc *cydx.Component, path string, c *CdxDoc) *Component {
 	if cdxc == nil {
 		return nil
 	}
 
+	invalid := func(field string, value interface{}) {
+		c.addDiagnostic(Diagnostic{
+			Severity:    SeverityError,
+			Path:        path + field,
+			ComponentID: cdxc.BOMRef,
+			Message:     fmt.Sprintf("invalid %s %q", strings.TrimLeft(field, "/"), value),
+		})
+	}
+
 	nc := NewComponent()
 	nc.Version = cdxc.Version
 	nc.Name = cdxc.Name
 	nc.purpose = string(cdxc.Type)
-	nc.isReqFieldsPresent = c.pkgRequiredFields(cdxc)
+	nc.isReqFieldsPresent = c.pkgRequiredFields(cdxc, path)
 	nc.CopyRight = cdxc.Copyright
 	ncpe := cpe.NewCPE(cdxc.CPE)
 	if ncpe.Valid() {
 		nc.Cpes = []cpe.CPE{ncpe}
+	} else if cdxc.CPE != "" {
+		invalid("/cpe", cdxc.CPE)
 	} else {
-		c.addToLogs(fmt.Sprintf("cdx base doc component %s at index %d invalid cpes found", cdxc.Name, -1))
+		c.addToLogs(fmt.Sprintf("cdx base doc component %s has no cpe", cdxc.Name))
 	}
 
 	npurl := purl.NewPURL(cdxc.PackageURL)
 	if npurl.Valid() {
 		nc.Purls = []purl.PURL{npurl}
+	} else if cdxc.PackageURL != "" {
+		invalid("/purl", cdxc.PackageURL)
 	} else {
-		c.addToLogs(fmt.Sprintf("cdx base doc component %s at index %d invalid purl found", cdxc.Name, -1))
+		c.addToLogs(fmt.Sprintf("cdx base doc component %s has no purl", cdxc.Name))
 	}
 
 	if cdxc.SWHID != nil {
-		for _, swhidStr := range *cdxc.SWHID {
+		for i, swhidStr := range *cdxc.SWHID {
 			nswhid := swhid.NewSWHID(swhidStr)
 			if nswhid.Valid() {
 				nc.Swhid = append(nc.Swhid, nswhid)
 			} else {
-				c.addToLogs(fmt.Sprintf("cdx base doc component %s at index %d invalid swhid found", cdxc.Name, -1))
+				invalid(jsonPointer("swhid", i), swhidStr)
 			}
 		}
 	} else {
@@ -429,19 +508,19 @@ func copyC(cdxc *cydx.Component, c *CdxDoc) *Component {
 		if nswid.Valid() {
 			nc.Swid = []swid.SWID{nswid}
 		} else {
-			c.addToLogs(fmt.Sprintf("cdx base doc component %s at index %d invalid swid found", cdxc.Name, -1))
+			invalid("/swid", cdxc.SWID.TagID)
 		}
 	} else {
 		c.addToLogs(fmt.Sprintf("cdx base doc component %s has nil SWID or SWID.Name", cdxc.Name))
 	}
 
 	if cdxc.OmniborID != nil {
-		for _, omniStr := range *cdxc.OmniborID {
+		for i, omniStr := range *cdxc.OmniborID {
 			omniID := omniborid.NewOmni(omniStr)
 			if omniID.Valid() {
 				nc.OmniID = append(nc.OmniID, omniID)
 			} else {
-				c.addToLogs(fmt.Sprintf("cdx base doc component %s at index %d invalid omniborid found", cdxc.Name, -1))
+				invalid(jsonPointer("omniborId", i), omniStr)
 			}
 		}
 	} else {
@@ -485,58 +564,92 @@ func copyC(cdxc *cydx.Component, c *CdxDoc) *Component {
 	nc.declaredLicense = nil
 	nc.concludedLicense = nil
 	nc.hasRelationships = getComponentRelationship(c, nc.ID)
+	if c.stream != nil {
+		nc.dependencyComposition, _ = c.stream.depCompositions.get(nc.ID)
+		if aggregate, ok := c.stream.assemblies.get(nc.ID); ok {
+			nc.composition = map[string]string{nc.ID: aggregate}
+		}
+	} else {
+		nc.composition = c.compositions
+		nc.dependencyComposition = c.depCompositions[nc.ID]
+	}
 
 	return nc
 }
 
 // return true if a component has relationship
 func getComponentRelationship(c *CdxDoc, compID string) bool {
+	if c.stream != nil {
+		return c.stream.dependents.has(compID)
+	}
 	if c.doc.Dependencies == nil {
 		c.addToLogs(fmt.Sprintf("cdx doc component %s has no dependencies", compID))
 		return false
 	}
-	for _, rel := range *c.doc.Dependencies {
-		if rel.Ref == compID {
+	if c.dependents == nil {
+		c.dependents = map[string]bool{}
+		for _, rel := range *c.doc.Dependencies {
 			if len(lo.FromPtr(rel.Dependencies)) > 0 {
-				return true
+				c.dependents[rel.Ref] = true
 			}
 		}
 	}
-	return false
+	return c.dependents[compID]
 }
 
-func (c *CdxDoc) parseComps() {
+func (c *CdxDoc) parseComps() error {
 	c.Comps = []GetComponent{}
+	// a streamed document decodes its components on each walk
+	if c.stream != nil {
+		return nil
+	}
+
 	comps := map[string]*Component{}
 	if c.doc.Metadata != nil && c.doc.Metadata.Component != nil {
-		walkComponents(&[]cydx.Component{*c.doc.Metadata.Component}, c, comps)
+		walkComponent(c.doc.Metadata.Component, "/metadata/component", nil, c, comps)
 	}
 
-	if c.doc.Components != nil {
-		walkComponents(c.doc.Components, c, comps)
-	}
+	walkComponents(c.doc.Components, "/components", nil, c, comps)
 
-	for _, v := range comps {
-		c.Comps = append(c.Comps, v)
+	if c.src != nil {
+		if _, err := c.src.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		err := walkCDXJSONComponents(c.src, func(i int, comp *cydx.Component) {
+			walkComponent(comp, jsonPointer("components", i), nil, c, comps)
+		})
+		c.src = nil
+		if err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func walkComponents(comps *[]cydx.Component, doc *CdxDoc, store map[string]*Component) {
+// walkComponents adds the components in the list at path, and the components
+// nested in them, to the store and to the document in document order. The
+// components in the list are parts of parent, unless it is nil.
+func walkComponents(comps *[]cydx.Component, path string, parent *Component, doc *CdxDoc, store map[string]*Component) {
 	if comps == nil {
 		return
 	}
-	for _, c := range *comps {
-		if c.Components != nil {
-			walkComponents(c.Components, doc, store)
-		}
-		//nolint:gosec
-		if _, ok := store[compID(&c)]; ok {
-			// already present no need to re add it.
-			continue
-		}
-		//nolint:gosec
-		store[compID(&c)] = copyC(&c, doc)
+	for i := range *comps {
+		walkComponent(&(*comps)[i], path+jsonPointer(i), parent, doc, store)
+	}
+}
+
+func walkComponent(c *cydx.Component, path string, parent *Component, doc *CdxDoc, store map[string]*Component) {
+	id := compID(c)
+	nc, ok := store[id]
+	if !ok {
+		nc = copyC(c, path, doc)
+		store[id] = nc
+		doc.Comps = append(doc.Comps, nc)
+		contain(parent, nc)
 	}
+	// A component listed again is already present, but its nested
+	// components may not be.
+	walkComponents(c.Components, path+"/components", nc, doc, store)
 }
 
 func compID(comp *cydx.Component) string {
@@ -554,14 +667,14 @@ func compID(comp *cydx.Component) string {
 	return id.String()
 }
 
-func (c *CdxDoc) pkgRequiredFields(comp *cydx.Component) bool {
+func (c *CdxDoc) pkgRequiredFields(comp *cydx.Component, path string) bool {
 	if string(comp.Type) == "" {
-		c.addToLogs(fmt.Sprintf("cdx doc comp %s missing type field", comp.Name))
+		c.addDiagnostic(Diagnostic{Severity: SeverityWarning, Path: path + "/type", ComponentID: comp.BOMRef, Message: "missing type"})
 		return false
 	}
 
 	if comp.Name == "" {
-		c.addToLogs(fmt.Sprintf("cdx doc comp %s missing type field", comp.BOMRef))
+		c.addDiagnostic(Diagnostic{Severity: SeverityWarning, Path: path + "/name", ComponentID: comp.BOMRef, Message: "missing name"})
 		return false
 	}
 
@@ -751,28 +864,40 @@ func (c *CdxDoc) parsePrimaryCompAndRelationships() {
 	c.PrimaryComponent.Name = c.doc.Metadata.Component.Name
 	var totalDependencies int
 
-	c.rels = []GetRelation{}
-
 	for _, r := range lo.FromPtr(c.doc.Dependencies) {
 		for _, d := range lo.FromPtr(r.Dependencies) {
-			nr := Relation{}
-			nr.From = r.Ref
-			nr.To = d
 			if r.Ref == c.PrimaryComponent.ID {
 				c.PrimaryComponent.HasDependency = true
 				c.PrimaryComponent.AllDependencies = append(c.PrimaryComponent.AllDependencies, d)
 				totalDependencies++
-				c.rels = append(c.rels, nr)
 				c.Dependencies[c.PrimaryComponent.ID] = append(c.Dependencies[c.PrimaryComponent.ID], d)
 			} else {
-				c.rels = append(c.rels, nr)
 				c.Dependencies[r.Ref] = append(c.Dependencies[r.Ref], d)
 			}
 		}
 	}
+	if c.stream != nil {
+		totalDependencies = c.stream.primaryDeps
+		c.PrimaryComponent.HasDependency = totalDependencies > 0
+	}
 	c.PrimaryComponent.Dependecies = totalDependencies
 }
 
+// parseRelations records the dependsOn and provides of every dependency
+// entry. CycloneDX doesn't scope dependencies, they are all runtime.
+func (c *CdxDoc) parseRelations() {
+	c.rels = []GetRelation{}
+
+	for _, r := range lo.FromPtr(c.doc.Dependencies) {
+		for _, d := range lo.FromPtr(r.Dependencies) {
+			c.rels = append(c.rels, Relation{From: r.Ref, To: d, Kind: RelationDependsOn, Type: "dependsOn"})
+		}
+		for _, p := range c.provides[r.Ref] {
+			c.rels = append(c.rels, Relation{From: r.Ref, To: p, Kind: RelationProvides, Type: "provides"})
+		}
+	}
+}
+
 func (c *CdxDoc) assignSupplier(comp *cydx.Component) *Supplier {
 	if comp.Supplier == nil {
 		c.addToLogs(fmt.Sprintf("cdx doc comp %s no supplier found", comp.Name))
@@ -801,12 +926,17 @@ func (c *CdxDoc) assignSupplier(comp *cydx.Component) *Supplier {
 
 func (c *CdxDoc) parseCompositions() {
 	c.compositions = make(map[string]string)
+	c.depCompositions = make(map[string]string)
 
 	if c.doc.Compositions == nil {
 		return
 	}
 
 	for _, composition := range lo.FromPtr(c.doc.Compositions) {
+		for _, dependency := range lo.FromPtr(composition.Dependencies) {
+			c.depCompositions[string(dependency)] = string(composition.Aggregate)
+		}
+
 		assemblies := lo.FromPtr(composition.Assemblies)
 		if len(assemblies) == 0 {
 			continue
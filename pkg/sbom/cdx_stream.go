@@ -0,0 +1,515 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"slices"
+
+	cydx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/interlynk-io/sbomqs/pkg/logger"
+	"github.com/samber/lo"
+)
+
+// ErrNotStreamable is returned by NewSBOMDocument for a document which can't
+// be parsed WithComponentStream: an attestation, or a CycloneDX document
+// with an embedded signature, which are verified over the whole document.
+// Such a document has to be parsed without the option.
+var ErrNotStreamable = errors.New("sbom can't be parsed from a component stream")
+
+// maxStreamDiagnostics caps the diagnostics a streamed document records
+// about its dependencies and components, a broken generator could
+// otherwise produce one for every component.
+const maxStreamDiagnostics = 1000
+
+// WithComponentStream leaves the components of a CycloneDX JSON document in
+// the input, to be decoded a top level component at a time by each walk
+// over them, see ComponentStream. The document keeps its metadata, the ids
+// of its vulnerabilities, a 64-bit hash of every component id and of every
+// ref with dependencies or a composition, and up to maxStreamDiagnostics
+// diagnostics, so memory doesn't grow with the size of the components. The
+// input must stay open while the components are walked.
+//
+// Other documents, and documents validated against their schema, are parsed
+// as usual.
+func WithComponentStream(stream bool) ParseOption {
+	return func(o *parseOptions) {
+		o.stream = stream
+	}
+}
+
+// ComponentStream is a document parsed WithComponentStream. It has no
+// Components, Relations or ComponentTree, and no GetRelationships, which
+// need all of the components at once.
+type ComponentStream interface {
+	// WalkComponents decodes the components of the document, the primary
+	// component and nested components included, and calls visit with each
+	// in document order, once per id.
+	WalkComponents(visit func(GetComponent)) error
+	// ComponentCount returns the number of components the last walk
+	// visited.
+	ComponentCount() int
+}
+
+// Streamed returns doc as a ComponentStream if it was parsed
+// WithComponentStream, or nil.
+func Streamed(doc Document) ComponentStream {
+	if c, ok := doc.(*CdxDoc); ok && c.stream != nil {
+		return c
+	}
+	return nil
+}
+
+// ComponentCount returns the number of components of doc, as counted by the
+// last walk over them for a streamed document.
+func ComponentCount(doc Document) int {
+	if s := Streamed(doc); s != nil {
+		return s.ComponentCount()
+	}
+	return len(doc.Components())
+}
+
+// CycloneDX JSON documents are parsed in two passes over the input so that
+// the full cyclonedx-go BOM is never built. decodeCDXJSON decodes everything
+// but the top level components, and walkCDXJSONComponents then decodes the
+// components one at a time into the document's component records.
+//
+// A document parsed WithComponentStream doesn't keep the component records
+// either. decodeCDXJSONStream keeps no more of the dependencies,
+// compositions and vulnerabilities than the checks need, and the components
+// are decoded again by every walk over them, see CdxDoc.WalkComponents.
+
+// cdxDependency is a dependency entry as CycloneDX 1.5 and later write it,
+// cyclonedx-go doesn't know provides.
+type cdxDependency struct {
+	cydx.Dependency
+	Provides *[]string `json:"provides,omitempty"`
+}
+
+// cdxProvides maps a component ref to the refs of the specifications and
+// standards it provides.
+type cdxProvides map[string][]string
+
+// decodeCDXJSON decodes the CycloneDX JSON document in r, leaving out its
+// top level components. Dependencies and vulnerabilities are decoded without
+// buffering their encoded arrays, every other member through the BOM's own
+// json decoding. The provides of the dependencies are returned separately.
+func decodeCDXJSON(r io.Reader) (*cydx.BOM, cdxProvides, error) {
+	bom := new(cydx.BOM)
+	var provides cdxProvides
+
+	err := walkJSONObject(r, func(key string, dec *json.Decoder) error {
+		var err error
+
+		switch key {
+		case "components":
+			return skipJSONValue(dec)
+		case "dependencies":
+			deps, err := decodeJSONArray[cdxDependency](dec)
+			if err != nil || deps == nil {
+				return err
+			}
+			bom.Dependencies = &[]cydx.Dependency{}
+			for _, d := range *deps {
+				*bom.Dependencies = append(*bom.Dependencies, d.Dependency)
+				if refs := lo.FromPtr(d.Provides); len(refs) > 0 {
+					if provides == nil {
+						provides = cdxProvides{}
+					}
+					provides[d.Ref] = append(provides[d.Ref], refs...)
+				}
+			}
+			return nil
+		case "vulnerabilities":
+			bom.Vulnerabilities, err = decodeJSONArray[cydx.Vulnerability](dec)
+			return err
+		}
+
+		return decodeBOMMember(bom, key, dec)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bom, provides, nil
+}
+
+// decodeBOMMember decodes the member key of a CycloneDX JSON document into
+// bom through the BOM's own json decoding.
+func decodeBOMMember(bom *cydx.BOM, key string, dec *json.Decoder) error {
+	var value json.RawMessage
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	member, err := json.Marshal(map[string]json.RawMessage{key: value})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(member, bom)
+}
+
+// cdxStream is what a streamed CycloneDX document keeps of the members it
+// doesn't decode into its BOM. Refs are kept as hashes.
+type cdxStream struct {
+	src io.ReadSeeker
+
+	// refs with dependencies, and the number of dependencies of the
+	// primary component
+	dependents  refSet
+	primaryDeps int
+	// the number of dependency entries without a ref, and the indexes of
+	// the first of them
+	refless     int
+	reflessDeps []int
+
+	// the aggregates compositions declare for the dependencies and the
+	// assemblies of refs
+	depCompositions refAggregates
+	assemblies      refAggregates
+
+	// walking is set during a walk over the components, walked once the
+	// first has ended; dropped counts the diagnostics past the cap
+	walking bool
+	walked  bool
+	dropped int
+	count   int
+}
+
+// decodeCDXJSONStream decodes the CycloneDX JSON document in r like
+// decodeCDXJSON, but counts its dependencies and hashes the refs of its
+// dependencies and compositions instead of decoding them, and only keeps
+// the ids of its vulnerabilities. A document with a top level signature
+// returns ErrNotStreamable.
+func decodeCDXJSONStream(r io.ReadSeeker) (*cydx.BOM, *cdxStream, error) {
+	bom := new(cydx.BOM)
+	st := &cdxStream{src: r}
+
+	// dependencies are counted for the primary component, which has to be
+	// known first
+	depsLater := false
+
+	err := walkJSONObject(r, func(key string, dec *json.Decoder) error {
+		switch key {
+		case "components":
+			return skipJSONValue(dec)
+		case "signature":
+			return ErrNotStreamable
+		case "dependencies":
+			if bom.Metadata == nil {
+				depsLater = true
+				return skipJSONValue(dec)
+			}
+			return st.walkDependencies(dec, primaryRef(bom))
+		case "compositions":
+			return st.walkCompositions(dec)
+		case "vulnerabilities":
+			return walkJSONArray(dec, func(dec *json.Decoder) error {
+				var v struct {
+					ID string `json:"id"`
+				}
+				if err := dec.Decode(&v); err != nil {
+					return err
+				}
+				if v.ID != "" {
+					if bom.Vulnerabilities == nil {
+						bom.Vulnerabilities = &[]cydx.Vulnerability{}
+					}
+					*bom.Vulnerabilities = append(*bom.Vulnerabilities, cydx.Vulnerability{ID: v.ID})
+				}
+				return nil
+			})
+		}
+		return decodeBOMMember(bom, key, dec)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if depsLater {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return nil, nil, err
+		}
+		err := walkJSONObject(r, func(key string, dec *json.Decoder) error {
+			if key != "dependencies" {
+				return skipJSONValue(dec)
+			}
+			if err := st.walkDependencies(dec, primaryRef(bom)); err != nil {
+				return err
+			}
+			return errStopWalk
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	st.dependents.sort()
+	st.depCompositions.sort()
+	st.assemblies.sort()
+
+	return bom, st, nil
+}
+
+// primaryRef returns the ref of the primary component of bom, nil if it has
+// none.
+func primaryRef(bom *cydx.BOM) *string {
+	if bom.Metadata == nil || bom.Metadata.Component == nil {
+		return nil
+	}
+	return &bom.Metadata.Component.BOMRef
+}
+
+// walkDependencies records the dependency entries in the array the decoder
+// is positioned at an entry at a time, counting their dependsOn without
+// keeping them.
+func (st *cdxStream) walkDependencies(dec *json.Decoder, primary *string) error {
+	i := 0
+	return walkJSONArray(dec, func(dec *json.Decoder) error {
+		var ref string
+		var deps int
+		err := walkJSONElement(dec, func(key string, dec *json.Decoder) error {
+			switch key {
+			case "ref":
+				return dec.Decode(&ref)
+			case "dependsOn":
+				return walkJSONArray(dec, func(dec *json.Decoder) error {
+					deps++
+					return skipJSONValue(dec)
+				})
+			}
+			return skipJSONValue(dec)
+		})
+		if err != nil {
+			return err
+		}
+
+		if ref == "" {
+			if st.refless < maxStreamDiagnostics {
+				st.reflessDeps = append(st.reflessDeps, i)
+			}
+			st.refless++
+		}
+		if deps > 0 {
+			st.dependents = append(st.dependents, hashRef(ref))
+		}
+		if primary != nil && ref == *primary {
+			st.primaryDeps += deps
+		}
+		i++
+		return nil
+	})
+}
+
+// walkCompositions records the aggregate each composition in the array the
+// decoder is positioned at declares for its dependencies and assemblies.
+func (st *cdxStream) walkCompositions(dec *json.Decoder) error {
+	return walkJSONArray(dec, func(dec *json.Decoder) error {
+		var aggregate string
+		var deps, assemblies []uint64
+
+		hashRefs := func(refs *[]uint64) func(*json.Decoder) error {
+			return func(dec *json.Decoder) error {
+				var ref string
+				if err := dec.Decode(&ref); err != nil {
+					return err
+				}
+				*refs = append(*refs, hashRef(ref))
+				return nil
+			}
+		}
+
+		err := walkJSONElement(dec, func(key string, dec *json.Decoder) error {
+			switch key {
+			case "aggregate":
+				return dec.Decode(&aggregate)
+			case "dependencies":
+				return walkJSONArray(dec, hashRefs(&deps))
+			case "assemblies":
+				return walkJSONArray(dec, hashRefs(&assemblies))
+			}
+			return skipJSONValue(dec)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, ref := range deps {
+			st.depCompositions = append(st.depCompositions, refAggregate{ref: ref, aggregate: aggregate})
+		}
+		for _, ref := range assemblies {
+			st.assemblies = append(st.assemblies, refAggregate{ref: ref, aggregate: aggregate})
+		}
+		return nil
+	})
+}
+
+// WalkComponents implements ComponentStream. Only the first walk records
+// diagnostics, and no walk records logs, which would otherwise grow with
+// the components.
+func (c *CdxDoc) WalkComponents(visit func(GetComponent)) error {
+	st := c.stream
+	if st == nil {
+		for _, comp := range c.Comps {
+			visit(comp)
+		}
+		return nil
+	}
+
+	st.walking = true
+	defer func() {
+		st.walking = false
+		st.walked = true
+	}()
+
+	// like walkComponent, a component listed again is visited once, but
+	// its nested components may be new
+	seen := map[uint64]bool{}
+	count := 0
+	var walk func(comp *cydx.Component, path string)
+	walk = func(comp *cydx.Component, path string) {
+		if id := hashRef(compID(comp)); !seen[id] {
+			seen[id] = true
+			visit(copyC(comp, path, c))
+			count++
+		}
+		for i := range lo.FromPtr(comp.Components) {
+			walk(&(*comp.Components)[i], path+"/components"+jsonPointer(i))
+		}
+	}
+
+	if c.doc.Metadata != nil && c.doc.Metadata.Component != nil {
+		walk(c.doc.Metadata.Component, "/metadata/component")
+	}
+
+	if _, err := st.src.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	err := walkCDXJSONComponents(st.src, func(i int, comp *cydx.Component) {
+		walk(comp, jsonPointer("components", i))
+	})
+	if err != nil {
+		return jsonErrorPosition(st.src, err)
+	}
+	st.count = count
+
+	if !st.walked {
+		if st.dropped > 0 {
+			c.diagnostics = append(c.diagnostics, Diagnostic{
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("%d more diagnostics about components not recorded", st.dropped),
+			})
+		}
+		if err := locateDiagnostics(st.src, c.diagnostics); err != nil {
+			logger.FromContext(c.ctx).Debugf("failed to locate diagnostics: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// ComponentCount implements ComponentStream.
+func (c *CdxDoc) ComponentCount() int {
+	if c.stream == nil {
+		return len(c.Comps)
+	}
+	return c.stream.count
+}
+
+// hashRef hashes a ref or component id for a streamed document, which
+// doesn't keep them. Collisions are unlikely enough at 64 bits for the
+// counts they could skew.
+func hashRef(ref string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(ref))
+	return h.Sum64()
+}
+
+// refSet is a set of hashed refs, sorted once it is built.
+type refSet []uint64
+
+func (s *refSet) sort() {
+	slices.Sort(*s)
+	*s = slices.Compact(*s)
+}
+
+func (s refSet) has(ref string) bool {
+	_, ok := slices.BinarySearch(s, hashRef(ref))
+	return ok
+}
+
+type refAggregate struct {
+	ref       uint64
+	aggregate string
+}
+
+// refAggregates maps hashed refs to aggregates, sorted once it is built.
+// As for a map, the aggregate recorded last for a ref wins.
+type refAggregates []refAggregate
+
+func (a *refAggregates) sort() {
+	slices.SortStableFunc(*a, func(x, y refAggregate) int {
+		return cmp.Compare(x.ref, y.ref)
+	})
+
+	kept := (*a)[:0]
+	for i, e := range *a {
+		if i+1 < len(*a) && (*a)[i+1].ref == e.ref {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	*a = kept
+}
+
+func (a refAggregates) get(ref string) (string, bool) {
+	i, ok := slices.BinarySearchFunc(a, hashRef(ref), func(e refAggregate, ref uint64) int {
+		return cmp.Compare(e.ref, ref)
+	})
+	if !ok {
+		return "", false
+	}
+	return a[i].aggregate, true
+}
+
+// walkCDXJSONComponents calls visit with each top level component of the
+// CycloneDX JSON document in r and its index, decoding a single component at
+// a time.
+func walkCDXJSONComponents(r io.Reader, visit func(int, *cydx.Component)) error {
+	return walkJSONObject(r, func(key string, dec *json.Decoder) error {
+		if key != "components" {
+			return skipJSONValue(dec)
+		}
+
+		i := 0
+		err := walkJSONArray(dec, func(dec *json.Decoder) error {
+			var comp cydx.Component
+			if err := dec.Decode(&comp); err != nil {
+				return err
+			}
+			visit(i, &comp)
+			i++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return errStopWalk
+	})
+}
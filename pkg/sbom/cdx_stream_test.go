@@ -0,0 +1,333 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	cydx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCDXJSONStreamingMatchesDecoder(t *testing.T) {
+	samples := []string{
+		"../../samples/sbomqs-cdx-cgomod.json",
+		"../../samples/sbomqs-dummy-bomlinks-data.cdx.json",
+		"../../samples/sbomqs-dummy-licenses.cdx-1.6.json",
+		"../../samples/sbomqs-sbomsh-with-vuln.cdx.json",
+		"../../samples/stree-cdxgen.cdx.json",
+	}
+
+	for _, sample := range samples {
+		t.Run(sample, func(t *testing.T) {
+			data, err := os.ReadFile(sample)
+			require.NoError(t, err)
+
+			want := new(cydx.BOM)
+			require.NoError(t, cydx.NewBOMDecoder(bytes.NewReader(data), cydx.BOMFileFormatJSON).Decode(want))
+
+			got, _, err := decodeCDXJSON(bytes.NewReader(data))
+			require.NoError(t, err)
+			assert.Nil(t, got.Components)
+			assert.Equal(t, want.SpecVersion, got.SpecVersion)
+			assert.Equal(t, want.SerialNumber, got.SerialNumber)
+			assert.Equal(t, want.Metadata, got.Metadata)
+			assert.Equal(t, want.Dependencies, got.Dependencies)
+			assert.Equal(t, want.Vulnerabilities, got.Vulnerabilities)
+			assert.Equal(t, want.Compositions, got.Compositions)
+
+			var comps []cydx.Component
+			err = walkCDXJSONComponents(bytes.NewReader(data), func(_ int, c *cydx.Component) {
+				comps = append(comps, *c)
+			})
+			require.NoError(t, err)
+			assert.Equal(t, len(*want.Components), len(comps))
+			assert.Equal(t, *want.Components, comps)
+		})
+	}
+}
+
+func TestCDXJSONDocumentComponents(t *testing.T) {
+	f, err := os.Open("../../samples/stree-cdxgen.cdx.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err := NewSBOMDocument(context.Background(), f, Signature{})
+	require.NoError(t, err)
+
+	cdx, ok := doc.(*CdxDoc)
+	require.True(t, ok)
+	assert.Nil(t, cdx.src)
+
+	comps := map[string]bool{}
+	var walk func(cs *[]cydx.Component)
+	walk = func(cs *[]cydx.Component) {
+		for _, c := range *cs {
+			if c.Components != nil {
+				walk(c.Components)
+			}
+			comps[compID(&c)] = true
+		}
+	}
+	want := new(cydx.BOM)
+	_, err = f.Seek(0, 0)
+	require.NoError(t, err)
+	require.NoError(t, cydx.NewBOMDecoder(f, cydx.BOMFileFormatJSON).Decode(want))
+	if want.Metadata != nil && want.Metadata.Component != nil {
+		walk(&[]cydx.Component{*want.Metadata.Component})
+	}
+	walk(want.Components)
+
+	assert.Len(t, doc.Components(), len(comps))
+	for _, c := range doc.Components() {
+		assert.True(t, comps[c.GetID()], c.GetID())
+	}
+}
+
+func TestCDXJSONMemberOrder(t *testing.T) {
+	// components ahead of the keys that identify the document
+	doc := `{
+		"components": [
+			{"type": "library", "bom-ref": "a", "name": "a", "version": "1"},
+			{"type": "library", "bom-ref": "b", "name": "b", "version": "1"}
+		],
+		"dependencies": [{"ref": "a", "dependsOn": ["b"]}],
+		"metadata": {"component": {"type": "application", "bom-ref": "app", "name": "app", "version": "1"}},
+		"specVersion": "1.5",
+		"bomFormat": "CycloneDX"
+	}`
+
+	d, err := NewSBOMDocument(context.Background(), strings.NewReader(doc), Signature{})
+	require.NoError(t, err)
+	assert.Equal(t, "cyclonedx", d.Spec().GetSpecType())
+	assert.Equal(t, "1.5", d.Spec().GetVersion())
+	assert.Len(t, d.Components(), 3)
+
+	for _, c := range d.Components() {
+		assert.Equal(t, c.GetID() == "a", c.HasRelationShips(), c.GetID())
+	}
+}
+
+func TestCDXJSONMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"truncated components", `{"bomFormat": "CycloneDX", "specVersion": "1.5", "components": [{"name": "a"},`},
+		{"truncated dependencies", `{"bomFormat": "CycloneDX", "specVersion": "1.5", "dependencies": [{"ref": "a"`},
+		{"wrong component type", `{"bomFormat": "CycloneDX", "specVersion": "1.5", "components": [{"name": 1}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSBOMDocument(context.Background(), strings.NewReader(tt.doc), Signature{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSniffJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want jsonHead
+	}{
+		{
+			name: "cyclonedx",
+			doc:  `{"bomFormat": "CycloneDX", "components": [`,
+			want: jsonHead{object: true, bomFormat: "CycloneDX"},
+		},
+		{
+			name: "spdx",
+			doc:  `{"packages": [{"SPDXID": "SPDXRef-a"}], "SPDXID": "SPDXRef-DOCUMENT", "spdxVersion": "SPDX-2.3", "files": [`,
+			want: jsonHead{object: true, spdxID: "SPDXRef-DOCUMENT", spdxVersion: "SPDX-2.3"},
+		},
+		{
+			name: "spdx3",
+			doc:  `{"@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld", "@graph": [{"type": "CreationInfo", "specVersion": "3.0.1"}, "x"]}`,
+			want: jsonHead{object: true, spdx3: true, spdx3Version: "3.0.1"},
+		},
+		{
+			name: "dsse envelope",
+			doc:  `{"payloadType": "application/vnd.in-toto+json", "payload": "e30="}`,
+			want: jsonHead{object: true, attestation: true},
+		},
+		{
+			name: "not json",
+			doc:  "SPDXVersion: SPDX-2.3\n",
+			want: jsonHead{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sniffJSON(strings.NewReader(tt.doc)))
+		})
+	}
+}
+
+// streamedComponents walks the components of a streamed document.
+func streamedComponents(t *testing.T, doc Document) []GetComponent {
+	t.Helper()
+
+	s := Streamed(doc)
+	require.NotNil(t, s)
+
+	var comps []GetComponent
+	require.NoError(t, s.WalkComponents(func(c GetComponent) {
+		comps = append(comps, c)
+	}))
+	assert.Equal(t, len(comps), ComponentCount(doc))
+	return comps
+}
+
+func TestCDXJSONComponentStream(t *testing.T) {
+	samples := []string{
+		"../../samples/sbomqs-cdx-cgomod.json",
+		"../../samples/sbomqs-dummy-bomlinks-data.cdx.json",
+		"../../samples/sbomqs-dummy-licenses.cdx-1.6.json",
+		"../../samples/sbomqs-sbomsh-with-vuln.cdx.json",
+		"../../samples/stree-cdxgen.cdx.json",
+	}
+
+	for _, sample := range samples {
+		t.Run(sample, func(t *testing.T) {
+			data, err := os.ReadFile(sample)
+			require.NoError(t, err)
+
+			want, err := NewSBOMDocument(context.Background(), bytes.NewReader(data), Signature{})
+			require.NoError(t, err)
+			got, err := NewSBOMDocument(context.Background(), bytes.NewReader(data), Signature{}, WithComponentStream(true))
+			require.NoError(t, err)
+
+			assert.Nil(t, Streamed(want))
+			assert.Empty(t, got.Components())
+			assert.Equal(t, want.Spec(), got.Spec())
+			assert.Equal(t, want.PrimaryComp().GetTotalNoOfDependencies(), got.PrimaryComp().GetTotalNoOfDependencies())
+			assert.Equal(t, want.Vulnerabilities(), got.Vulnerabilities())
+
+			comps := streamedComponents(t, got)
+			require.Len(t, comps, len(want.Components()))
+			for i, c := range want.Components() {
+				assert.Equal(t, c.GetID(), comps[i].GetID())
+				assert.Equal(t, c.GetName(), comps[i].GetName())
+				assert.Equal(t, c.HasRelationShips(), comps[i].HasRelationShips(), c.GetID())
+				assert.Equal(t, c.RequiredFields(), comps[i].RequiredFields(), c.GetID())
+			}
+
+			// the input is decoded again by a second walk
+			assert.Len(t, streamedComponents(t, got), len(comps))
+		})
+	}
+}
+
+func TestCDXJSONComponentStreamDependencies(t *testing.T) {
+	// dependencies ahead of the primary component they are counted for
+	doc := `{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.5",
+		"version": 1,
+		"dependencies": [
+			{"dependsOn": ["a"]},
+			{"ref": "app", "dependsOn": ["a", "b"]},
+			{"dependsOn": ["b"], "ref": "a"}
+		],
+		"components": [
+			{"type": "library", "bom-ref": "a", "name": "a", "purl": "not a purl"},
+			{"type": "library", "bom-ref": "b", "name": "b", "components": [
+				{"type": "library", "bom-ref": "c", "name": "c"},
+				{"type": "library", "bom-ref": "a", "name": "a"}
+			]}
+		],
+		"compositions": [
+			{"dependencies": ["app", "a"], "aggregate": "complete"},
+			{"aggregate": "incomplete", "dependencies": ["a", "c"], "assemblies": ["b"]}
+		],
+		"metadata": {"component": {"type": "application", "bom-ref": "app", "name": "app"}}
+	}`
+
+	want, err := NewSBOMDocument(context.Background(), strings.NewReader(doc), Signature{})
+	require.NoError(t, err)
+	got, err := NewSBOMDocument(context.Background(), strings.NewReader(doc), Signature{}, WithComponentStream(true))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, got.PrimaryComp().GetTotalNoOfDependencies())
+	assert.False(t, got.Spec().RequiredFields())
+
+	comps := streamedComponents(t, got)
+	require.Len(t, comps, len(want.Components()))
+	for i, c := range want.Components() {
+		assert.Equal(t, c.GetID(), comps[i].GetID())
+		assert.Equal(t, c.HasRelationShips(), comps[i].HasRelationShips(), c.GetID())
+		assert.Equal(t, c.GetDependencyComposition(), comps[i].GetDependencyComposition(), c.GetID())
+		assert.Equal(t, c.GetComposition(c.GetID()), comps[i].GetComposition(c.GetID()), c.GetID())
+	}
+	assert.Equal(t, want.Diagnostics(), got.Diagnostics())
+}
+
+func TestCDXJSONComponentStreamDiagnosticsCapped(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(`{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1, "components": [`)
+	for i := 0; i < maxStreamDiagnostics+10; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"type": "library", "bom-ref": "c%d", "name": "c", "purl": "bad"}`, i)
+	}
+	b.WriteString("]}")
+
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(b.String()), Signature{}, WithComponentStream(true))
+	require.NoError(t, err)
+	comps := streamedComponents(t, doc)
+	assert.Len(t, comps, maxStreamDiagnostics+10)
+
+	diags := doc.Diagnostics()
+	require.Len(t, diags, maxStreamDiagnostics+1)
+	// without a path, it sorts ahead of the located ones
+	assert.Equal(t, "10 more diagnostics about components not recorded", diags[0].Message)
+
+	// a second walk doesn't record them again
+	streamedComponents(t, doc)
+	assert.Len(t, doc.Diagnostics(), maxStreamDiagnostics+1)
+}
+
+func TestCDXJSONComponentStreamNotStreamable(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"embedded signature", `{"bomFormat": "CycloneDX", "specVersion": "1.5", "signature": {"algorithm": "RS256"}}`},
+		{"attestation", `{"payloadType": "application/vnd.in-toto+json", "payload": "e30=", "signatures": []}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSBOMDocument(context.Background(), strings.NewReader(tt.doc), Signature{}, WithComponentStream(true))
+			assert.ErrorIs(t, err, ErrNotStreamable)
+		})
+	}
+
+	// a document validated against its schema is read whole
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(`{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1}`), Signature{},
+		WithComponentStream(true), WithSchemaValidation(true))
+	require.NoError(t, err)
+	assert.Nil(t, Streamed(doc))
+}
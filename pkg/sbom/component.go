@@ -46,6 +46,8 @@ type GetComponent interface {
 	GetDownloadLocationURL() string
 	SourceCodeHash() string
 	IsPrimaryComponent() bool
+	// HasRelationShips reports whether the component has a runtime
+	// dependency, dev, build and test dependencies aren't counted.
 	HasRelationShips() bool
 	RelationShipState() string
 	GetSpdxID() string
@@ -54,8 +56,24 @@ type GetComponent interface {
 	GetPackageLicenseDeclared() string
 	GetPackageLicenseConcluded() string
 	ExternalReferences() []GetExternalReference
+	// GetComposition returns the aggregate a CycloneDX composition declares
+	// for the assemblies of the component with the given id.
 	GetComposition(string) string
+	// GetDependencyComposition returns the aggregate a CycloneDX composition
+	// declares for the dependencies of the component, such as "complete",
+	// or "" if none does.
+	GetDependencyComposition() string
 	GetPrimaryCompInfo() GetPrimaryComp
+
+	// GetParentID returns the id of the component this one is a part of, a
+	// nested CycloneDX component or the target of an SPDX CONTAINS
+	// relationship, or "" for a top level component.
+	GetParentID() string
+	// GetChildIDs returns the ids of the components this one contains.
+	GetChildIDs() []string
+	// GetDepth returns the number of ancestors of the component, zero for a
+	// top level component.
+	GetDepth() int
 }
 
 type Component struct {
@@ -90,12 +108,40 @@ type Component struct {
 	PackageLicenseDeclared  string
 	ExternalRefs            []GetExternalReference
 	composition             map[string]string
+	dependencyComposition   string
+
+	// assembly hierarchy, see contain
+	parent   *Component
+	children []*Component
 }
 
 func NewComponent() *Component {
 	return &Component{}
 }
 
+func (c Component) GetParentID() string {
+	if c.parent == nil {
+		return ""
+	}
+	return c.parent.ID
+}
+
+func (c Component) GetChildIDs() []string {
+	ids := make([]string, 0, len(c.children))
+	for _, child := range c.children {
+		ids = append(ids, child.ID)
+	}
+	return ids
+}
+
+func (c Component) GetDepth() int {
+	depth := 0
+	for p := c.parent; p != nil; p = p.parent {
+		depth++
+	}
+	return depth
+}
+
 func (c Component) GetPrimaryCompInfo() GetPrimaryComp {
 	return c.PrimaryCompt
 }
@@ -219,3 +265,7 @@ func (c Component) ExternalReferences() []GetExternalReference {
 func (c Component) GetComposition(componentID string) string {
 	return c.composition[componentID]
 }
+
+func (c Component) GetDependencyComposition() string {
+	return c.dependencyComposition
+}
@@ -0,0 +1,297 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Severity string
+
+const (
+	// SeverityError is a value that is present but malformed.
+	SeverityError Severity = "error"
+	// SeverityWarning is a field the spec requires that is missing.
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a problem found in an SBOM while parsing it, located as
+// precisely as the file format allows.
+type Diagnostic struct {
+	Severity Severity
+	// Path is a JSON pointer to the element, such as /components/3/purl.
+	// For XML, YAML and tag-value documents it is the path of the same
+	// element in the JSON encoding.
+	Path string
+	// Line and Column locate the element in a JSON document, both one
+	// based. They are zero for other formats.
+	Line   int
+	Column int
+	// ComponentID is the id of the component the element belongs to.
+	ComponentID string
+	Message     string
+}
+
+// String formats d as "line:column: severity: path: message (component id)",
+// leaving out the parts that aren't known.
+func (d Diagnostic) String() string {
+	var b strings.Builder
+	if d.Line > 0 {
+		fmt.Fprintf(&b, "%d:%d: ", d.Line, d.Column)
+	}
+	b.WriteString(string(d.Severity))
+	b.WriteString(": ")
+	if d.Path != "" {
+		b.WriteString(d.Path)
+		b.WriteString(": ")
+	}
+	b.WriteString(d.Message)
+	if d.ComponentID != "" {
+		fmt.Fprintf(&b, " (%s)", d.ComponentID)
+	}
+	return b.String()
+}
+
+// WriteDiagnostics writes the parse diagnostics of the SBOM at path to w, one
+// per line and prefixed with the path, in the style of compiler errors.
+func WriteDiagnostics(w io.Writer, path string, diags []Diagnostic) {
+	for _, d := range diags {
+		if d.Line > 0 {
+			fmt.Fprintf(w, "%s:%s\n", path, d)
+		} else {
+			fmt.Fprintf(w, "%s: %s\n", path, d)
+		}
+	}
+}
+
+// diagnosticLogs returns the parse logs followed by the formatted
+// diagnostics.
+func diagnosticLogs(logs []string, diags []Diagnostic) []string {
+	all := make([]string, 0, len(logs)+len(diags))
+	all = append(all, logs...)
+	for _, d := range diags {
+		all = append(all, d.String())
+	}
+	return all
+}
+
+// jsonPointer joins the tokens into a JSON pointer, escaping them as RFC 6901
+// requires.
+func jsonPointer(tokens ...interface{}) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		switch v := t.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(v))
+		default:
+			s := fmt.Sprint(v)
+			s = strings.ReplaceAll(s, "~", "~0")
+			s = strings.ReplaceAll(s, "/", "~1")
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+// locateDiagnostics sets the line and column of every diagnostic in the JSON
+// document in r, and sorts them by position. A diagnostic about a missing field is located at the
+// nearest element on its path that exists.
+func locateDiagnostics(r io.ReadSeeker, diags []Diagnostic) error {
+	var ptrs []string
+	for _, d := range diags {
+		for p := d.Path; p != ""; p = p[:strings.LastIndexByte(p, '/')] {
+			ptrs = append(ptrs, p)
+		}
+	}
+	if len(ptrs) == 0 {
+		return nil
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	offsets, err := jsonOffsets(r, ptrs)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	positions, err := linesAndColumns(r, offsets, true)
+	if err != nil {
+		return err
+	}
+
+	for i := range diags {
+		for p := diags[i].Path; p != ""; p = p[:strings.LastIndexByte(p, '/')] {
+			if pos, ok := positions[p]; ok {
+				diags[i].Line, diags[i].Column = pos[0], pos[1]
+				break
+			}
+		}
+	}
+
+	// Report in document order, not in the order the parser got to them.
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Line != diags[j].Line {
+			return diags[i].Line < diags[j].Line
+		}
+		return diags[i].Column < diags[j].Column
+	})
+	return nil
+}
+
+// jsonOffsets returns the byte offsets in r of the values at the JSON
+// pointers in ptrs, for those that exist. Only the containers on the way to
+// a wanted value are walked token by token, everything else is skipped a
+// value at a time. An offset can point at separators ahead of the value.
+func jsonOffsets(r io.Reader, ptrs []string) (map[string]int64, error) {
+	targets := map[string]bool{}
+	ancestors := map[string]bool{}
+	for _, p := range ptrs {
+		targets[p] = true
+		for i := strings.LastIndexByte(p, '/'); i >= 0; i = strings.LastIndexByte(p[:i], '/') {
+			ancestors[p[:i]] = true
+		}
+	}
+
+	dec := json.NewDecoder(r)
+	offsets := map[string]int64{}
+
+	var walk func(ptr string) error
+	walk = func(ptr string) error {
+		if targets[ptr] {
+			offsets[ptr] = dec.InputOffset()
+		}
+		if !ancestors[ptr] {
+			var v json.RawMessage
+			return dec.Decode(&v)
+		}
+
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if err := walk(ptr + jsonPointer(key)); err != nil {
+					return err
+				}
+			}
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(ptr + jsonPointer(i)); err != nil {
+					return err
+				}
+			}
+		default:
+			return nil
+		}
+
+		_, err = dec.Token()
+		return err
+	}
+
+	return offsets, walk("")
+}
+
+// linesAndColumns converts byte offsets in r to one based lines and columns.
+// With skip set, each offset is first moved past any separators to the start
+// of the value.
+func linesAndColumns(r io.Reader, offsets map[string]int64, skip bool) (map[string][2]int, error) {
+	type target struct {
+		ptr    string
+		offset int64
+	}
+	targets := make([]target, 0, len(offsets))
+	for p, o := range offsets {
+		targets = append(targets, target{p, o})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].offset < targets[j].offset })
+
+	positions := map[string][2]int{}
+	br := bufio.NewReader(r)
+	line, col := 1, 1
+	var pos int64
+
+	for len(targets) > 0 {
+		b, err := br.ReadByte()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for len(targets) > 0 && pos >= targets[0].offset && !(skip && isJSONSeparator(b)) {
+			positions[targets[0].ptr] = [2]int{line, col}
+			targets = targets[1:]
+		}
+
+		pos++
+		if b == '\n' {
+			line, col = line+1, 1
+		} else {
+			col++
+		}
+	}
+
+	return positions, nil
+}
+
+func isJSONSeparator(b byte) bool {
+	switch b {
+	case ' ', '\t', '\r', '\n', ',', ':':
+		return true
+	}
+	return false
+}
+
+// jsonErrorPosition adds the line and column of a JSON syntax error to err.
+// Other errors are returned unchanged.
+func jsonErrorPosition(r io.ReadSeeker, err error) error {
+	var serr *json.SyntaxError
+	if !errors.As(err, &serr) || serr.Offset <= 0 {
+		return err
+	}
+
+	if _, seekErr := r.Seek(0, io.SeekStart); seekErr != nil {
+		return err
+	}
+	// the offset is just past the offending byte
+	positions, perr := linesAndColumns(r, map[string]int64{"": serr.Offset - 1}, false)
+	if perr != nil {
+		return err
+	}
+	p, ok := positions[""]
+	if !ok {
+		return err
+	}
+	return fmt.Errorf("line %d, column %d: %w", p[0], p[1], err)
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDiagnosticsCDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "purl": "pkg:npm/a@1"},
+    {
+      "type": "library",
+      "bom-ref": "b",
+      "name": "b",
+      "purl": "not a purl",
+      "components": [{"bom-ref": "c", "name": "c"}]
+    }
+  ],
+  "dependencies": [{"dependsOn": ["a"]}]
+}`
+
+func TestDiagnosticString(t *testing.T) {
+	tests := []struct {
+		name string
+		diag Diagnostic
+		want string
+	}{
+		{
+			name: "located",
+			diag: Diagnostic{Severity: SeverityError, Path: "/components/0/purl", Line: 3, Column: 7, ComponentID: "a", Message: "invalid purl"},
+			want: "3:7: error: /components/0/purl: invalid purl (a)",
+		},
+		{
+			name: "unlocated",
+			diag: Diagnostic{Severity: SeverityWarning, Path: "/version", Message: "missing version"},
+			want: "warning: /version: missing version",
+		},
+		{
+			name: "no path",
+			diag: Diagnostic{Severity: SeverityWarning, Message: "empty document"},
+			want: "warning: empty document",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.diag.String())
+		})
+	}
+}
+
+func TestWriteDiagnostics(t *testing.T) {
+	var b strings.Builder
+	WriteDiagnostics(&b, "app.cdx.json", []Diagnostic{
+		{Severity: SeverityError, Path: "/components/0/purl", Line: 3, Column: 7, Message: "invalid purl"},
+		{Severity: SeverityWarning, Path: "/version", Message: "missing version"},
+	})
+
+	assert.Equal(t, "app.cdx.json:3:7: error: /components/0/purl: invalid purl\n"+
+		"app.cdx.json: warning: /version: missing version\n", b.String())
+}
+
+func TestJSONPointer(t *testing.T) {
+	assert.Equal(t, "/components/3/purl", jsonPointer("components", 3, "purl"))
+	assert.Equal(t, "/a~1b/c~0d", jsonPointer("a/b", "c~d"))
+}
+
+func TestCDXDiagnostics(t *testing.T) {
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(testDiagnosticsCDX), Signature{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Diagnostic{
+		{Severity: SeverityError, Path: "/components/1/purl", Line: 11, Column: 15, ComponentID: "b", Message: `invalid purl "not a purl"`},
+		{Severity: SeverityWarning, Path: "/components/1/components/0/type", Line: 12, Column: 22, ComponentID: "c", Message: "missing type"},
+		{Severity: SeverityWarning, Path: "/dependencies/0/ref", Line: 15, Column: 20, Message: "dependency is missing ref"},
+	}, doc.Diagnostics())
+}
+
+func TestSPDXDiagnostics(t *testing.T) {
+	input := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "test",
+  "documentNamespace": "https://example.com/test",
+  "creationInfo": {"created": "2024-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-a",
+      "name": "a",
+      "downloadLocation": "NOASSERTION",
+      "externalRefs": [
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "not a purl"}
+      ]
+    }
+  ]
+}`
+
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	require.NoError(t, err)
+
+	var found *Diagnostic
+	for _, d := range doc.Diagnostics() {
+		if d.Path == "/packages/0/externalRefs/0/referenceLocator" {
+			found = &d
+			break
+		}
+	}
+	require.NotNil(t, found, "diagnostics: %v", doc.Diagnostics())
+	assert.Equal(t, SeverityError, found.Severity)
+	assert.Equal(t, "SPDXRef-a", found.ComponentID)
+	assert.Equal(t, 14, found.Line)
+}
+
+func TestSyntaxErrorPosition(t *testing.T) {
+	input := "{\n  \"bomFormat\": \"CycloneDX\",\n  \"specVersion\": \"1.5\",\n  \"components\": [}\n}"
+
+	_, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 4, column")
+}
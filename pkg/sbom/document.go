@@ -20,11 +20,19 @@ package sbom
 type Document interface {
 	Spec() Spec
 	Components() []GetComponent
+
+	// ComponentTree returns the components arranged by the assembly
+	// hierarchy of the SBOM, top level components first.
+	ComponentTree() []*ComponentNode
 	Relations() []GetRelation
 	Authors() []GetAuthor
 	Tools() []GetTool
 	Logs() []string
 
+	// Diagnostics returns the malformed and missing elements found while
+	// parsing the SBOM.
+	Diagnostics() []Diagnostic
+
 	Lifecycles() []string
 	Manufacturer() GetManufacturer
 	Supplier() GetSupplier
@@ -34,4 +42,12 @@ type Document interface {
 
 	Vulnerabilities() []GetVulnerabilities
 	Signature() GetSignature
+
+	// Attestation returns the in-toto attestation the SBOM was unwrapped
+	// from, or nil if it wasn't wrapped in one.
+	Attestation() GetAttestation
+
+	// Schema returns the outcome of validating the SBOM against the JSON
+	// schema of its spec version, or nil if it wasn't validated.
+	Schema() *SchemaResult
 }
@@ -0,0 +1,64 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+// ComponentNode is a component in the assembly hierarchy of an SBOM, with
+// the components it is assembled from.
+type ComponentNode struct {
+	Component GetComponent
+	Children  []*ComponentNode
+}
+
+// contain makes parent the parent of child. A component keeps the first
+// parent it is given, and a parent that is already a descendant of child is
+// refused, so the hierarchy stays a forest.
+func contain(parent, child *Component) bool {
+	if parent == nil || child == nil || child.parent != nil {
+		return false
+	}
+	for p := parent; p != nil; p = p.parent {
+		if p == child {
+			return false
+		}
+	}
+
+	child.parent = parent
+	parent.children = append(parent.children, child)
+	return true
+}
+
+// componentTree returns the hierarchy of comps. Components without a parent
+// are the roots, in the order of comps, and children are in the order they
+// were added.
+func componentTree(comps []GetComponent) []*ComponentNode {
+	var roots []*ComponentNode
+	for _, gc := range comps {
+		c, ok := gc.(*Component)
+		if !ok || c.parent == nil {
+			roots = append(roots, componentNode(gc))
+		}
+	}
+	return roots
+}
+
+func componentNode(gc GetComponent) *ComponentNode {
+	n := &ComponentNode{Component: gc}
+	if c, ok := gc.(*Component); ok {
+		for _, child := range c.children {
+			n.Children = append(n.Children, componentNode(child))
+		}
+	}
+	return n
+}
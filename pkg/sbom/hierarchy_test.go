@@ -0,0 +1,141 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// treeNames flattens a component tree into "name@depth" in depth first order.
+func treeNames(nodes []*ComponentNode) []string {
+	var names []string
+	for _, n := range nodes {
+		names = append(names, n.Component.GetName()+"@"+strings.Repeat(">", n.Component.GetDepth()))
+		names = append(names, treeNames(n.Children)...)
+	}
+	return names
+}
+
+func TestCDXComponentHierarchy(t *testing.T) {
+	input := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"type": "application", "bom-ref": "app", "name": "app",
+    "components": [{"type": "library", "bom-ref": "plugin", "name": "plugin"}]}},
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "components": [
+      {"type": "library", "bom-ref": "b", "name": "b", "components": [
+        {"type": "library", "bom-ref": "c", "name": "c"}
+      ]}
+    ]},
+    {"type": "library", "bom-ref": "d", "name": "d", "components": [
+      {"type": "library", "bom-ref": "b", "name": "b"}
+    ]}
+  ]
+}`
+
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"app@", "plugin@>", "a@", "b@>", "c@>>", "d@"}, treeNames(doc.ComponentTree()))
+
+	byID := map[string]GetComponent{}
+	for _, c := range doc.Components() {
+		byID[c.GetID()] = c
+	}
+	require.Len(t, byID, 6)
+	assert.Equal(t, "a", byID["b"].GetParentID())
+	assert.Equal(t, []string{"c"}, byID["b"].GetChildIDs())
+	assert.Equal(t, 2, byID["c"].GetDepth())
+	assert.Equal(t, "", byID["d"].GetParentID())
+	assert.Empty(t, byID["d"].GetChildIDs())
+}
+
+func TestSPDXComponentHierarchy(t *testing.T) {
+	input := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "test",
+  "documentNamespace": "https://example.com/test",
+  "creationInfo": {"created": "2024-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-image", "name": "image", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-layer", "name": "layer", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-lib", "name": "lib", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-other", "name": "other", "downloadLocation": "NOASSERTION"}
+  ],
+  "files": [{"SPDXID": "SPDXRef-file", "fileName": "./f", "checksums": [{"algorithm": "SHA1", "checksumValue": "da39a3ee5e6b4b0d3255bfef95601890afd80709"}]}],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-image"},
+    {"spdxElementId": "SPDXRef-image", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-layer"},
+    {"spdxElementId": "SPDXRef-lib", "relationshipType": "CONTAINED_BY", "relatedSpdxElement": "SPDXRef-layer"},
+    {"spdxElementId": "SPDXRef-lib", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-image"},
+    {"spdxElementId": "SPDXRef-layer", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-file"},
+    {"spdxElementId": "SPDXRef-other", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-lib"}
+  ]
+}`
+
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	require.NoError(t, err)
+
+	// lib CONTAINS image would make image its own ancestor and is ignored
+	assert.Equal(t, []string{"image@", "layer@>", "lib@>>", "other@"}, treeNames(doc.ComponentTree()))
+}
+
+func TestContain(t *testing.T) {
+	a, b, c := &Component{ID: "a"}, &Component{ID: "b"}, &Component{ID: "c"}
+
+	assert.True(t, contain(a, b))
+	assert.True(t, contain(b, c))
+	assert.False(t, contain(c, a), "cycle")
+	assert.False(t, contain(a, c), "second parent")
+	assert.False(t, contain(c, c), "self")
+	assert.False(t, contain(nil, c))
+
+	assert.Equal(t, "b", c.GetParentID())
+	assert.Equal(t, 2, c.GetDepth())
+	assert.Equal(t, []string{"b"}, a.GetChildIDs())
+}
+
+func TestSPDX3ComponentHierarchy(t *testing.T) {
+	input := `{
+  "@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld",
+  "@graph": [
+    {
+      "type": "SpdxDocument",
+      "spdxId": "urn:doc",
+      "creationInfo": {"type": "CreationInfo", "specVersion": "3.0.1", "created": "2025-01-01T00:00:00Z", "createdBy": "urn:person"},
+      "rootElement": "urn:app"
+    },
+    {"type": "Person", "spdxId": "urn:person", "name": "Jane Doe"},
+    {"type": "software_Package", "spdxId": "urn:app", "name": "app"},
+    {"type": "software_Package", "spdxId": "urn:lib", "name": "lib"},
+    {"type": "software_Package", "spdxId": "urn:tool", "name": "tool"},
+    {"type": "Relationship", "spdxId": "urn:rel", "from": "urn:app", "relationshipType": "contains", "to": ["urn:lib"]}
+  ]
+}`
+
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"app@", "lib@>", "tool@"}, treeNames(doc.ComponentTree()))
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// errStopWalk ends a walkJSONObject early without an error.
+var errStopWalk = errors.New("stop walk")
+
+// walkJSONObject walks the members of the top level JSON object in r one at
+// a time, so that no more than a single member value is held in memory.
+// visit is called with each key while the decoder is positioned at the
+// value, and must consume that value, either with dec.Decode or with
+// skipJSONValue. visit can return errStopWalk to end the walk early.
+func walkJSONObject(r io.Reader, visit func(key string, dec *json.Decoder) error) error {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("expected a json object, got %v", tok)
+	}
+
+	err = walkJSONMembers(dec, visit)
+	if errors.Is(err, errStopWalk) {
+		return nil
+	}
+	return err
+}
+
+// walkJSONMembers calls visit with each key of the object the decoder has
+// just entered, and consumes its closing brace, like walkJSONObject.
+// errStopWalk is returned as is.
+func walkJSONMembers(dec *json.Decoder, visit func(key string, dec *json.Decoder) error) error {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected an object key, got %v", tok)
+		}
+
+		if err := visit(key, dec); err != nil {
+			return err
+		}
+	}
+
+	_, err := dec.Token()
+	return err
+}
+
+// walkJSONArray calls visit for each element of the array the decoder is
+// positioned at. visit must consume the element. A null array has no
+// elements.
+func walkJSONArray(dec *json.Decoder, visit func(dec *json.Decoder) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("expected a json array, got %v", tok)
+	}
+
+	for dec.More() {
+		if err := visit(dec); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// walkJSONElement calls visit with each key of the object the decoder is
+// positioned at, like walkJSONMembers. A null element has no keys.
+func walkJSONElement(dec *json.Decoder, visit func(key string, dec *json.Decoder) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("expected a json object, got %v", tok)
+	}
+
+	return walkJSONMembers(dec, visit)
+}
+
+// decodeJSONArray decodes the array the decoder is positioned at an element
+// at a time, so the encoded array is never buffered whole. A null array
+// decodes to nil.
+func decodeJSONArray[T any](dec *json.Decoder) (*[]T, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return nil, fmt.Errorf("expected a json array, got %v", tok)
+	}
+
+	elems := []T{}
+	for dec.More() {
+		var e T
+		if err := dec.Decode(&e); err != nil {
+			return nil, err
+		}
+		elems = append(elems, e)
+	}
+
+	_, err = dec.Token()
+	return &elems, err
+}
+
+// skipJSONValue consumes the value the decoder is positioned at. Arrays and
+// objects are skipped a member at a time, so a large array costs no more
+// memory than its largest element.
+func skipJSONValue(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	d, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	for dec.More() {
+		if d == '{' {
+			if _, err := dec.Token(); err != nil {
+				return err
+			}
+		}
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// jsonHead is what the top level keys of a JSON document say about the kind
+// of document it is.
+type jsonHead struct {
+	object       bool
+	attestation  bool
+	spdxID       string
+	spdxVersion  string
+	spdx3        bool
+	spdx3Version string
+	bomFormat    string
+}
+
+// sniffJSON reads the top level keys of the JSON document in r, stopping as
+// soon as they identify it. Large values that don't identify the document,
+// such as the component list, are skipped a member at a time.
+func sniffJSON(r io.Reader) jsonHead {
+	var h jsonHead
+
+	decodeString := func(dec *json.Decoder, s *string) error {
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		*s, _ = v.(string)
+		return nil
+	}
+
+	_ = walkJSONObject(r, func(key string, dec *json.Decoder) error {
+		h.object = true
+
+		var err error
+		switch {
+		case key == "payloadType" || key == "dsseEnvelope" || key == "_type":
+			h.attestation = true
+			return errStopWalk
+		case strings.EqualFold(key, "SPDXID"):
+			err = decodeString(dec, &h.spdxID)
+		case strings.EqualFold(key, "spdxVersion"):
+			err = decodeString(dec, &h.spdxVersion)
+		case strings.EqualFold(key, "bomFormat"):
+			err = decodeString(dec, &h.bomFormat)
+		case key == "@context":
+			var v json.RawMessage
+			err = dec.Decode(&v)
+			h.spdx3 = isSPDX3Context(v)
+		case key == "@graph":
+			err = walkJSONArray(dec, func(dec *json.Decoder) error {
+				var v json.RawMessage
+				if err := dec.Decode(&v); err != nil {
+					return err
+				}
+				var e struct {
+					SpecVersion string `json:"specVersion"`
+				}
+				if json.Unmarshal(v, &e) == nil && h.spdx3Version == "" {
+					h.spdx3Version = e.SpecVersion
+				}
+				return nil
+			})
+		default:
+			err = skipJSONValue(dec)
+		}
+		if err != nil {
+			return err
+		}
+
+		if h.decided() {
+			return errStopWalk
+		}
+		return nil
+	})
+
+	return h
+}
+
+// decided reports whether the keys seen so far identify the document.
+func (h jsonHead) decided() bool {
+	switch {
+	case strings.HasPrefix(h.spdxID, "SPDX") && h.spdxVersion != "":
+		return true
+	case h.spdx3 && h.spdx3Version != "":
+		return true
+	case h.bomFormat == "CycloneDX":
+		return true
+	}
+	return false
+}
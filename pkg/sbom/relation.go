@@ -14,15 +14,61 @@
 
 package sbom
 
+import "strings"
+
+// RelationKind is the kind of a relationship in a vocabulary shared by the
+// specs. Inverse relationship types are normalized, so the From of a
+// relation is always the subject: "B DEPENDENCY_OF A" is A depends-on B.
+type RelationKind string
+
+const (
+	// RelationDependsOn is a dependency needed at run time.
+	RelationDependsOn         RelationKind = "depends-on"
+	RelationDevDependsOn      RelationKind = "dev-depends-on"
+	RelationBuildDependsOn    RelationKind = "build-depends-on"
+	RelationTestDependsOn     RelationKind = "test-depends-on"
+	RelationOptionalDependsOn RelationKind = "optional-depends-on"
+	RelationProvidedDependsOn RelationKind = "provided-depends-on"
+	// RelationProvides is a component that implements a specification or
+	// standard, the CycloneDX provides.
+	RelationProvides      RelationKind = "provides"
+	RelationContains      RelationKind = "contains"
+	RelationDescribes     RelationKind = "describes"
+	RelationGeneratedFrom RelationKind = "generated-from"
+	RelationOther         RelationKind = "other"
+)
+
+// IsDependency reports whether k is a dependency of any scope.
+func (k RelationKind) IsDependency() bool {
+	switch k {
+	case RelationDependsOn, RelationDevDependsOn, RelationBuildDependsOn,
+		RelationTestDependsOn, RelationOptionalDependsOn, RelationProvidedDependsOn:
+		return true
+	}
+	return false
+}
+
+// IsRuntimeDependency reports whether k is a dependency needed at run time.
+func (k RelationKind) IsRuntimeDependency() bool {
+	return k == RelationDependsOn
+}
+
 //counterfeiter:generate . Relation
 type GetRelation interface {
 	GetFrom() string
 	GetTo() string
+	// GetKind returns the normalized kind of the relationship.
+	GetKind() RelationKind
+	// GetType returns the relationship type as the spec names it, such as
+	// DEV_DEPENDENCY_OF or dependsOn.
+	GetType() string
 }
 
 type Relation struct {
 	From string
 	To   string
+	Kind RelationKind
+	Type string
 }
 
 func (r Relation) GetFrom() string {
@@ -32,3 +78,73 @@ func (r Relation) GetFrom() string {
 func (r Relation) GetTo() string {
 	return r.To
 }
+
+func (r Relation) GetKind() RelationKind {
+	return r.Kind
+}
+
+func (r Relation) GetType() string {
+	return r.Type
+}
+
+type relationKind struct {
+	kind RelationKind
+	// inverse is set for types whose subject is the related element
+	inverse bool
+}
+
+// spdxRelationKinds maps SPDX 2 relationship types to their kind.
+var spdxRelationKinds = map[string]relationKind{
+	"DEPENDS_ON":             {RelationDependsOn, false},
+	"DEPENDENCY_OF":          {RelationDependsOn, true},
+	"RUNTIME_DEPENDENCY_OF":  {RelationDependsOn, true},
+	"STATIC_LINK":            {RelationDependsOn, false},
+	"DYNAMIC_LINK":           {RelationDependsOn, false},
+	"DEV_DEPENDENCY_OF":      {RelationDevDependsOn, true},
+	"BUILD_DEPENDENCY_OF":    {RelationBuildDependsOn, true},
+	"TEST_DEPENDENCY_OF":     {RelationTestDependsOn, true},
+	"OPTIONAL_DEPENDENCY_OF": {RelationOptionalDependsOn, true},
+	"PROVIDED_DEPENDENCY_OF": {RelationProvidedDependsOn, true},
+	"CONTAINS":               {RelationContains, false},
+	"CONTAINED_BY":           {RelationContains, true},
+	"DESCRIBES":              {RelationDescribes, false},
+	"DESCRIBED_BY":           {RelationDescribes, true},
+	"GENERATED_FROM":         {RelationGeneratedFrom, false},
+	"GENERATES":              {RelationGeneratedFrom, true},
+}
+
+// spdx3RelationKinds maps SPDX 3 relationship types to their kind. SPDX 3
+// has no inverse types except generates.
+var spdx3RelationKinds = map[string]relationKind{
+	"dependsOn":             {RelationDependsOn, false},
+	"hasStaticLink":         {RelationDependsOn, false},
+	"hasDynamicLink":        {RelationDependsOn, false},
+	"hasOptionalDependency": {RelationOptionalDependsOn, false},
+	"hasProvidedDependency": {RelationProvidedDependsOn, false},
+	"contains":              {RelationContains, false},
+	"describes":             {RelationDescribes, false},
+	"generates":             {RelationGeneratedFrom, true},
+}
+
+// spdx3ScopeKinds narrows an SPDX 3 dependsOn by the scope of a
+// LifecycleScopedRelationship. Runtime and unscoped dependencies stay
+// depends-on.
+var spdx3ScopeKinds = map[string]RelationKind{
+	"development": RelationDevDependsOn,
+	"build":       RelationBuildDependsOn,
+	"test":        RelationTestDependsOn,
+}
+
+// newRelation returns the relation of type typ between a and b, looked up
+// in kinds, case insensitively. Types that aren't in kinds are other.
+func newRelation(kinds map[string]relationKind, typ, a, b string) Relation {
+	for t, k := range kinds {
+		if strings.EqualFold(t, typ) {
+			if k.inverse {
+				a, b = b, a
+			}
+			return Relation{From: a, To: b, Kind: k.kind, Type: typ}
+		}
+	}
+	return Relation{From: a, To: b, Kind: RelationOther, Type: typ}
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRelation(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want Relation
+	}{
+		{"DEPENDS_ON", Relation{From: "a", To: "b", Kind: RelationDependsOn, Type: "DEPENDS_ON"}},
+		{"DEPENDENCY_OF", Relation{From: "b", To: "a", Kind: RelationDependsOn, Type: "DEPENDENCY_OF"}},
+		{"dev_dependency_of", Relation{From: "b", To: "a", Kind: RelationDevDependsOn, Type: "dev_dependency_of"}},
+		{"CONTAINED_BY", Relation{From: "b", To: "a", Kind: RelationContains, Type: "CONTAINED_BY"}},
+		{"GENERATES", Relation{From: "b", To: "a", Kind: RelationGeneratedFrom, Type: "GENERATES"}},
+		{"VARIANT_OF", Relation{From: "a", To: "b", Kind: RelationOther, Type: "VARIANT_OF"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			assert.Equal(t, tt.want, newRelation(spdxRelationKinds, tt.typ, "a", "b"))
+		})
+	}
+}
+
+func TestRelationKind(t *testing.T) {
+	assert.True(t, RelationDependsOn.IsRuntimeDependency())
+	assert.False(t, RelationDevDependsOn.IsRuntimeDependency())
+	assert.True(t, RelationTestDependsOn.IsDependency())
+	assert.False(t, RelationContains.IsDependency())
+	assert.False(t, RelationProvides.IsDependency())
+}
+
+// relationsByKind returns "from>to" for each relation of the document, by kind.
+func relationsByKind(doc Document) map[RelationKind][]string {
+	rels := map[RelationKind][]string{}
+	for _, r := range doc.Relations() {
+		rels[r.GetKind()] = append(rels[r.GetKind()], r.GetFrom()+">"+r.GetTo())
+	}
+	return rels
+}
+
+// withRuntimeDependencies returns the names of the components that have a
+// runtime dependency.
+func withRuntimeDependencies(doc Document) []string {
+	var names []string
+	for _, c := range doc.Components() {
+		if c.HasRelationShips() {
+			names = append(names, c.GetName())
+		}
+	}
+	return names
+}
+
+func TestSPDXRelations(t *testing.T) {
+	input := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "test",
+  "documentNamespace": "https://example.com/test",
+  "creationInfo": {"created": "2024-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-app", "name": "app", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-lib", "name": "lib", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-linter", "name": "linter", "downloadLocation": "NOASSERTION"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-lib", "relationshipType": "DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-linter", "relationshipType": "DEV_DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-lib"},
+    {"spdxElementId": "SPDXRef-lib", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "NOASSERTION"}
+  ]
+}`
+
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[RelationKind][]string{
+		RelationDescribes:    {"SPDXRef-DOCUMENT>SPDXRef-app"},
+		RelationDependsOn:    {"SPDXRef-app>SPDXRef-lib"},
+		RelationDevDependsOn: {"SPDXRef-lib>SPDXRef-linter"},
+	}, relationsByKind(doc))
+	assert.Equal(t, []string{"app"}, withRuntimeDependencies(doc))
+}
+
+func TestCDXRelations(t *testing.T) {
+	input := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "version": 1,
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a"},
+    {"type": "library", "bom-ref": "b", "name": "b"},
+    {"type": "cryptographic-asset", "bom-ref": "aes", "name": "aes"}
+  ],
+  "dependencies": [
+    {"ref": "a", "dependsOn": ["b"], "provides": ["aes"]},
+    {"ref": "b", "provides": ["aes"]}
+  ]
+}`
+
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[RelationKind][]string{
+		RelationDependsOn: {"a>b"},
+		RelationProvides:  {"a>aes", "b>aes"},
+	}, relationsByKind(doc))
+	assert.Equal(t, []string{"a"}, withRuntimeDependencies(doc))
+}
+
+func TestSPDX3Relations(t *testing.T) {
+	input := `{
+  "@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld",
+  "@graph": [
+    {
+      "type": "SpdxDocument",
+      "spdxId": "urn:doc",
+      "creationInfo": {"type": "CreationInfo", "specVersion": "3.0.1", "created": "2025-01-01T00:00:00Z", "createdBy": "urn:person"},
+      "rootElement": "urn:app"
+    },
+    {"type": "Person", "spdxId": "urn:person", "name": "Jane Doe"},
+    {"type": "software_Package", "spdxId": "urn:app", "name": "app"},
+    {"type": "software_Package", "spdxId": "urn:lib", "name": "lib"},
+    {"type": "software_Package", "spdxId": "urn:test", "name": "test"},
+    {"type": "Relationship", "spdxId": "urn:rel1", "from": "urn:lib", "relationshipType": "dependsOn", "to": ["urn:app"]},
+    {"type": "LifecycleScopedRelationship", "spdxId": "urn:rel2", "from": "urn:app", "relationshipType": "dependsOn", "to": ["urn:test"], "scope": "test"}
+  ]
+}`
+
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[RelationKind][]string{
+		RelationDependsOn:     {"urn:lib>urn:app"},
+		RelationTestDependsOn: {"urn:app>urn:test"},
+	}, relationsByKind(doc))
+	assert.Equal(t, []string{"lib"}, withRuntimeDependencies(doc))
+}
@@ -16,10 +16,11 @@ package sbom
 
 import (
 	"bufio"
+	"bytes"
 	"context"
-	"encoding/json"
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"strings"
@@ -55,9 +56,25 @@ type spdxbasic struct {
 	Version string `json:"spdxVersion" yaml:"spdxVersion"`
 }
 
-type cdxbasic struct {
-	XMLNS     string `json:"-" xml:"xmlns,attr"`
-	BOMFormat string `json:"bomFormat" xml:"-"`
+// isSPDX3Context reports whether the @context of a JSON-LD document names
+// the SPDX 3 vocabulary.
+func isSPDX3Context(context []byte) bool {
+	return bytes.Contains(context, []byte("spdx.org/rdf/3"))
+}
+
+// isCDXXML reports whether the root element of the XML document in r is in
+// a CycloneDX namespace. Only the prolog and root element are read.
+func isCDXXML(r io.Reader) bool {
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			return strings.HasPrefix(se.Name.Space, "http://cyclonedx.org")
+		}
+	}
 }
 
 func SupportedSBOMSpecs() []string {
@@ -110,21 +127,18 @@ func detectSbomFormat(f io.ReadSeeker) (SpecFormat, FileFormat, FormatVersion, e
 		log.Fatalf("Failed to seek: %v", err)
 	}
 
-	var s spdxbasic
-	if err := json.NewDecoder(f).Decode(&s); err == nil {
-		if strings.HasPrefix(s.ID, "SPDX") {
-			return SBOMSpecSPDX, FileFormatJSON, FormatVersion(s.Version), nil
+	h := sniffJSON(f)
+	if h.object {
+		if strings.HasPrefix(h.spdxID, "SPDX") {
+			return SBOMSpecSPDX, FileFormatJSON, FormatVersion(h.spdxVersion), nil
 		}
-	}
-
-	_, err = f.Seek(0, io.SeekStart)
-	if err != nil {
-		log.Printf("Failed to seek: %v", err)
-	}
-
-	var cdx cdxbasic
-	if err := json.NewDecoder(f).Decode(&cdx); err == nil {
-		if cdx.BOMFormat == "CycloneDX" {
+		if h.spdx3 {
+			if h.spdx3Version == "" {
+				return SBOMSpecSPDX, FileFormatJSON, "SPDX-3", nil
+			}
+			return SBOMSpecSPDX, FileFormatJSON, FormatVersion("SPDX-" + h.spdx3Version), nil
+		}
+		if h.bomFormat == "CycloneDX" {
 			return SBOMSpecCDX, FileFormatJSON, "", nil
 		}
 	}
@@ -134,11 +148,10 @@ func detectSbomFormat(f io.ReadSeeker) (SpecFormat, FileFormat, FormatVersion, e
 		log.Printf("Failed to seek: %v", err)
 	}
 
-	if err := xml.NewDecoder(f).Decode(&cdx); err == nil {
-		if strings.HasPrefix(cdx.XMLNS, "http://cyclonedx.org") {
-			return SBOMSpecCDX, FileFormatXML, "", nil
-		}
+	if isCDXXML(f) {
+		return SBOMSpecCDX, FileFormatXML, "", nil
 	}
+
 	_, err = f.Seek(0, io.SeekStart)
 	if err != nil {
 		log.Printf("Failed to seek: %v", err)
@@ -158,16 +171,68 @@ func detectSbomFormat(f io.ReadSeeker) (SpecFormat, FileFormat, FormatVersion, e
 	var y spdxbasic
 	if err := yaml.NewDecoder(f).Decode(&y); err == nil {
 		if strings.HasPrefix(y.ID, "SPDX") {
-			return SBOMSpecSPDX, FileFormatYAML, FormatVersion(s.Version), nil
+			return SBOMSpecSPDX, FileFormatYAML, FormatVersion(y.Version), nil
 		}
 	}
 
 	return SBOMSpecUnknown, FileFormatUnknown, "", nil
 }
 
-func NewSBOMDocument(ctx context.Context, f io.ReadSeeker, sig Signature) (Document, error) {
+// DetectSpec returns the spec of the SBOM in data, reading no more of it
+// than it takes to tell.
+func DetectSpec(data []byte) SpecFormat {
+	spec, _, _, _ := detectSbomFormat(bytes.NewReader(data))
+	return spec
+}
+
+// attested is implemented by documents that can record the attestation
+// they were unwrapped from.
+type attested interface {
+	setAttestation(*Attestation)
+}
+
+func NewSBOMDocument(ctx context.Context, f io.ReadSeeker, sig Signature, opts ...ParseOption) (Document, error) {
 	log := logger.FromContext(ctx)
 
+	var o parseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// A document validated against its schema is read whole anyway.
+	stream := o.stream && !o.strict
+
+	// Only an attestation is copied to be unwrapped, other documents are
+	// parsed from f as they are.
+	var att *Attestation
+	if sniffJSON(f).attestation {
+		if stream {
+			return nil, ErrNotStreamable
+		}
+
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return nil, err
+		}
+
+		data, att, err = UnwrapAttestation(data)
+		if err != nil {
+			return nil, err
+		}
+		if att != nil {
+			log.Debugf("SBOM unwrapped from attestation predicate:%s subjects:%d", att.PredicateType, len(att.Subjects))
+			f = bytes.NewReader(data)
+		}
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
 	spec, format, version, err := detectSbomFormat(f)
 	if err != nil {
 		return nil, err
@@ -181,10 +246,38 @@ func NewSBOMDocument(ctx context.Context, f io.ReadSeeker, sig Signature) (Docum
 	case SBOMSpecSPDX:
 		doc, err = newSPDXDoc(ctx, f, format, version, sig)
 	case SBOMSpecCDX:
-		doc, err = newCDXDoc(ctx, f, format, sig)
+		doc, err = newCDXDoc(ctx, f, format, sig, stream)
 	default:
 		return nil, errors.New("unsupported sbom format")
 	}
+	if err != nil {
+		if format == FileFormatJSON {
+			err = jsonErrorPosition(f, err)
+		}
+		return nil, err
+	}
+
+	if a, ok := doc.(attested); ok && att != nil {
+		a.setAttestation(att)
+	}
+
+	if o.strict {
+		if format != FileFormatJSON {
+			if v, ok := doc.(validated); ok {
+				v.addDiagnostic(Diagnostic{Severity: SeverityWarning, Message: fmt.Sprintf("schema validation needs a JSON document, %s not validated", format)})
+			}
+		} else if err := validateSchema(f, doc, version); err != nil {
+			return nil, fmt.Errorf("schema validation: %w", err)
+		}
+	}
+
+	// Diagnostics are located in place, through the slice the document
+	// returns. Positions in an unwrapped payload wouldn't match the file.
+	if format == FileFormatJSON && att == nil {
+		if err := locateDiagnostics(f, doc.Diagnostics()); err != nil {
+			log.Debugf("failed to locate diagnostics: %v", err)
+		}
+	}
 
-	return doc, err
+	return doc, nil
 }
@@ -0,0 +1,200 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// The CycloneDX schemas are the ones shipped with cyclonedx-go, the SPDX ones
+// follow the schemas published in spdx/spdx-spec for each version.
+//
+//go:embed schema/*.json
+var schemaFS embed.FS
+
+// schemaFiles maps a spec and version to the file of its JSON schema.
+var schemaFiles = map[string]string{
+	"cyclonedx-1.4": "bom-1.4.schema.json",
+	"cyclonedx-1.5": "bom-1.5.schema.json",
+	"cyclonedx-1.6": "bom-1.6.schema.json",
+	"spdx-2.2":      "spdx-2.2.schema.json",
+	"spdx-2.3":      "spdx-2.3.schema.json",
+}
+
+// schemaRefs are the schemas the CycloneDX schemas reference by relative
+// URI. They are resolved against the embedded copies, not fetched.
+var schemaRefs = []string{"spdx.schema.json", "jsf-0.82.schema.json"}
+
+var (
+	schemaMu    sync.Mutex
+	schemaCache = map[string]*gojsonschema.Schema{}
+)
+
+// SchemaResult is the outcome of validating an SBOM against the JSON schema
+// of its spec version.
+type SchemaResult struct {
+	// Schema names the schema, such as cyclonedx-1.5 or spdx-2.3.
+	Schema string
+	// Violations is the number of places the document breaks the schema,
+	// each of which is also recorded as a diagnostic.
+	Violations int
+}
+
+// ParseOption changes how NewSBOMDocument parses an SBOM.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	strict bool
+	stream bool
+}
+
+// WithSchemaValidation validates JSON documents against the schema of their
+// spec version after parsing them. The result is available from
+// Document.Schema and every violation is recorded as a diagnostic.
+func WithSchemaValidation(strict bool) ParseOption {
+	return func(o *parseOptions) {
+		o.strict = strict
+	}
+}
+
+// validated is implemented by documents that can record the outcome of
+// schema validation.
+type validated interface {
+	setSchema(*SchemaResult)
+	addDiagnostic(Diagnostic)
+}
+
+// schemaName returns the name of the schema for a spec type and version as
+// the documents report them, such as "spdx" and "SPDX-2.3".
+func schemaName(specType, version string) string {
+	return specType + "-" + strings.TrimPrefix(version, "SPDX-")
+}
+
+// loadSchema compiles the named schema, once.
+func loadSchema(name string) (*gojsonschema.Schema, error) {
+	schemaMu.Lock()
+	defer schemaMu.Unlock()
+
+	if s, ok := schemaCache[name]; ok {
+		return s, nil
+	}
+
+	file, ok := schemaFiles[name]
+	if !ok {
+		return nil, fmt.Errorf("no JSON schema for %s", name)
+	}
+
+	sl := gojsonschema.NewSchemaLoader()
+	if strings.HasPrefix(name, "cyclonedx-") {
+		for _, ref := range schemaRefs {
+			data, err := schemaFS.ReadFile("schema/" + ref)
+			if err != nil {
+				return nil, err
+			}
+			if err := sl.AddSchemas(gojsonschema.NewBytesLoader(data)); err != nil {
+				return nil, fmt.Errorf("loading %s: %w", ref, err)
+			}
+		}
+	}
+
+	data, err := schemaFS.ReadFile("schema/" + file)
+	if err != nil {
+		return nil, err
+	}
+	s, err := sl.Compile(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return nil, fmt.Errorf("compiling %s: %w", file, err)
+	}
+
+	schemaCache[name] = s
+	return s, nil
+}
+
+// validateSchema validates the JSON document in r against the schema of
+// its spec version and records the result and the violations on doc. The
+// version detected in the file is preferred over the one doc reports, as
+// the SPDX parser reads a 2.2 document as 2.3.
+func validateSchema(r io.ReadSeeker, doc Document, version FormatVersion) error {
+	v, ok := doc.(validated)
+	if !ok {
+		return nil
+	}
+
+	if version == "" {
+		version = FormatVersion(doc.Spec().GetVersion())
+	}
+	name := schemaName(doc.Spec().GetSpecType(), string(version))
+	if _, ok := schemaFiles[name]; !ok {
+		v.addDiagnostic(Diagnostic{Severity: SeverityWarning, Message: "no JSON schema for " + name + ", not validated"})
+		return nil
+	}
+
+	s, err := loadSchema(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.Validate(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return err
+	}
+
+	for _, e := range res.Errors() {
+		v.addDiagnostic(Diagnostic{
+			Severity: SeverityError,
+			Path:     schemaErrorPath(e),
+			Message:  "schema: " + e.Description(),
+		})
+	}
+	v.setSchema(&SchemaResult{Schema: name, Violations: len(res.Errors())})
+	return nil
+}
+
+// schemaErrorPath returns the JSON pointer to the element a schema violation
+// is about. A missing required property is pointed at, like the parsers
+// point at missing fields.
+func schemaErrorPath(e gojsonschema.ResultError) string {
+	// Property names can hold dots, so the context is split on a byte that
+	// can't appear unescaped in a JSON string.
+	tokens := strings.Split(e.Context().String("\x00"), "\x00")[1:]
+	if e.Type() == "required" {
+		if p, ok := e.Details()["property"].(string); ok {
+			tokens = append(tokens, p)
+		}
+	}
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	parts := make([]interface{}, len(tokens))
+	for i, t := range tokens {
+		parts[i] = t
+	}
+	return jsonPointer(parts...)
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		schema     string
+		violations []string
+	}{
+		{
+			name:       "cyclonedx violation",
+			input:      testDiagnosticsCDX,
+			schema:     "cyclonedx-1.5",
+			violations: []string{"/components/1/components/0/type", "/dependencies/0/ref"},
+		},
+		{
+			name:   "valid cyclonedx",
+			input:  testCDX,
+			schema: "cyclonedx-1.5",
+		},
+		{
+			name:       "spdx violation",
+			input:      `{"spdxVersion": "SPDX-2.2", "dataLicense": "CC0-1.0", "SPDXID": "SPDXRef-DOCUMENT", "name": "test", "creationInfo": {"created": "2024-01-01T00:00:00Z", "creators": []}, "packages": [{"SPDXID": "SPDXRef-a", "name": "a"}]}`,
+			schema:     "spdx-2.2",
+			violations: []string{"/creationInfo/creators", "/packages/0/downloadLocation"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := NewSBOMDocument(context.Background(), strings.NewReader(tt.input), Signature{}, WithSchemaValidation(true))
+			require.NoError(t, err)
+			require.NotNil(t, doc.Schema())
+			assert.Equal(t, tt.schema, doc.Schema().Schema)
+			assert.Equal(t, len(tt.violations), doc.Schema().Violations)
+
+			var paths []string
+			for _, d := range doc.Diagnostics() {
+				if strings.HasPrefix(d.Message, "schema: ") {
+					assert.Equal(t, SeverityError, d.Severity)
+					paths = append(paths, d.Path)
+				}
+			}
+			assert.ElementsMatch(t, tt.violations, paths)
+		})
+	}
+}
+
+func TestSchemaValidationSamples(t *testing.T) {
+	samples := []string{
+		"../../samples/sbomqs-cdx-cgomod.json",
+		"../../samples/sbomqs-dummy-licenses.cdx-1.6.json",
+		"../../samples/stree-cdxgen.cdx.json",
+		"../../samples/photon.spdx.json",
+	}
+
+	for _, sample := range samples {
+		t.Run(sample, func(t *testing.T) {
+			f, err := os.Open(sample)
+			require.NoError(t, err)
+			defer f.Close()
+
+			doc, err := NewSBOMDocument(context.Background(), f, Signature{}, WithSchemaValidation(true))
+			require.NoError(t, err)
+			require.NotNil(t, doc.Schema())
+			assert.Zero(t, doc.Schema().Violations)
+		})
+	}
+}
+
+func TestSchemaValidationSkipped(t *testing.T) {
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(testCDX), Signature{})
+	require.NoError(t, err)
+	assert.Nil(t, doc.Schema())
+
+	old := strings.Replace(testCDX, `"specVersion": "1.5"`, `"specVersion": "1.3"`, 1)
+	require.NotEqual(t, testCDX, old)
+	doc, err = NewSBOMDocument(context.Background(), strings.NewReader(old), Signature{}, WithSchemaValidation(true))
+	require.NoError(t, err)
+	assert.Nil(t, doc.Schema())
+	require.NotEmpty(t, doc.Diagnostics())
+	assert.Equal(t, "no JSON schema for cyclonedx-1.3, not validated", doc.Diagnostics()[0].Message)
+}
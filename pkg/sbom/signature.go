@@ -20,6 +20,9 @@ type GetSignature interface {
 	GetBlob() string
 }
 
+// Signature holds the material to verify an SBOM signature. All fields hold
+// contents rather than file names: the raw signature, the PEM encoded public
+// key and the signed blob.
 type Signature struct {
 	SigValue  string
 	PublicKey string
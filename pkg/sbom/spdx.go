@@ -38,7 +38,7 @@ import (
 
 var (
 	spdxFileFormats    = []string{"json", "yaml", "rdf", "tag-value"}
-	spdxSpecVersions   = []string{"SPDX-2.1", "SPDX-2.2", "SPDX-2.3"}
+	spdxSpecVersions   = []string{"SPDX-2.1", "SPDX-2.2", "SPDX-2.3", "SPDX-3.0", "SPDX-3.0.1"}
 	spdxPrimaryPurpose = []string{"application", "framework", "library", "container", "operating-system", "device", "firmware", "source", "archive", "file", "install", "other"}
 )
 
@@ -53,18 +53,27 @@ type SpdxDoc struct {
 	SpdxTools        []GetTool
 	Rels             []GetRelation
 	logs             []string
+	diagnostics      []Diagnostic
 	PrimaryComponent PrimaryComp
 	Lifecycle        string
 	Dependencies     map[string][]string
 	composition      map[string]string
 	Vuln             []GetVulnerabilities
 	SignatureDetail  GetSignature
+	attestation      *Attestation
+	// elements with a runtime dependency, by SPDX identifier
+	dependents map[string]bool
+	schema     *SchemaResult
 }
 
 func newSPDXDoc(ctx context.Context, f io.ReadSeeker, format FileFormat, version FormatVersion, sig Signature) (Document, error) {
 	_ = logger.FromContext(ctx)
 	var err error
 
+	if isSPDX3(version) {
+		return newSPDX3Doc(ctx, f, format, version, sig)
+	}
+
 	_, err = f.Seek(0, io.SeekStart)
 	if err != nil {
 		log.Printf("Failed to seek: %v", err)
@@ -127,7 +136,11 @@ func (s SpdxDoc) Relations() []GetRelation {
 }
 
 func (s SpdxDoc) Logs() []string {
-	return s.logs
+	return diagnosticLogs(s.logs, s.diagnostics)
+}
+
+func (s SpdxDoc) Diagnostics() []Diagnostic {
+	return s.diagnostics
 }
 
 func (s SpdxDoc) Lifecycles() []string {
@@ -163,12 +176,36 @@ func (s SpdxDoc) Signature() GetSignature {
 	return s.SignatureDetail
 }
 
+func (s SpdxDoc) ComponentTree() []*ComponentNode {
+	return componentTree(s.Comps)
+}
+
+func (s SpdxDoc) Attestation() GetAttestation {
+	if s.attestation == nil {
+		return nil
+	}
+	return s.attestation
+}
+
+func (s *SpdxDoc) setAttestation(att *Attestation) {
+	s.attestation = att
+}
+
+func (s SpdxDoc) Schema() *SchemaResult {
+	return s.schema
+}
+
+func (s *SpdxDoc) setSchema(res *SchemaResult) {
+	s.schema = res
+}
+
 func (s *SpdxDoc) parse() {
 	s.parseDoc()
 	s.parseSpec()
 	s.parseAuthors()
 	s.parseTool()
 	s.parsePrimaryCompAndRelationships()
+	s.parseRelations()
 	s.parseComps()
 }
 
@@ -305,6 +342,34 @@ func (s *SpdxDoc) parseComps() {
 
 		s.Comps = append(s.Comps, nc)
 	}
+
+	s.parseContainment()
+}
+
+// parseContainment arranges the packages by their CONTAINS and CONTAINED_BY
+// relationships. Files and elements of other documents aren't components,
+// relationships with them are skipped.
+func (s *SpdxDoc) parseContainment() {
+	byID := make(map[string]*Component, len(s.Comps))
+	for _, c := range s.Comps {
+		if nc, ok := c.(*Component); ok {
+			byID[nc.ID] = nc
+		}
+	}
+
+	for _, r := range s.doc.Relationships {
+		if r == nil || r.RefA.DocumentRefID != "" || r.RefB.DocumentRefID != "" {
+			continue
+		}
+		a, b := byID[string(r.RefA.ElementRefID)], byID[string(r.RefB.ElementRefID)]
+
+		switch strings.ToUpper(r.Relationship) {
+		case spdx_common.TypeRelationshipContains:
+			contain(a, b)
+		case spdx_common.TypeRelationshipContainedBy:
+			contain(b, a)
+		}
+	}
 }
 
 func (s *SpdxDoc) parseAuthors() {
@@ -331,23 +396,37 @@ func (s *SpdxDoc) parseAuthors() {
 	}
 }
 
-// return true if a component has DEPENDS_ON relationship
+// return true if a component has a runtime dependency, see parseRelations
 func getComponentDependencies(s *SpdxDoc, componentID string) bool {
-	newID := "SPDXRef-" + componentID
+	return s.dependents["SPDXRef-"+componentID]
+}
+
+// parseRelations records every relationship between elements, normalized,
+// and the elements that have a runtime dependency.
+func (s *SpdxDoc) parseRelations() {
+	s.Rels = []GetRelation{}
+	s.dependents = map[string]bool{}
 
 	for _, r := range s.doc.Relationships {
-		if strings.ToUpper(r.Relationship) == "DEPENDS_ON" {
-			aBytes, err := r.RefA.MarshalJSON()
-			if err != nil {
-				continue
-			}
+		// NONE and NOASSERTION aren't elements
+		if r == nil || r.RefA.SpecialID != "" || r.RefB.SpecialID != "" {
+			continue
+		}
+		aBytes, err := r.RefA.MarshalJSON()
+		if err != nil {
+			continue
+		}
+		bBytes, err := r.RefB.MarshalJSON()
+		if err != nil {
+			continue
+		}
 
-			if CleanKey(string(aBytes)) == newID {
-				return true
-			}
+		rel := newRelation(spdxRelationKinds, r.Relationship, CleanKey(string(aBytes)), CleanKey(string(bBytes)))
+		s.Rels = append(s.Rels, rel)
+		if rel.Kind.IsRuntimeDependency() {
+			s.dependents[rel.From] = true
 		}
 	}
-	return false
 }
 
 func (s *SpdxDoc) parsePrimaryCompAndRelationships() {
@@ -411,50 +490,69 @@ func (s *SpdxDoc) parseTool() {
 		return
 	}
 
-	// https://spdx.github.io/spdx-spec/v2.3/document-creation-information/#68-creator-field
-	// spdx2.3 spec says If the SPDX document was created using a software tool,
-	// indicate the name and version for that tool
-	extractVersion := func(inputName string) (string, string) {
-		// Split the input string by "-"
-		parts := strings.Split(inputName, "-")
-
-		// if there are no "-" its a bad string
-		if len(parts) == 1 {
-			return inputName, ""
-		}
-		// The last element after splitting is the version
-		version := parts[len(parts)-1]
-
-		// The name is everything before the last element
-		name := strings.Join(parts[:len(parts)-1], "-")
-
-		// check if version has atleast one-digit
-		// if not, then it is not a version
-		for _, r := range version {
-			if unicode.IsDigit(r) {
-				return name, version
-			}
-		}
-
-		// This is a bad case
-		return inputName, ""
-	}
-
 	for _, c := range s.doc.CreationInfo.Creators {
 		ctType := strings.ToLower(c.CreatorType)
 		if ctType != "tool" {
 			continue
 		}
 		t := Tool{}
-		t.Name, t.Version = extractVersion(c.Creator)
+		t.Name, t.Version = splitToolVersion(c.Creator)
 		s.SpdxTools = append(s.SpdxTools, t)
 	}
 }
 
+// https://spdx.github.io/spdx-spec/v2.3/document-creation-information/#68-creator-field
+// spdx2.3 spec says If the SPDX document was created using a software tool,
+// indicate the name and version for that tool
+func splitToolVersion(inputName string) (string, string) {
+	// Split the input string by "-"
+	parts := strings.Split(inputName, "-")
+
+	// if there are no "-" its a bad string
+	if len(parts) == 1 {
+		return inputName, ""
+	}
+	// The last element after splitting is the version
+	version := parts[len(parts)-1]
+
+	// The name is everything before the last element
+	name := strings.Join(parts[:len(parts)-1], "-")
+
+	// check if version has atleast one-digit
+	// if not, then it is not a version
+	for _, r := range version {
+		if unicode.IsDigit(r) {
+			return name, version
+		}
+	}
+
+	// This is a bad case
+	return inputName, ""
+}
+
 func (s *SpdxDoc) addToLogs(log string) {
 	s.logs = append(s.logs, log)
 }
 
+func (s *SpdxDoc) addDiagnostic(d Diagnostic) {
+	s.diagnostics = append(s.diagnostics, d)
+}
+
+// missing records a required field missing at path.
+func (s *SpdxDoc) missing(path, compID string) {
+	field := path[strings.LastIndexByte(path, '/')+1:]
+	s.addDiagnostic(Diagnostic{Severity: SeverityWarning, Path: path, ComponentID: compID, Message: "missing " + field})
+}
+
+// pkgID returns the SPDX identifier of the package at index as it is written
+// in the document.
+func (s *SpdxDoc) pkgID(index int) string {
+	if id := s.doc.Packages[index].PackageSPDXIdentifier; id != "" {
+		return "SPDXRef-" + string(id)
+	}
+	return ""
+}
+
 func (s *SpdxDoc) requiredFields() bool {
 	if s.doc == nil {
 		s.addToLogs("spdx doc is not parsable")
@@ -462,48 +560,48 @@ func (s *SpdxDoc) requiredFields() bool {
 	}
 	// Creation info is a required section
 	if s.doc.CreationInfo == nil {
-		s.addToLogs("spdx doc is missing creation info")
+		s.missing("/creationInfo", "")
 		return false
 	}
 
 	// SPDXVersion is required
 	if s.doc.SPDXVersion == "" {
-		s.addToLogs("spdx doc is missing SPDXVersion")
+		s.missing("/spdxVersion", "")
 		return false
 	}
 
 	// data license is required
 	if s.doc.DataLicense == "" {
-		s.addToLogs("spdx doc is missing Datalicense")
+		s.missing("/dataLicense", "")
 		return false
 	}
 	// Identify the current SPDX document which may be referenced in relationships
 	// by other files, packages internally and documents externally
 	if s.doc.SPDXIdentifier == "" {
-		s.addToLogs("spdx doc is missing SPDXIdentifier")
+		s.missing("/SPDXID", "")
 		return false
 	}
 	// Identify name of this document as designated by creator
 	if s.doc.DocumentName == "" {
-		s.addToLogs("spdx doc is missing DocumentName")
+		s.missing("/name", "")
 		return false
 	}
 
 	// The URI provides an unambiguous mechanism for other SPDX documents to reference SPDX elements within this SPDX document
 	if s.doc.DocumentNamespace == "" {
-		s.addToLogs("spdx doc is missing Document Namespace")
+		s.missing("/documentNamespace", "")
 		return false
 	}
 
 	// Identify who (or what, in the case of a tool) created the SPDX document.
 	if len(s.doc.CreationInfo.Creators) == 0 {
-		s.addToLogs("spdx doc is missing creators")
+		s.missing("/creationInfo/creators", "")
 		return false
 	}
 
 	// Identify when the SPDX document was originally created.
 	if s.doc.CreationInfo.Created == "" {
-		s.addToLogs("spdx doc is missing created timestamp")
+		s.missing("/creationInfo/created", "")
 		return false
 	}
 	return true
@@ -513,23 +611,23 @@ func (s *SpdxDoc) pkgRequiredFields(index int) bool {
 	pkg := s.doc.Packages[index]
 
 	if pkg.PackageName == "" {
-		s.addToLogs(fmt.Sprintf("spdx doc pkg %s at index %d missing name", pkg.PackageSPDXIdentifier, index))
+		s.missing(jsonPointer("packages", index, "name"), s.pkgID(index))
 		return false
 	}
 
 	if pkg.PackageSPDXIdentifier == "" {
-		s.addToLogs(fmt.Sprintf("spdx doc pkg %s at index %d missing identifier", pkg.PackageName, index))
+		s.missing(jsonPointer("packages", index, "SPDXID"), s.pkgID(index))
 		return false
 	}
 
 	// What is the correct behaviour for NONE and NOASSERTION?
 	if pkg.PackageDownloadLocation == "" {
-		s.addToLogs(fmt.Sprintf("spdx doc pkg %s at index %d missing downloadLocation", pkg.PackageName, index))
+		s.missing(jsonPointer("packages", index, "downloadLocation"), s.pkgID(index))
 		return false
 	}
 
 	if pkg.FilesAnalyzed && pkg.PackageVerificationCode == nil {
-		s.addToLogs(fmt.Sprintf("spdx doc pkg %s at index %d missing packageVerificationCode", pkg.PackageName, index))
+		s.missing(jsonPointer("packages", index, "packageVerificationCode"), s.pkgID(index))
 		return false
 	}
 	return true
@@ -544,13 +642,13 @@ func (s *SpdxDoc) purls(index int) []purl.PURL {
 		return urls
 	}
 
-	for _, p := range pkg.PackageExternalReferences {
+	for i, p := range pkg.PackageExternalReferences {
 		if strings.ToLower(p.RefType) == spdx_common.TypePackageManagerPURL {
 			prl := purl.NewPURL(p.Locator)
 			if prl.Valid() {
 				urls = append(urls, prl)
 			} else {
-				s.addToLogs(fmt.Sprintf("spdx doc pkg %s at index %d invalid purl found", pkg.PackageName, index))
+				s.invalidRef(index, i, "purl")
 			}
 		}
 	}
@@ -562,6 +660,17 @@ func (s *SpdxDoc) purls(index int) []purl.PURL {
 	return urls
 }
 
+// invalidRef records a malformed locator in external reference ref of the
+// package at index.
+func (s *SpdxDoc) invalidRef(index, ref int, kind string) {
+	s.addDiagnostic(Diagnostic{
+		Severity:    SeverityError,
+		Path:        jsonPointer("packages", index, "externalRefs", ref, "referenceLocator"),
+		ComponentID: s.pkgID(index),
+		Message:     fmt.Sprintf("invalid %s %q", kind, s.doc.Packages[index].PackageExternalReferences[ref].Locator),
+	})
+}
+
 func (s *SpdxDoc) cpes(index int) []cpe.CPE {
 	urls := []cpe.CPE{}
 	pkg := s.doc.Packages[index]
@@ -570,13 +679,13 @@ func (s *SpdxDoc) cpes(index int) []cpe.CPE {
 		return urls
 	}
 
-	for _, p := range pkg.PackageExternalReferences {
+	for i, p := range pkg.PackageExternalReferences {
 		if p.RefType == spdx_common.TypeSecurityCPE23Type || p.RefType == spdx_common.TypeSecurityCPE22Type {
 			cpeV := cpe.NewCPE(p.Locator)
 			if cpeV.Valid() {
 				urls = append(urls, cpeV)
 			} else {
-				s.addToLogs(fmt.Sprintf("spdx doc pkg %s at index %d invalid cpes found", pkg.PackageName, index))
+				s.invalidRef(index, i, "cpe")
 			}
 		}
 	}
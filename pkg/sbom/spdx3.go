@@ -0,0 +1,958 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/cpe"
+	"github.com/interlynk-io/sbomqs/pkg/licenses"
+	"github.com/interlynk-io/sbomqs/pkg/logger"
+	"github.com/interlynk-io/sbomqs/pkg/omniborid"
+	"github.com/interlynk-io/sbomqs/pkg/purl"
+	"github.com/interlynk-io/sbomqs/pkg/swhid"
+	"github.com/interlynk-io/sbomqs/pkg/swid"
+	"github.com/samber/lo"
+)
+
+const spdx3LicensePrefix = "https://spdx.org/licenses/"
+
+// spdx3RefTypes maps SPDX 3.0 external identifier types to the SPDX 2.x
+// external reference types, which is what the compliance checks look for.
+var spdx3RefTypes = map[string]string{
+	"packageUrl": "purl",
+	"cpe22":      "cpe22Type",
+	"cpe23":      "cpe23Type",
+	"swhid":      "swh",
+}
+
+func isSPDX3(version FormatVersion) bool {
+	return strings.HasPrefix(string(version), "SPDX-3")
+}
+
+type spdx3Graph struct {
+	Context json.RawMessage `json:"@context"`
+	Graph   []*spdx3Element `json:"@graph"`
+}
+
+// spdx3Element holds the properties sbomqs reads from any element in the
+// graph. SPDX 3.0 classes share one flat JSON-LD namespace, so a single
+// struct covers documents, agents, packages, relationships and licenses.
+type spdx3Element struct {
+	Type                string                    `json:"type"`
+	AtType              string                    `json:"@type"`
+	SpdxID              string                    `json:"spdxId"`
+	AtID                string                    `json:"@id"`
+	Name                string                    `json:"name"`
+	Comment             string                    `json:"comment"`
+	CreationInfo        json.RawMessage           `json:"creationInfo"`
+	ExternalIdentifiers []spdx3ExternalIdentifier `json:"externalIdentifier"`
+	ExternalRefs        []spdx3ExternalRef        `json:"externalRef"`
+	VerifiedUsing       []spdx3Hash               `json:"verifiedUsing"`
+
+	// CreationInfo
+	SpecVersion  string    `json:"specVersion"`
+	Created      string    `json:"created"`
+	CreatedBy    spdx3List `json:"createdBy"`
+	CreatedUsing spdx3List `json:"createdUsing"`
+
+	// SpdxDocument and software_Sbom
+	DataLicense string             `json:"dataLicense"`
+	RootElement spdx3List          `json:"rootElement"`
+	Imports     []spdx3ExternalMap `json:"import"`
+	SbomTypes   spdx3List          `json:"software_sbomType"`
+
+	// software_Package
+	PackageVersion   string    `json:"software_packageVersion"`
+	PackageURL       string    `json:"software_packageUrl"`
+	DownloadLocation string    `json:"software_downloadLocation"`
+	PrimaryPurpose   string    `json:"software_primaryPurpose"`
+	CopyrightText    string    `json:"software_copyrightText"`
+	SuppliedBy       spdx3List `json:"suppliedBy"`
+	OriginatedBy     spdx3List `json:"originatedBy"`
+
+	// Relationship
+	From             string    `json:"from"`
+	To               spdx3List `json:"to"`
+	RelationshipType string    `json:"relationshipType"`
+	// LifecycleScopedRelationship
+	Scope string `json:"scope"`
+
+	// simplelicensing_LicenseExpression
+	LicenseExpression string `json:"simplelicensing_licenseExpression"`
+}
+
+func (e *spdx3Element) id() string {
+	if e.SpdxID != "" {
+		return e.SpdxID
+	}
+	return e.AtID
+}
+
+func (e *spdx3Element) kind() string {
+	if e.Type != "" {
+		return e.Type
+	}
+	return e.AtType
+}
+
+type spdx3ExternalIdentifier struct {
+	Type       string `json:"externalIdentifierType"`
+	Identifier string `json:"identifier"`
+}
+
+type spdx3ExternalRef struct {
+	Type    string    `json:"externalRefType"`
+	Locator spdx3List `json:"locator"`
+}
+
+type spdx3Hash struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"hashValue"`
+}
+
+type spdx3ExternalMap struct {
+	ExternalSpdxID string `json:"externalSpdxId"`
+	LocationHint   string `json:"locationHint"`
+}
+
+// spdx3List is a list of strings or element references. JSON-LD compacts a
+// single value to a scalar and may embed an element in place of its id.
+type spdx3List []string
+
+func (l *spdx3List) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		items = []json.RawMessage{data}
+	}
+
+	for _, item := range items {
+		var s string
+		if err := json.Unmarshal(item, &s); err == nil {
+			*l = append(*l, s)
+			continue
+		}
+
+		var e spdx3Element
+		if err := json.Unmarshal(item, &e); err != nil {
+			return err
+		}
+		*l = append(*l, e.id())
+	}
+	return nil
+}
+
+// spdx3Key returns the key a package is known by in Dependencies and
+// PrimaryComp. It follows the SPDXRef- form used for SPDX 2.x, since the
+// compliance checks derive these keys from GetSpdxID for every SPDX version.
+func spdx3Key(id string) string {
+	return "SPDXRef-" + id
+}
+
+type Spdx3Doc struct {
+	graph            *spdx3Graph
+	elements         map[string]*spdx3Element
+	positions        map[*spdx3Element]int
+	document         *spdx3Element
+	creation         *spdx3Element
+	concluded        map[string]string
+	declared         map[string]string
+	format           FileFormat
+	version          FormatVersion
+	ctx              context.Context
+	SpdxSpec         *Specs
+	Comps            []GetComponent
+	Auths            []GetAuthor
+	SpdxTools        []GetTool
+	Rels             []GetRelation
+	logs             []string
+	diagnostics      []Diagnostic
+	PrimaryComponent PrimaryComp
+	Lifecycle        []string
+	Dependencies     map[string][]string
+	Vuln             []GetVulnerabilities
+	SignatureDetail  GetSignature
+	attestation      *Attestation
+	// packages with a runtime dependency, by spdx3Key
+	dependents map[string]bool
+	schema     *SchemaResult
+}
+
+func newSPDX3Doc(ctx context.Context, f io.ReadSeeker, format FileFormat, version FormatVersion, sig Signature) (Document, error) {
+	_ = logger.FromContext(ctx)
+
+	_, err := f.Seek(0, io.SeekStart)
+	if err != nil {
+		log.Printf("Failed to seek: %v", err)
+	}
+
+	if format != FileFormatJSON {
+		return nil, fmt.Errorf("unsupported spdx 3 format %s", string(format))
+	}
+
+	var g spdx3Graph
+	if err := json.NewDecoder(f).Decode(&g); err != nil {
+		return nil, err
+	}
+	if len(g.Graph) == 0 {
+		return nil, errors.New("spdx 3 document has no @graph elements")
+	}
+
+	doc := &Spdx3Doc{
+		graph:           &g,
+		format:          format,
+		ctx:             ctx,
+		version:         version,
+		SignatureDetail: &sig,
+	}
+
+	doc.parse()
+
+	return doc, nil
+}
+
+func (s Spdx3Doc) PrimaryComp() GetPrimaryComp {
+	return &s.PrimaryComponent
+}
+
+func (s Spdx3Doc) Spec() Spec {
+	return *s.SpdxSpec
+}
+
+func (s Spdx3Doc) Components() []GetComponent {
+	return s.Comps
+}
+
+func (s Spdx3Doc) Authors() []GetAuthor {
+	return s.Auths
+}
+
+func (s Spdx3Doc) Tools() []GetTool {
+	return s.SpdxTools
+}
+
+func (s Spdx3Doc) Relations() []GetRelation {
+	return s.Rels
+}
+
+func (s Spdx3Doc) Logs() []string {
+	return diagnosticLogs(s.logs, s.diagnostics)
+}
+
+func (s Spdx3Doc) Diagnostics() []Diagnostic {
+	return s.diagnostics
+}
+
+func (s Spdx3Doc) Lifecycles() []string {
+	return s.Lifecycle
+}
+
+func (s Spdx3Doc) Manufacturer() GetManufacturer {
+	return nil
+}
+
+func (s Spdx3Doc) Supplier() GetSupplier {
+	return nil
+}
+
+func (s Spdx3Doc) GetRelationships(componentID string) []string {
+	return s.Dependencies[componentID]
+}
+
+func (s Spdx3Doc) Vulnerabilities() []GetVulnerabilities {
+	return s.Vuln
+}
+
+func (s Spdx3Doc) Signature() GetSignature {
+	return s.SignatureDetail
+}
+
+func (s Spdx3Doc) ComponentTree() []*ComponentNode {
+	return componentTree(s.Comps)
+}
+
+func (s Spdx3Doc) Attestation() GetAttestation {
+	if s.attestation == nil {
+		return nil
+	}
+	return s.attestation
+}
+
+func (s *Spdx3Doc) setAttestation(att *Attestation) {
+	s.attestation = att
+}
+
+func (s Spdx3Doc) Schema() *SchemaResult {
+	return s.schema
+}
+
+func (s *Spdx3Doc) setSchema(res *SchemaResult) {
+	s.schema = res
+}
+
+func (s *Spdx3Doc) parse() {
+	s.parseDoc()
+	s.parseSpec()
+	s.parseAuthors()
+	s.parseTool()
+	s.parsePrimaryCompAndRelationships()
+	s.parseComps()
+	s.parseVulnerabilities()
+}
+
+func (s *Spdx3Doc) parseDoc() {
+	s.elements = make(map[string]*spdx3Element, len(s.graph.Graph))
+	s.positions = make(map[*spdx3Element]int, len(s.graph.Graph))
+	for i, e := range s.graph.Graph {
+		if e == nil {
+			continue
+		}
+		s.positions[e] = i
+		if id := e.id(); id != "" {
+			s.elements[id] = e
+		}
+		if s.document == nil && e.kind() == "SpdxDocument" {
+			s.document = e
+		}
+	}
+
+	if s.document == nil {
+		s.addToLogs("spdx 3 doc has no SpdxDocument element")
+	} else {
+		s.creation = s.creationInfo(s.document)
+	}
+
+	// Without a document to point at one, fall back to the first
+	// CreationInfo in the graph.
+	if s.creation == nil {
+		s.creation, _ = lo.Find(s.graph.Graph, func(e *spdx3Element) bool {
+			return e != nil && e.kind() == "CreationInfo"
+		})
+	}
+
+	for _, e := range s.ofKind("software_Sbom") {
+		s.Lifecycle = append(s.Lifecycle, e.SbomTypes...)
+	}
+	s.Lifecycle = lo.Uniq(s.Lifecycle)
+
+	if len(s.Lifecycle) == 0 && s.creation != nil && s.creation.Comment != "" {
+		s.Lifecycle = []string{s.creation.Comment}
+	}
+}
+
+func (s *Spdx3Doc) parseSpec() {
+	sp := NewSpec()
+	sp.Format = string(s.format)
+	sp.Version = string(s.version)
+	sp.SpecType = string(SBOMSpecSPDX)
+
+	if s.creation != nil {
+		if s.creation.SpecVersion != "" {
+			sp.Version = "SPDX-" + s.creation.SpecVersion
+		}
+		sp.CreationTimestamp = s.creation.Created
+		sp.Comment = s.creation.Comment
+
+		for _, ref := range s.creation.CreatedBy {
+			if a := s.elements[ref]; a != nil && a.kind() == "Organization" {
+				sp.Organization = a.Name
+			}
+		}
+	}
+
+	if s.document != nil {
+		sp.Name = s.document.Name
+		sp.Spdxid = s.document.id()
+		// SPDX 3.0 has no document namespace; the document's spdxId is a
+		// globally unique IRI and plays that part.
+		sp.URI = s.document.id()
+
+		for _, m := range s.document.Imports {
+			if m.LocationHint != "" {
+				sp.ExternalDocReference = append(sp.ExternalDocReference, m.LocationHint)
+			} else {
+				sp.ExternalDocReference = append(sp.ExternalDocReference, m.ExternalSpdxID)
+			}
+		}
+
+		if s.document.DataLicense != "" {
+			sp.Licenses = append(sp.Licenses, licenses.LookupExpression(s.licenseExpression(s.document.DataLicense), nil)...)
+		}
+	}
+
+	sp.isReqFieldsPresent = s.requiredFields()
+
+	s.SpdxSpec = sp
+}
+
+func (s *Spdx3Doc) parseAuthors() {
+	s.Auths = []GetAuthor{}
+
+	if s.creation == nil {
+		return
+	}
+
+	for _, ref := range s.creation.CreatedBy {
+		a := s.elements[ref]
+		if a == nil {
+			s.addToLogs(fmt.Sprintf("spdx 3 doc creator %s not found", ref))
+			continue
+		}
+		if a.kind() == "SoftwareAgent" {
+			continue
+		}
+
+		s.Auths = append(s.Auths, Author{
+			Name:       a.Name,
+			Email:      a.email(),
+			AuthorType: strings.ToLower(a.kind()),
+		})
+	}
+}
+
+func (s *Spdx3Doc) parseTool() {
+	s.SpdxTools = []GetTool{}
+
+	if s.creation == nil {
+		return
+	}
+
+	for _, ref := range s.creation.CreatedUsing {
+		t := s.elements[ref]
+		if t == nil {
+			s.addToLogs(fmt.Sprintf("spdx 3 doc tool %s not found", ref))
+			continue
+		}
+
+		nt := Tool{}
+		nt.Name, nt.Version = splitToolVersion(t.Name)
+		s.SpdxTools = append(s.SpdxTools, nt)
+	}
+}
+
+func (s *Spdx3Doc) parsePrimaryCompAndRelationships() {
+	s.Dependencies = make(map[string][]string)
+	s.concluded = make(map[string]string)
+	s.declared = make(map[string]string)
+	s.Rels = []GetRelation{}
+	s.dependents = make(map[string]bool)
+
+	if primary := s.primaryPackage(); primary != nil {
+		s.PrimaryComponent.Present = true
+		s.PrimaryComponent.ID = spdx3Key(primary.id())
+		s.PrimaryComponent.Name = primary.Name
+		s.PrimaryComponent.Version = primary.PackageVersion
+	}
+
+	var totalDependencies int
+
+	for _, r := range s.ofKind("Relationship", "LifecycleScopedRelationship") {
+		if r.From == "" || len(r.To) == 0 {
+			continue
+		}
+
+		s.addRelations(r)
+
+		switch {
+		case strings.EqualFold(r.RelationshipType, "hasConcludedLicense"):
+			s.concluded[r.From] = s.licenseExpression(r.To[0])
+		case strings.EqualFold(r.RelationshipType, "hasDeclaredLicense"):
+			s.declared[r.From] = s.licenseExpression(r.To[0])
+		case strings.EqualFold(r.RelationshipType, "dependsOn"), strings.EqualFold(r.RelationshipType, "contains"):
+			from := spdx3Key(r.From)
+			for _, to := range r.To {
+				s.Dependencies[from] = append(s.Dependencies[from], spdx3Key(to))
+
+				if from == s.PrimaryComponent.ID {
+					totalDependencies++
+					s.PrimaryComponent.HasDependency = true
+					s.PrimaryComponent.AllDependencies = append(s.PrimaryComponent.AllDependencies, spdx3Key(to))
+				}
+			}
+		}
+	}
+	s.PrimaryComponent.Dependecies = totalDependencies
+}
+
+// addRelations records the relations of relationship r, but not those to
+// licenses, which are read as license fields as in SPDX 2.
+func (s *Spdx3Doc) addRelations(r *spdx3Element) {
+	if strings.EqualFold(r.RelationshipType, "hasConcludedLicense") || strings.EqualFold(r.RelationshipType, "hasDeclaredLicense") {
+		return
+	}
+
+	for _, to := range r.To {
+		rel := newRelation(spdx3RelationKinds, r.RelationshipType, r.From, to)
+		if k, ok := spdx3ScopeKinds[strings.ToLower(r.Scope)]; ok && rel.Kind == RelationDependsOn {
+			rel.Kind = k
+		}
+		s.Rels = append(s.Rels, rel)
+		if rel.Kind.IsRuntimeDependency() {
+			s.dependents[spdx3Key(rel.From)] = true
+		}
+	}
+}
+
+// primaryPackage returns the package the document is about: the root
+// element of the document, looking through an Sbom root to its own root,
+// or else the target of a describes relationship.
+func (s *Spdx3Doc) primaryPackage() *spdx3Element {
+	if s.document == nil {
+		return nil
+	}
+
+	roots := append(spdx3List{}, s.document.RootElement...)
+	for _, ref := range s.document.RootElement {
+		if e := s.elements[ref]; e != nil && e.kind() == "software_Sbom" {
+			roots = append(roots, e.RootElement...)
+		}
+	}
+
+	for _, ref := range roots {
+		if e := s.elements[ref]; e != nil && e.kind() == "software_Package" {
+			return e
+		}
+	}
+
+	for _, r := range s.ofKind("Relationship") {
+		if !strings.EqualFold(r.RelationshipType, "describes") {
+			continue
+		}
+		for _, to := range r.To {
+			if e := s.elements[to]; e != nil && e.kind() == "software_Package" {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Spdx3Doc) parseComps() {
+	s.Comps = []GetComponent{}
+
+	for _, sc := range s.ofKind("software_Package") {
+		id := sc.id()
+		nc := NewComponent()
+
+		nc.Name = sc.Name
+		nc.Version = sc.PackageVersion
+		nc.purpose = sc.PrimaryPurpose
+		nc.Spdxid = id
+		nc.ID = id
+		nc.CopyRight = sc.CopyrightText
+		nc.isReqFieldsPresent = s.pkgRequiredFields(sc)
+		nc.Purls = s.purls(sc)
+		nc.Cpes = s.cpes(sc)
+		nc.Swhid = s.swhids(sc)
+		nc.OmniID = s.omniborIDs(sc)
+		nc.Swid = s.swids(sc)
+		nc.Checksums = s.checksums(sc)
+		nc.ExternalRefs = s.externalRefs(sc)
+
+		nc.PackageLicenseConcluded = s.concluded[id]
+		nc.PackageLicenseDeclared = s.declared[id]
+		nc.concludedLicense = licenses.LookupExpression(nc.PackageLicenseConcluded, nil)
+		nc.declaredLicense = licenses.LookupExpression(nc.PackageLicenseDeclared, nil)
+		nc.licenses = nc.concludedLicense
+		if len(nc.licenses) == 0 {
+			nc.licenses = nc.declaredLicense
+		}
+
+		manu := s.getManufacturer(sc)
+		if manu != nil {
+			nc.manufacturer = *manu
+		}
+
+		supp := s.getSupplier(sc)
+		if supp != nil {
+			nc.Supplier = *supp
+		}
+
+		// As with SPDX 2.x, the originator stands in for a missing supplier.
+		if supp == nil && manu != nil {
+			nc.Supplier.Name = manu.Name
+			nc.Supplier.Email = manu.Email
+		}
+
+		if strings.ToLower(sc.DownloadLocation) != "noassertion" && strings.ToLower(sc.DownloadLocation) != "none" {
+			nc.DownloadLocation = sc.DownloadLocation
+		}
+
+		for _, ref := range sc.ExternalRefs {
+			if ref.Type == "vcs" && len(ref.Locator) > 0 {
+				nc.sourceCodeURL = ref.Locator[0]
+				break
+			}
+		}
+
+		nc.isPrimary = s.PrimaryComponent.ID == spdx3Key(id)
+		if nc.isPrimary {
+			nc.PrimaryCompt = s.PrimaryComponent
+		}
+		nc.hasRelationships = s.dependents[spdx3Key(id)]
+
+		s.Comps = append(s.Comps, nc)
+	}
+
+	s.parseContainment()
+}
+
+// parseContainment arranges the packages by their contains relationships,
+// which SPDX 3 has no inverse of.
+func (s *Spdx3Doc) parseContainment() {
+	byID := make(map[string]*Component, len(s.Comps))
+	for _, c := range s.Comps {
+		if nc, ok := c.(*Component); ok {
+			byID[nc.ID] = nc
+		}
+	}
+
+	for _, r := range s.ofKind("Relationship", "LifecycleScopedRelationship") {
+		if !strings.EqualFold(r.RelationshipType, "contains") {
+			continue
+		}
+		for _, to := range r.To {
+			contain(byID[r.From], byID[to])
+		}
+	}
+}
+
+func (s *Spdx3Doc) parseVulnerabilities() {
+	for _, v := range s.ofKind("security_Vulnerability") {
+		id := v.Name
+		for _, ei := range v.ExternalIdentifiers {
+			if ei.Type == "cve" || ei.Type == "securityOther" {
+				id = ei.Identifier
+				break
+			}
+		}
+		if id != "" {
+			s.Vuln = append(s.Vuln, Vulnerability{ID: id})
+		}
+	}
+}
+
+func (s *Spdx3Doc) addToLogs(log string) {
+	s.logs = append(s.logs, log)
+}
+
+func (s *Spdx3Doc) addDiagnostic(d Diagnostic) {
+	s.diagnostics = append(s.diagnostics, d)
+}
+
+// path returns the JSON pointer to field of an element in the graph, or ""
+// for an element embedded in another.
+func (s *Spdx3Doc) path(e *spdx3Element, field string) string {
+	i, ok := s.positions[e]
+	if !ok {
+		return ""
+	}
+	if field == "" {
+		return jsonPointer("@graph", i)
+	}
+	return jsonPointer("@graph", i, field)
+}
+
+// missing records a required field missing from an element.
+func (s *Spdx3Doc) missing(e *spdx3Element, field string) {
+	d := Diagnostic{Severity: SeverityWarning, Path: s.path(e, field), Message: "missing " + field}
+	if e.kind() == "software_Package" {
+		d.ComponentID = e.id()
+	}
+	s.addDiagnostic(d)
+}
+
+// invalid records a malformed identifier of a package.
+func (s *Spdx3Doc) invalid(pkg *spdx3Element, kind, value string) {
+	s.addDiagnostic(Diagnostic{
+		Severity:    SeverityError,
+		Path:        s.path(pkg, ""),
+		ComponentID: pkg.id(),
+		Message:     fmt.Sprintf("invalid %s %q", kind, value),
+	})
+}
+
+func (s *Spdx3Doc) ofKind(kinds ...string) []*spdx3Element {
+	return lo.Filter(s.graph.Graph, func(e *spdx3Element, _ int) bool {
+		return e != nil && lo.Contains(kinds, e.kind())
+	})
+}
+
+// creationInfo resolves an element's creationInfo, which is either embedded
+// or a reference to a CreationInfo node elsewhere in the graph.
+func (s *Spdx3Doc) creationInfo(e *spdx3Element) *spdx3Element {
+	if len(e.CreationInfo) == 0 {
+		return nil
+	}
+
+	var ref string
+	if err := json.Unmarshal(e.CreationInfo, &ref); err == nil {
+		return s.elements[ref]
+	}
+
+	var ci spdx3Element
+	if err := json.Unmarshal(e.CreationInfo, &ci); err != nil {
+		s.addToLogs(fmt.Sprintf("spdx 3 doc element %s has invalid creationInfo", e.id()))
+		return nil
+	}
+	return &ci
+}
+
+// licenseExpression turns a reference to a license element, or to a listed
+// license IRI outside the graph, into an SPDX license expression.
+func (s *Spdx3Doc) licenseExpression(ref string) string {
+	e := s.elements[ref]
+	if e == nil {
+		return strings.TrimPrefix(ref, spdx3LicensePrefix)
+	}
+
+	switch e.kind() {
+	case "simplelicensing_LicenseExpression":
+		return e.LicenseExpression
+	case "expandedlicensing_ListedLicense":
+		return strings.TrimPrefix(e.id(), spdx3LicensePrefix)
+	default:
+		if e.Name != "" {
+			return e.Name
+		}
+		return e.id()
+	}
+}
+
+func (s *Spdx3Doc) requiredFields() bool {
+	if s.document == nil {
+		s.addToLogs("spdx 3 doc is missing SpdxDocument")
+		return false
+	}
+
+	if s.document.id() == "" {
+		s.missing(s.document, "spdxId")
+		return false
+	}
+
+	if s.creation == nil {
+		s.missing(s.document, "creationInfo")
+		return false
+	}
+
+	if s.creation.SpecVersion == "" {
+		s.missing(s.creation, "specVersion")
+		return false
+	}
+
+	if s.creation.Created == "" {
+		s.missing(s.creation, "created")
+		return false
+	}
+
+	if len(s.creation.CreatedBy) == 0 {
+		s.missing(s.creation, "createdBy")
+		return false
+	}
+	return true
+}
+
+func (s *Spdx3Doc) pkgRequiredFields(pkg *spdx3Element) bool {
+	if pkg.id() == "" {
+		s.missing(pkg, "spdxId")
+		return false
+	}
+
+	if pkg.Name == "" {
+		s.missing(pkg, "name")
+		return false
+	}
+	return true
+}
+
+func (s *Spdx3Doc) purls(pkg *spdx3Element) []purl.PURL {
+	urls := []purl.PURL{}
+
+	candidates := pkg.identifiers("packageUrl")
+	if pkg.PackageURL != "" {
+		candidates = append([]string{pkg.PackageURL}, candidates...)
+	}
+
+	for _, p := range lo.Uniq(candidates) {
+		prl := purl.NewPURL(p)
+		if prl.Valid() {
+			urls = append(urls, prl)
+		} else {
+			s.invalid(pkg, "purl", p)
+		}
+	}
+
+	if len(urls) == 0 {
+		s.addToLogs(fmt.Sprintf("spdx 3 doc pkg %s no purls found", pkg.Name))
+	}
+	return urls
+}
+
+func (s *Spdx3Doc) cpes(pkg *spdx3Element) []cpe.CPE {
+	urls := []cpe.CPE{}
+
+	for _, c := range append(pkg.identifiers("cpe23"), pkg.identifiers("cpe22")...) {
+		cpeV := cpe.NewCPE(c)
+		if cpeV.Valid() {
+			urls = append(urls, cpeV)
+		} else {
+			s.invalid(pkg, "cpe", c)
+		}
+	}
+
+	if len(urls) == 0 {
+		s.addToLogs(fmt.Sprintf("spdx 3 doc pkg %s no cpes found", pkg.Name))
+	}
+	return urls
+}
+
+func (s *Spdx3Doc) swhids(pkg *spdx3Element) []swhid.SWHID {
+	var ids []swhid.SWHID
+	for _, id := range pkg.identifiers("swhid") {
+		if nswhid := swhid.NewSWHID(id); nswhid.Valid() {
+			ids = append(ids, nswhid)
+		} else {
+			s.invalid(pkg, "swhid", id)
+		}
+	}
+	return ids
+}
+
+func (s *Spdx3Doc) omniborIDs(pkg *spdx3Element) []omniborid.OMNIBORID {
+	var ids []omniborid.OMNIBORID
+	for _, id := range pkg.identifiers("gitoid") {
+		if omniID := omniborid.NewOmni(id); omniID.Valid() {
+			ids = append(ids, omniID)
+		} else {
+			s.invalid(pkg, "omniborid", id)
+		}
+	}
+	return ids
+}
+
+func (s *Spdx3Doc) swids(pkg *spdx3Element) []swid.SWID {
+	var ids []swid.SWID
+	for _, id := range pkg.identifiers("swid") {
+		if nswid := swid.NewSWID(id, pkg.Name); nswid.Valid() {
+			ids = append(ids, nswid)
+		} else {
+			s.invalid(pkg, "swid", id)
+		}
+	}
+	return ids
+}
+
+func (s *Spdx3Doc) checksums(pkg *spdx3Element) []GetChecksum {
+	chks := []GetChecksum{}
+
+	for _, h := range pkg.VerifiedUsing {
+		if h.Algorithm == "" || h.Value == "" {
+			continue
+		}
+		chks = append(chks, Checksum{Alg: strings.ToUpper(h.Algorithm), Content: h.Value})
+	}
+
+	if len(chks) == 0 {
+		s.addToLogs(fmt.Sprintf("spdx 3 doc pkg %s no checksum found", pkg.Name))
+	}
+	return chks
+}
+
+// externalRefs returns the package's external identifiers and references,
+// with identifier types renamed to their SPDX 2.x equivalents.
+func (s *Spdx3Doc) externalRefs(pkg *spdx3Element) []GetExternalReference {
+	extRefs := []GetExternalReference{}
+
+	if pkg.PackageURL != "" {
+		extRefs = append(extRefs, ExternalReference{RefType: "purl", RefLocator: pkg.PackageURL})
+	}
+
+	for _, ei := range pkg.ExternalIdentifiers {
+		if ei.Type == "packageUrl" && ei.Identifier == pkg.PackageURL {
+			continue
+		}
+		refType := ei.Type
+		if t, ok := spdx3RefTypes[ei.Type]; ok {
+			refType = t
+		}
+		extRefs = append(extRefs, ExternalReference{RefType: refType, RefLocator: ei.Identifier})
+	}
+
+	for _, ref := range pkg.ExternalRefs {
+		for _, loc := range ref.Locator {
+			extRefs = append(extRefs, ExternalReference{RefType: ref.Type, RefLocator: loc})
+		}
+	}
+
+	if len(extRefs) == 0 {
+		s.addToLogs(fmt.Sprintf("spdx 3 doc pkg %s no externalReferences found", pkg.Name))
+	}
+	return extRefs
+}
+
+func (s *Spdx3Doc) getManufacturer(pkg *spdx3Element) *Manufacturer {
+	if len(pkg.OriginatedBy) == 0 {
+		return nil
+	}
+
+	a := s.elements[pkg.OriginatedBy[0]]
+	if a == nil || a.Name == "" {
+		return nil
+	}
+
+	return &Manufacturer{
+		Name:  a.Name,
+		Email: a.email(),
+	}
+}
+
+func (s *Spdx3Doc) getSupplier(pkg *spdx3Element) *Supplier {
+	if len(pkg.SuppliedBy) == 0 {
+		return nil
+	}
+
+	a := s.elements[pkg.SuppliedBy[0]]
+	if a == nil || a.Name == "" {
+		return nil
+	}
+
+	return &Supplier{
+		Name:  a.Name,
+		Email: a.email(),
+	}
+}
+
+func (e *spdx3Element) identifiers(kind string) []string {
+	var ids []string
+	for _, ei := range e.ExternalIdentifiers {
+		if ei.Type == kind {
+			ids = append(ids, ei.Identifier)
+		}
+	}
+	return ids
+}
+
+func (e *spdx3Element) email() string {
+	if ids := e.identifiers("email"); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
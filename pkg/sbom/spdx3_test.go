@@ -0,0 +1,130 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPDX3Document(t *testing.T) {
+	f, err := os.Open("../../samples/sbomqs-spdx3.spdx.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err := NewSBOMDocument(context.Background(), f, Signature{})
+	require.NoError(t, err)
+
+	spec := doc.Spec()
+	assert.Equal(t, "spdx", spec.GetSpecType())
+	assert.Equal(t, "SPDX-3.0.1", spec.GetVersion())
+	assert.Equal(t, "json", spec.FileFormat())
+	assert.Equal(t, "sbomqs", spec.GetName())
+	assert.Equal(t, "2025-06-02T10:15:00Z", spec.GetCreationTimestamp())
+	assert.Equal(t, "Interlynk", spec.GetOrganization())
+	assert.Equal(t, "https://interlynk.io/spdx/sbomqs/Document", spec.GetURI())
+	assert.True(t, spec.RequiredFields())
+	require.Len(t, spec.GetLicenses(), 1)
+	assert.Equal(t, "CC0-1.0", spec.GetLicenses()[0].ShortID())
+
+	require.Len(t, doc.Authors(), 1)
+	assert.Equal(t, "Interlynk", doc.Authors()[0].GetName())
+	assert.Equal(t, "support@interlynk.io", doc.Authors()[0].GetEmail())
+	assert.Equal(t, "organization", doc.Authors()[0].GetType())
+
+	require.Len(t, doc.Tools(), 1)
+	assert.Equal(t, "sbomasm", doc.Tools()[0].GetName())
+	assert.Equal(t, "1.0.4", doc.Tools()[0].GetVersion())
+
+	assert.Equal(t, []string{"build"}, doc.Lifecycles())
+
+	pc := doc.PrimaryComp()
+	assert.True(t, pc.IsPresent())
+	assert.Equal(t, "sbomqs", pc.GetName())
+	assert.Equal(t, []string{"SPDXRef-https://interlynk.io/spdx/sbomqs/Package/cobra"}, pc.GetDependencies())
+	assert.Len(t, doc.Relations(), 2)
+
+	comps := map[string]GetComponent{}
+	for _, c := range doc.Components() {
+		comps[c.GetName()] = c
+	}
+	require.Len(t, comps, 3)
+
+	app := comps["sbomqs"]
+	assert.True(t, app.IsPrimaryComponent())
+	assert.True(t, app.HasRelationShips())
+	assert.Equal(t, "application", app.PrimaryPurpose())
+	assert.Equal(t, "Interlynk", app.Suppliers().GetName())
+	assert.Equal(t, "https://github.com/interlynk-io/sbomqs.git", app.SourceCodeURL())
+	assert.Equal(t, "Apache-2.0", app.GetPackageLicenseConcluded())
+	require.Len(t, app.GetChecksums(), 1)
+	assert.Equal(t, "SHA256", app.GetChecksums()[0].GetAlgo())
+
+	cobra := comps["github.com/spf13/cobra"]
+	assert.False(t, cobra.IsPrimaryComponent())
+	assert.Equal(t, "Steve Francia", cobra.Suppliers().GetName())
+	assert.Equal(t, "Apache-2.0", cobra.GetPackageLicenseDeclared())
+	assert.Len(t, cobra.GetPurls(), 1)
+	assert.Len(t, cobra.GetCpes(), 1)
+	assert.Equal(t, []string{"SPDXRef-https://interlynk.io/spdx/sbomqs/Package/pflag"},
+		doc.GetRelationships("SPDXRef-"+cobra.GetSpdxID()))
+
+	pflag := comps["github.com/spf13/pflag"]
+	assert.Empty(t, pflag.GetDownloadLocationURL())
+	assert.False(t, pflag.HasRelationShips())
+}
+
+func TestSPDX3CompactedValues(t *testing.T) {
+	// The creationInfo is embedded, and rootElement and to are single
+	// values rather than lists.
+	input := `{
+  "@context": "https://spdx.org/rdf/3.0.0/spdx-context.jsonld",
+  "@graph": [
+    {
+      "type": "SpdxDocument",
+      "spdxId": "urn:doc",
+      "creationInfo": {"type": "CreationInfo", "specVersion": "3.0.0", "created": "2025-01-01T00:00:00Z", "createdBy": "urn:person"},
+      "rootElement": "urn:app"
+    },
+    {"type": "Person", "spdxId": "urn:person", "name": "Jane Doe"},
+    {"type": "software_Package", "spdxId": "urn:app", "name": "app", "software_packageVersion": "1.0"},
+    {"type": "software_Package", "spdxId": "urn:lib", "name": "lib"},
+    {"type": "Relationship", "spdxId": "urn:rel", "from": "urn:app", "relationshipType": "dependsOn", "to": "urn:lib"}
+  ]
+}`
+
+	doc, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "SPDX-3.0.0", doc.Spec().GetVersion())
+	assert.True(t, doc.Spec().RequiredFields())
+	require.Len(t, doc.Authors(), 1)
+	assert.Equal(t, "person", doc.Authors()[0].GetType())
+	assert.Equal(t, "app", doc.PrimaryComp().GetName())
+	assert.Equal(t, 1, doc.PrimaryComp().GetTotalNoOfDependencies())
+	assert.Len(t, doc.Components(), 2)
+}
+
+func TestSPDX3EmptyGraph(t *testing.T) {
+	input := `{"@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld", "@graph": []}`
+
+	_, err := NewSBOMDocument(context.Background(), strings.NewReader(input), Signature{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/samber/lo"
+)
+
+// Baseline decides which failing components are known failures of a
+// feature, left out of its score.
+type Baseline interface {
+	Suppressed(feature string, c sbom.GetComponent) bool
+}
+
+// SetBaseline leaves the known failures of b out of the component features.
+func (s *Scorer) SetBaseline(b Baseline) {
+	s.baseline = b
+}
+
+// baselineDoc is a document without the components a baseline suppressed
+// for a feature.
+type baselineDoc struct {
+	sbom.Document
+	comps []sbom.GetComponent
+}
+
+func (d baselineDoc) Components() []sbom.GetComponent {
+	return d.comps
+}
+
+// fullDoc returns the document with all of its components, for checks which
+// need the relationships between all of them, like the dependency graph.
+func fullDoc(d sbom.Document) sbom.Document {
+	if bd, ok := d.(baselineDoc); ok {
+		return bd.Document
+	}
+	return d
+}
+
+// evaluate scores c, leaving out the components the baseline suppressed for
+// a component feature which applies to the document. The checks of a
+// streamed document are all scored by the first call.
+func (s *Scorer) evaluate(c *check) score {
+	if s.stream != nil {
+		if s.streamed == nil {
+			s.scoreStream()
+		}
+		return s.streamed[streamKey(c)]
+	}
+
+	sc := c.evaluate(s.doc, c)
+	if s.baseline == nil || !strings.HasPrefix(c.Key, "comp_") || sc.Ignore() {
+		return sc
+	}
+
+	comps := s.doc.Components()
+	kept := lo.Filter(comps, func(comp sbom.GetComponent, _ int) bool {
+		return !s.baseline.Suppressed(c.Key, comp)
+	})
+
+	suppressed := len(comps) - len(kept)
+	if suppressed == 0 {
+		return sc
+	}
+
+	// every failure is known
+	if len(kept) == 0 {
+		sc.setScore(10.0)
+		sc.setDesc(fmt.Sprintf("%d/%d baselined", suppressed, len(comps)))
+		return sc
+	}
+
+	sc = c.evaluate(baselineDoc{Document: s.doc, comps: kept}, c)
+	sc.setDesc(fmt.Sprintf("%s (%d baselined)", sc.Descr(), suppressed))
+	return sc
+}
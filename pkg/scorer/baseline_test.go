@@ -0,0 +1,66 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// knownFailures suppresses the components named for every feature.
+type knownFailures map[string]bool
+
+func (k knownFailures) Suppressed(_ string, c sbom.GetComponent) bool {
+	return k[c.GetName()]
+}
+
+func TestBaseline(t *testing.T) {
+	doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "supplier": {"name": "Acme"}},
+    {"type": "library", "bom-ref": "b", "name": "b"},
+    {"type": "library", "bom-ref": "c", "name": "c"}
+  ]
+}`), sbom.Signature{})
+	require.NoError(t, err)
+
+	score := func(k knownFailures) Score {
+		sr := NewScorer(context.Background(), doc)
+		sr.AddFilter(Filter{Name: "comp_with_supplier", Ftype: Mix, Category: string(ntiam)})
+		if k != nil {
+			sr.SetBaseline(k)
+		}
+		return sr.Score().ScoreList()[0]
+	}
+
+	s := score(nil)
+	assert.InDelta(t, 10.0/3, s.Score(), 0.001)
+
+	s = score(knownFailures{"b": true})
+	assert.InDelta(t, 5.0, s.Score(), 0.001)
+	assert.Equal(t, "1/2 have supplier names (1 baselined)", s.Descr())
+
+	s = score(knownFailures{"a": true, "b": true, "c": true})
+	assert.InDelta(t, 10.0, s.Score(), 0.001)
+	assert.Equal(t, "3/3 baselined", s.Descr())
+}
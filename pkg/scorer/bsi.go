@@ -16,7 +16,6 @@ package scorer
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
@@ -75,187 +74,68 @@ func sbomWithURICheck(doc sbom.Document, c *check) score {
 }
 
 func bsiCompWithUniqIDCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	compIDs := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
-		purl := c.GetPurls()
-		cpes := c.GetCpes()
-		return len(purl) > 0 || len(cpes) > 0
+	return countComponents(d, c, "have unique ID's", func(c sbom.GetComponent) bool {
+		return len(c.GetPurls()) > 0 || len(c.GetCpes()) > 0
 	})
-
-	if totalComponents > 0 {
-		s.setScore((float64(compIDs) / float64(totalComponents)) * 10.0)
-	}
-	s.setDesc(fmt.Sprintf("%d/%d have unique ID's", compIDs, totalComponents))
-	return *s
 }
 
 // check whether provided license is compliant or non-compliant
 func compWithLicensesCompliantCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-	withLicenses := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have compliant licenses", func(c sbom.GetComponent) bool {
 		return common.AreLicensesValid(c.Licenses())
 	})
-
-	if totalComponents > 0 {
-		s.setScore((float64(withLicenses) / float64(totalComponents)) * 10.0)
-	}
-
-	s.setDesc(fmt.Sprintf("%d/%d have compliant licenses", withLicenses, totalComponents))
-
-	return *s
 }
 
 func compWithDependencyCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	withDependencies := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have dependencies", func(c sbom.GetComponent) bool {
 		return c.HasRelationShips()
 	})
-
-	if totalComponents > 0 {
-		s.setScore((float64(withDependencies) / float64(totalComponents)) * 10.0)
-	}
-
-	s.setDesc(fmt.Sprintf("%d/%d have dependencies", withDependencies, totalComponents))
-
-	return *s
 }
 
 // checks whether components have sha256 checksums
 // this is a BSI requirement
 func compWithSHA256ChecksumsCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
 	algos := []string{"SHA256", "SHA-256", "sha256", "sha-256"}
 
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	withChecksums := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have checksums", func(c sbom.GetComponent) bool {
 		return lo.ContainsBy(c.GetChecksums(), func(checksum sbom.GetChecksum) bool {
 			return lo.Contains(algos, checksum.GetAlgo())
 		})
 	})
-
-	if totalComponents > 0 {
-		s.setScore((float64(withChecksums) / float64(totalComponents)) * 10.0)
-	}
-
-	s.setDesc(fmt.Sprintf("%d/%d have checksums", withChecksums, totalComponents))
-
-	return *s
 }
 
 func compWithSourceCodeURICheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
 	if d.Spec().GetSpecType() == "spdx" {
+		s := newScoreFromCheck(c)
 		s.setScore(0.0)
 		s.setDesc("no-deterministic-field in spdx")
 		s.setIgnore(true)
 		return *s
 	}
 
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	withSourceCodeURI := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have source code URI", func(c sbom.GetComponent) bool {
 		return c.SourceCodeURL() != ""
 	})
-
-	if totalComponents > 0 {
-		s.setScore((float64(withSourceCodeURI) / float64(totalComponents)) * 10.0)
-	}
-
-	s.setDesc(fmt.Sprintf("%d/%d have source code URI", withSourceCodeURI, totalComponents))
-	return *s
 }
 
 func compWithExecutableURICheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	withExecutableURI := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have executable URI", func(c sbom.GetComponent) bool {
 		return c.GetDownloadLocationURL() != ""
 	})
-
-	if totalComponents > 0 {
-		s.setScore((float64(withExecutableURI) / float64(totalComponents)) * 10.0)
-	}
-
-	s.setDesc(fmt.Sprintf("%d/%d have executable URI", withExecutableURI, totalComponents))
-	return *s
 }
 
 func compWithSourceCodeHashCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
 	if d.Spec().GetSpecType() == "cyclonedx" {
+		s := newScoreFromCheck(c)
 		s.setScore(0.0)
 		s.setDesc("no-deterministic-field in cdx")
 		s.setIgnore(true)
 		return *s
 	}
 
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	withSourceCodeHash := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have source code hash", func(c sbom.GetComponent) bool {
 		return c.SourceCodeHash() != ""
 	})
-
-	if totalComponents > 0 {
-		s.setScore((float64(withSourceCodeHash) / float64(totalComponents)) * 10.0)
-	}
-
-	s.setDesc(fmt.Sprintf("%d/%d have source code hash", withSourceCodeHash, totalComponents))
-	return *s
 }
 
 func sbomWithBomLinksCheck(doc sbom.Document, c *check) score {
@@ -337,15 +217,14 @@ func sbomWithSignatureCheck(doc sbom.Document, c *check) score {
 		blob := doc.Signature().GetBlob()
 		sig := doc.Signature().GetSigValue()
 
-		pubKeyData, err := os.ReadFile(pubKey)
-		if err != nil {
+		if pubKey == "" || sig == "" {
 			s.setScore(0.0)
 			s.setDesc("No signature or public key provided!")
 			// s.setIgnore(true)
 			return *s
 		}
 
-		valid, err := common.VerifySignature(pubKeyData, blob, sig)
+		valid, err := common.VerifySignature([]byte(pubKey), []byte(blob), []byte(sig))
 		if err != nil {
 			s.setScore(0.0)
 			s.setDesc("Signature verification failed!")
@@ -358,9 +237,6 @@ func sbomWithSignatureCheck(doc sbom.Document, c *check) score {
 			s.setScore(5.0)
 			s.setDesc("Signature provided but verification failed!")
 		}
-		common.RemoveFileIfExists("extracted_public_key.pem")
-		common.RemoveFileIfExists("extracted_signature.bin")
-		common.RemoveFileIfExists("standalone_sbom.json")
 	} else {
 		s.setScore(0.0)
 		s.setDesc("No signature provided")
@@ -373,85 +249,31 @@ func sbomWithSignatureCheck(doc sbom.Document, c *check) score {
 // compWithAssociatedLicensesCheck checks whether components have associated licenses
 // this is a BSI requirement
 func compWithAssociatedLicensesCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	var withLicenses int
 	spec := d.Spec().GetSpecType()
-	if spec == "spdx" {
-		withLicenses = lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+
+	return countComponents(d, c, "have compliant licenses", func(c sbom.GetComponent) bool {
+		switch spec {
+		case "spdx":
 			return common.AreLicensesValid(c.ConcludedLicenses())
-		})
-	} else if spec == "cyclonedx" {
-		withLicenses = lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+		case "cyclonedx":
 			return common.AreLicensesValid(c.Licenses())
-		})
-	}
-
-	if totalComponents > 0 {
-		s.setScore((float64(withLicenses) / float64(totalComponents)) * 10.0)
-	}
-
-	s.setDesc(fmt.Sprintf("%d/%d have compliant licenses", withLicenses, totalComponents))
-
-	return *s
+		}
+		return false
+	})
 }
 
 // compWithConcludedLicensesCheck checks whether components have concluded licenses
 // this is a BSI requirement
 func compWithConcludedLicensesCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	withLicenses := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have compliant licenses", func(c sbom.GetComponent) bool {
 		return common.AreLicensesValid(c.ConcludedLicenses())
 	})
-
-	if totalComponents > 0 {
-		s.setScore((float64(withLicenses) / float64(totalComponents)) * 10.0)
-	}
-
-	s.setDesc(fmt.Sprintf("%d/%d have compliant licenses", withLicenses, totalComponents))
-
-	return *s
 }
 
 // compWithDeclaredLicensesCheck checks whether components have declared licenses
 // this is a BSI requirement
 func compWithDeclaredLicensesCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	withLicenses := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have compliant licenses", func(c sbom.GetComponent) bool {
 		return common.AreLicensesValid(c.DeclaredLicenses())
 	})
-
-	if totalComponents > 0 {
-		s.setScore((float64(withLicenses) / float64(totalComponents)) * 10.0)
-	}
-
-	s.setDesc(fmt.Sprintf("%d/%d have compliant licenses", withLicenses, totalComponents))
-
-	return *s
 }
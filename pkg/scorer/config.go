@@ -15,10 +15,12 @@
 package scorer
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"time"
 
+	"github.com/samber/lo"
 	"gopkg.in/yaml.v2"
 )
 
@@ -29,18 +31,38 @@ type Config struct {
 		LastUpdated string `yaml:"last_updated"`
 	} `yaml:"metadata"`
 
-	Categories []*Cat `yaml:"categories"`
+	MinScore   float64 `yaml:"min_score,omitempty"`
+	Categories []*Cat  `yaml:"categories"`
 }
 
 type Cat struct {
 	Name        string     `yaml:"name"`
 	Description string     `yaml:"description"`
+	Weight      *float64   `yaml:"weight,omitempty"`
+	MinScore    float64    `yaml:"min_score,omitempty"`
 	Features    []Features `yaml:"features"`
 }
 type Features struct {
-	Name        string `yaml:"name"`
-	Disabled    bool   `yaml:"ignore"`
-	Description string `yaml:"description"`
+	Name        string   `yaml:"name"`
+	Disabled    bool     `yaml:"ignore"`
+	Weight      *float64 `yaml:"weight,omitempty"`
+	MinScore    float64  `yaml:"min_score,omitempty"`
+	Description string   `yaml:"description"`
+	Custom      *Custom  `yaml:"custom,omitempty"`
+}
+
+// DefaultWeight is the weight applied to a category or feature when the
+// config file doesn't specify one. An explicit weight of 0 is kept, so the
+// feature or category is scored but doesn't count towards the average.
+const DefaultWeight = 1.0
+
+// configWeight returns the weight set in the config file, or DefaultWeight
+// when it is missing.
+func configWeight(w *float64) float64 {
+	if w == nil {
+		return DefaultWeight
+	}
+	return *w
 }
 
 // Category descriptions
@@ -60,6 +82,7 @@ var featureDescriptions = map[string]string{
 	"sbom_spec_version":              "SBOM specification version",
 	"sbom_file_format":               "SBOM file format",
 	"sbom_parsable":                  "SBOM is machine-parsable",
+	"sbom_schema_valid":              "SBOM validates against the JSON schema of its spec version",
 	"comp_with_supplier":             "components have supplier",
 	"comp_with_name":                 "components have a name",
 	"comp_with_version":              "components have a version",
@@ -93,6 +116,29 @@ var featureDescriptions = map[string]string{
 	"sbom_with_vuln":                 "SBOM has vulnerability information",
 	"sbom_build_process":             "SBOM has build process information",
 	"sbom_with_signature":            "SBOM has a digital signature",
+
+	// dependency graph
+	"comp_with_dangling_dependencies": "components have dependencies that aren't in the SBOM",
+	"comp_reachable":                  "components are reachable from the primary component",
+	"comp_in_dependency_cycle":        "components are part of a dependency cycle",
+	"sbom_dependency_depth":           "SBOM records transitive dependencies of the primary component",
+	"comp_with_described_parts":       "the parts of assembled components have a name, a version and a lookup id",
+	"comp_with_complete_dependencies": "components declare their dependencies complete in a composition, weighted by aggregate",
+	"comp_dependency_completeness":    "components in the dependency tree of the primary component declare their dependencies complete, weighted by aggregate",
+}
+
+// FeatureDescription describes a built-in feature, it is empty for features
+// sbomqs doesn't ship.
+func FeatureDescription(feature string) string {
+	if d, ok := featureDescriptions[feature]; ok {
+		return d
+	}
+	for _, c := range checks {
+		if c.Key == feature {
+			return c.Descr
+		}
+	}
+	return ""
 }
 
 func DefaultConfig() string {
@@ -117,6 +163,7 @@ func DefaultConfig() string {
 			cat = &Cat{
 				Name:        catKey,
 				Description: categoryDescriptions[catKey],
+				Weight:      lo.ToPtr(DefaultWeight),
 			}
 			if cat.Description == "" {
 				cat.Description = "Features for " + catKey
@@ -128,6 +175,7 @@ func DefaultConfig() string {
 		feature := Features{
 			Name:        c.Key,
 			Disabled:    c.Ignore,
+			Weight:      lo.ToPtr(DefaultWeight),
 			Description: featureDescriptions[c.Key],
 		}
 		if feature.Description == "" {
@@ -144,7 +192,7 @@ func DefaultConfig() string {
 	return string(d)
 }
 
-func ReadConfigFile(path string) ([]Filter, error) {
+func readConfig(path string) (*Config, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -157,24 +205,114 @@ func ReadConfigFile(path string) ([]Filter, error) {
 		return nil, err
 	}
 
+	return &cfg, nil
+}
+
+func ReadConfigFile(path string) ([]Filter, error) {
+	cfg, err := readConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
 	filters := []Filter{}
 	for _, cat := range cfg.Categories {
 		if cat == nil || len(cat.Features) == 0 {
 			continue
 		}
 
+		catWeight := configWeight(cat.Weight)
+		if catWeight < 0 {
+			return nil, fmt.Errorf("category %s has a negative weight %v", cat.Name, catWeight)
+		}
+
 		for _, f := range cat.Features {
 			if f.Disabled {
 				continue
 			}
 
+			weight := configWeight(f.Weight)
+			if weight < 0 {
+				return nil, fmt.Errorf("feature %s in category %s has a negative weight %v", f.Name, cat.Name, weight)
+			}
+
 			filter := Filter{
 				Name:     f.Name,
 				Ftype:    Mix,
 				Category: cat.Name,
+				Weight:   lo.ToPtr(weight),
+
+				CategoryWeight: lo.ToPtr(catWeight),
+			}
+
+			if f.Custom != nil {
+				if isBuiltinCheck(cat.Name, f.Name) {
+					return nil, fmt.Errorf("custom feature %s in category %s clashes with a built-in feature", f.Name, cat.Name)
+				}
+
+				custom, err := newCustomCheck(cat.Name, f.Name, f.Description, f.Custom)
+				if err != nil {
+					return nil, err
+				}
+				filter.custom = custom
 			}
 			filters = append(filters, filter)
 		}
 	}
 	return filters, nil
 }
+
+// ReadThresholdsFile returns the minimum scores set with min_score in the
+// config file. Minimums on ignored features are dropped.
+func ReadThresholdsFile(path string) (Thresholds, error) {
+	t := Thresholds{}
+
+	cfg, err := readConfig(path)
+	if err != nil {
+		return t, err
+	}
+
+	if cfg.MinScore < 0 || cfg.MinScore > 10 {
+		return t, fmt.Errorf("min_score %v is out of range 0-10", cfg.MinScore)
+	}
+	t.Overall = cfg.MinScore
+
+	for _, cat := range cfg.Categories {
+		if cat == nil {
+			continue
+		}
+
+		if cat.MinScore < 0 || cat.MinScore > 10 {
+			return t, fmt.Errorf("category %s has min_score %v out of range 0-10", cat.Name, cat.MinScore)
+		}
+		if cat.MinScore > 0 {
+			if t.Categories == nil {
+				t.Categories = make(map[string]float64)
+			}
+			t.Categories[cat.Name] = cat.MinScore
+		}
+
+		for _, f := range cat.Features {
+			if f.MinScore < 0 || f.MinScore > 10 {
+				return t, fmt.Errorf("feature %s in category %s has min_score %v out of range 0-10", f.Name, cat.Name, f.MinScore)
+			}
+			if f.Disabled || f.MinScore == 0 {
+				continue
+			}
+			if t.Features == nil {
+				t.Features = make(map[string]float64)
+			}
+			t.Features[cat.Name+"/"+f.Name] = f.MinScore
+		}
+	}
+
+	return t, nil
+}
+
+func isBuiltinCheck(category, feature string) bool {
+	for _, c := range checks {
+		if c.Category == category && c.Key == feature {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "features.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestReadConfigFileWeights(t *testing.T) {
+	path := writeConfig(t, `
+categories:
+- name: NTIA-minimum-elements
+  weight: 2
+  features:
+  - name: comp_with_supplier
+    weight: 3
+  - name: comp_with_name
+  - name: comp_with_version
+    ignore: true
+- name: Structural
+  features:
+  - name: sbom_file_format
+    weight: 0.5
+`)
+
+	filters, err := ReadConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, filters, 3)
+
+	assert.Equal(t, Filter{Name: "comp_with_supplier", Ftype: Mix, Category: "NTIA-minimum-elements", Weight: lo.ToPtr(3.0), CategoryWeight: lo.ToPtr(2.0)}, filters[0])
+	assert.Equal(t, Filter{Name: "comp_with_name", Ftype: Mix, Category: "NTIA-minimum-elements", Weight: lo.ToPtr(DefaultWeight), CategoryWeight: lo.ToPtr(2.0)}, filters[1])
+	assert.Equal(t, Filter{Name: "sbom_file_format", Ftype: Mix, Category: "Structural", Weight: lo.ToPtr(0.5), CategoryWeight: lo.ToPtr(DefaultWeight)}, filters[2])
+}
+
+func TestReadConfigFileZeroWeight(t *testing.T) {
+	path := writeConfig(t, `
+categories:
+- name: Structural
+  features:
+  - name: sbom_file_format
+    weight: 0
+  - name: sbom_spec
+- name: Sharing
+  weight: 0
+  features:
+  - name: sbom_sharable
+`)
+
+	filters, err := ReadConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, filters, 3)
+
+	assert.InDelta(t, 0.0, *filters[0].Weight, 1e-9)
+	assert.InDelta(t, DefaultWeight, *filters[1].Weight, 1e-9)
+	assert.InDelta(t, 0.0, *filters[2].CategoryWeight, 1e-9)
+}
+
+func TestZeroWeightAvgScore(t *testing.T) {
+	s := newScores()
+	s.catWeights["Sharing"] = 0
+	s.addScore(score{category: "Structural", score: 8.0, weight: 1})
+	s.addScore(score{category: "Structural", score: 2.0, weight: 0})
+	s.addScore(score{category: "Sharing", score: 0.0, weight: 1})
+
+	assert.InDelta(t, 8.0, s.AvgScore(), 1e-9)
+
+	s = newScores()
+	s.catWeights["Sharing"] = 0
+	s.addScore(score{category: "Sharing", score: 5.0, weight: 1})
+	assert.InDelta(t, 0.0, s.AvgScore(), 1e-9)
+}
+
+func TestReadConfigFileNegativeWeight(t *testing.T) {
+	path := writeConfig(t, `
+categories:
+- name: Structural
+  features:
+  - name: sbom_file_format
+    weight: -1
+`)
+
+	_, err := ReadConfigFile(path)
+	assert.Error(t, err)
+
+	path = writeConfig(t, `
+categories:
+- name: Structural
+  weight: -2
+  features:
+  - name: sbom_file_format
+`)
+
+	_, err = ReadConfigFile(path)
+	assert.Error(t, err)
+}
+
+func TestWeightedAvgScore(t *testing.T) {
+	s := newScores()
+	s.addScore(score{feature: "a", score: 10.0, weight: 3})
+	s.addScore(score{feature: "b", score: 0.0, weight: 1})
+	s.addScore(score{feature: "c", score: 5.0, weight: 0.5, ignore: true})
+
+	assert.InDelta(t, 30.0/4.5, s.AvgScore(), 1e-9)
+}
+
+func TestCategoryAvgScore(t *testing.T) {
+	s := newScores()
+	s.catWeights["Sharing"] = 3
+
+	// a large category must not swamp a small one
+	for i := 0; i < 20; i++ {
+		s.addScore(score{category: "bsi-v2.0", score: 0.0, weight: 1})
+	}
+	s.addScore(score{category: "Sharing", score: 10.0, weight: 1})
+
+	cats := s.CategoryScores()
+	require.Len(t, cats, 2)
+	assert.Equal(t, "bsi-v2.0", cats[0].Category())
+	assert.Equal(t, 20, cats[0].Count())
+	assert.InDelta(t, 0.0, cats[0].Score(), 1e-9)
+	assert.Equal(t, "Sharing", cats[1].Category())
+	assert.InDelta(t, 3.0, cats[1].Weight(), 1e-9)
+	assert.InDelta(t, 10.0, cats[1].Score(), 1e-9)
+
+	assert.InDelta(t, 30.0/4.0, s.AvgScore(), 1e-9)
+}
+
+func TestReadConfigFileCustom(t *testing.T) {
+	path := writeConfig(t, `
+categories:
+- name: Policy
+  features:
+  - name: npm_with_sha512
+    weight: 2
+    custom:
+      filter: '"npm" in purl_types'
+      predicate: 'has_checksum("SHA-512")'
+  - name: recent_cdx
+    custom:
+      scope: document
+      predicate: 'spec_version >= "1.5"'
+`)
+
+	filters, err := ReadConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, filters, 2)
+	require.NotNil(t, filters[0].custom)
+	assert.Equal(t, "Policy", filters[0].custom.Category)
+	assert.Equal(t, "npm_with_sha512", filters[0].custom.Key)
+	assert.InDelta(t, 2.0, *filters[0].Weight, 1e-9)
+	require.NotNil(t, filters[1].custom)
+}
+
+func TestReadConfigFileCustomInvalid(t *testing.T) {
+	for name, feature := range map[string]string{
+		"syntax error": `
+  - name: broken
+    custom:
+      predicate: 'name =='`,
+		"unknown scope": `
+  - name: broken
+    custom:
+      scope: file
+      predicate: 'name != ""'`,
+		"unknown aggregate": `
+  - name: broken
+    custom:
+      aggregate: most
+      predicate: 'name != ""'`,
+		"document filter": `
+  - name: broken
+    custom:
+      scope: document
+      filter: 'true'
+      predicate: 'name != ""'`,
+		"missing predicate": `
+  - name: broken
+    custom:
+      filter: 'true'`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := writeConfig(t, "categories:\n- name: Policy\n  features:"+feature+"\n")
+			_, err := ReadConfigFile(path)
+			assert.Error(t, err)
+		})
+	}
+
+	path := writeConfig(t, `
+categories:
+- name: Structural
+  features:
+  - name: sbom_file_format
+    custom:
+      scope: document
+      predicate: 'true'
+`)
+	_, err := ReadConfigFile(path)
+	assert.ErrorContains(t, err, "built-in")
+}
@@ -33,6 +33,8 @@ const (
 type check struct {
 	Category string `yaml:"category"`
 	Key      string `yaml:"feature"`
+	// Ignore marks an opt-in check, only scored when it is selected with a
+	// feature filter or enabled in a config file.
 	Ignore   bool   `yaml:"disabled"`
 	Descr    string `yaml:"descrption"`
 	evaluate func(sbom.Document, *check) score
@@ -88,10 +90,17 @@ var checks = []check{
 	{string(bsiv2_0), "sbom_with_vuln", false, "SBOM has vulnerability", sbomWithVulnCheck},
 	{string(bsiv2_0), "sbom_with_signature", false, "sbom has signature", sbomWithSignatureCheck},
 
-	// semantic
+	// semantic, the dependency graph, assembly and composition checks are opt-in
 	{string(semantic), "sbom_required_fields", false, "sbom has all required fields", sbomWithRequiredFieldCheck},
 	{string(semantic), "comp_with_licenses", false, "components have licenses", compWithLicensesCheck},
 	{string(semantic), "comp_with_checksums", false, "components have checksums", compWithChecksumsCheck},
+	{string(semantic), "comp_with_dangling_dependencies", true, "components with dependencies missing from the sbom", compWithDanglingDependenciesCheck},
+	{string(semantic), "comp_reachable", true, "components reachable from the primary component", compReachableCheck},
+	{string(semantic), "comp_in_dependency_cycle", true, "components in a dependency cycle", compInDependencyCycleCheck},
+	{string(semantic), "sbom_dependency_depth", true, "sbom has transitive dependencies", sbomDependencyDepthCheck},
+	{string(semantic), "comp_with_described_parts", true, "assemblies have described parts", compWithDescribedPartsCheck},
+	{string(semantic), "comp_with_complete_dependencies", true, "components with complete dependencies", compWithCompleteDependenciesCheck},
+	{string(semantic), "comp_dependency_completeness", true, "dependency tree declared complete", compDependencyCompletenessCheck},
 
 	// quality
 	{string(quality), "comp_valid_licenses", false, "components with valid licenses", compWithValidLicensesCheck},
@@ -111,4 +120,5 @@ var checks = []check{
 	{string(structural), "sbom_spec_version", false, "Spec Version", specVersionCheck},
 	{string(structural), "sbom_file_format", false, "SBOM File Format", sbomFileFormatCheck},
 	{string(structural), "sbom_parsable", false, "Spec is parsable", specParsableCheck},
+	{string(structural), schemaCheckKey, false, "Spec is valid against its schema", specSchemaCheck},
 }
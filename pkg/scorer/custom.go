@@ -0,0 +1,184 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/scorer/expr"
+)
+
+const (
+	scopeComponent = "component"
+	scopeDocument  = "document"
+
+	aggregateRatio = "ratio"
+	aggregateAll   = "all"
+	aggregateAny   = "any"
+)
+
+// Custom describes a feature which is defined in the config file instead of in Go.
+//
+// Component scoped checks evaluate Predicate for every component that passes
+// Filter, and turn the matches into a score according to Aggregate. Document
+// scoped checks evaluate Predicate once and score either 0 or 10.
+type Custom struct {
+	Scope     string `yaml:"scope,omitempty"`
+	Filter    string `yaml:"filter,omitempty"`
+	Predicate string `yaml:"predicate"`
+	Aggregate string `yaml:"aggregate,omitempty"`
+}
+
+func newCustomCheck(category, name, descr string, c *Custom) (*check, error) {
+	scope := strings.ToLower(strings.TrimSpace(c.Scope))
+	if scope == "" {
+		scope = scopeComponent
+	}
+
+	aggregate := strings.ToLower(strings.TrimSpace(c.Aggregate))
+	if aggregate == "" {
+		aggregate = aggregateRatio
+	}
+
+	if strings.TrimSpace(c.Predicate) == "" {
+		return nil, fmt.Errorf("custom feature %s has no predicate", name)
+	}
+
+	var evaluate func(sbom.Document, *check) score
+
+	switch scope {
+	case scopeComponent:
+		if aggregate != aggregateRatio && aggregate != aggregateAll && aggregate != aggregateAny {
+			return nil, fmt.Errorf("custom feature %s has unknown aggregate %q, expected ratio, all or any", name, c.Aggregate)
+		}
+
+		predicate, err := expr.Compile(c.Predicate, expr.ScopeComponent)
+		if err != nil {
+			return nil, fmt.Errorf("custom feature %s predicate: %w", name, err)
+		}
+
+		var filter *expr.Expr
+		if strings.TrimSpace(c.Filter) != "" {
+			filter, err = expr.Compile(c.Filter, expr.ScopeComponent)
+			if err != nil {
+				return nil, fmt.Errorf("custom feature %s filter: %w", name, err)
+			}
+		}
+
+		evaluate = func(d sbom.Document, ch *check) score {
+			return customComponentCheck(d, ch, filter, predicate, aggregate)
+		}
+
+	case scopeDocument:
+		if c.Filter != "" {
+			return nil, fmt.Errorf("custom feature %s: filter is only supported for component checks", name)
+		}
+
+		predicate, err := expr.Compile(c.Predicate, expr.ScopeDocument)
+		if err != nil {
+			return nil, fmt.Errorf("custom feature %s predicate: %w", name, err)
+		}
+
+		evaluate = func(d sbom.Document, ch *check) score {
+			return customDocumentCheck(d, ch, predicate)
+		}
+
+	default:
+		return nil, fmt.Errorf("custom feature %s has unknown scope %q, expected component or document", name, c.Scope)
+	}
+
+	return &check{
+		Category: category,
+		Key:      name,
+		Descr:    descr,
+		evaluate: evaluate,
+	}, nil
+}
+
+func customComponentCheck(d sbom.Document, c *check, filter, predicate *expr.Expr, aggregate string) score {
+	s := newScoreFromCheck(c)
+
+	considered, matched := 0, 0
+	for _, comp := range d.Components() {
+		env := expr.Env{Doc: d, Comp: comp}
+
+		if filter != nil {
+			ok, err := filter.Eval(env)
+			if err != nil {
+				s.setDesc(fmt.Sprintf("filter failed for component %s: %s", comp.GetName(), err))
+				return *s
+			}
+			if !ok {
+				continue
+			}
+		}
+		considered++
+
+		ok, err := predicate.Eval(env)
+		if err != nil {
+			s.setDesc(fmt.Sprintf("predicate failed for component %s: %s", comp.GetName(), err))
+			return *s
+		}
+		if ok {
+			matched++
+		}
+	}
+
+	if considered == 0 {
+		s.setScore(0.0)
+		s.setDesc("N/A (no components)")
+		s.setIgnore(true)
+		return *s
+	}
+
+	switch aggregate {
+	case aggregateAll:
+		if matched == considered {
+			s.setScore(10.0)
+		}
+		s.setDesc(fmt.Sprintf("%d/%d components match, all required", matched, considered))
+	case aggregateAny:
+		if matched > 0 {
+			s.setScore(10.0)
+		}
+		s.setDesc(fmt.Sprintf("%d/%d components match, at least one required", matched, considered))
+	default:
+		s.setScore((float64(matched) / float64(considered)) * 10.0)
+		s.setDesc(fmt.Sprintf("%d/%d components match", matched, considered))
+	}
+
+	return *s
+}
+
+func customDocumentCheck(d sbom.Document, c *check, predicate *expr.Expr) score {
+	s := newScoreFromCheck(c)
+
+	ok, err := predicate.Eval(expr.Env{Doc: d})
+	if err != nil {
+		s.setDesc(fmt.Sprintf("predicate failed: %s", err))
+		return *s
+	}
+
+	if ok {
+		s.setScore(10.0)
+		s.setDesc("doc matches")
+	} else {
+		s.setDesc("doc doesn't match")
+	}
+
+	return *s
+}
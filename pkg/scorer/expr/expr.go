@@ -0,0 +1,522 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expr implements the small expression language used by custom
+// checks in the scoring config file.
+//
+// An expression is evaluated either against a single component or against
+// the document as a whole, and combines fields, literals and function calls
+// with the operators ==, !=, <, <=, >, >=, in, &&, || and !. For example:
+//
+//	"npm" in purl_types && has_checksum("SHA-512")
+//	len(doc.authors) > 0 && doc.spec == "cyclonedx"
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+)
+
+// Scope decides what bare identifiers in an expression refer to.
+type Scope int
+
+const (
+	// ScopeComponent evaluates an expression once per component. Bare
+	// identifiers are component fields, doc.<field> are document fields.
+	ScopeComponent Scope = iota
+	// ScopeDocument evaluates an expression once per document. Bare
+	// identifiers and doc.<field> both refer to document fields.
+	ScopeDocument
+)
+
+// Env is the data an expression is evaluated against. Comp is only
+// required for expressions compiled with ScopeComponent.
+type Env struct {
+	Doc  sbom.Document
+	Comp sbom.GetComponent
+}
+
+// Expr is a compiled expression.
+type Expr struct {
+	src  string
+	root node
+}
+
+// value is one of string, float64, bool or []string.
+type value interface{}
+
+type node interface {
+	eval(env Env) (value, error)
+}
+
+// Compile parses src and resolves every identifier and function against scope.
+func Compile(src string, scope Scope) (*Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, scope: scope}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("position %d: unexpected %q", t.pos, t.text)
+	}
+
+	return &Expr{src: src, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Eval evaluates the expression, which must produce a boolean.
+func (e *Expr) Eval(env Env) (bool, error) {
+	v, err := e.root.eval(env)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q is not a boolean, got %s", e.src, typeName(v))
+	}
+	return b, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	scope  Scope
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	t := p.next()
+	if t.kind != kind {
+		if t.kind == tokEOF {
+			return fmt.Errorf("expected %q at end of expression", text)
+		}
+		return fmt.Errorf("position %d: expected %q, got %q", t.pos, text, t.text)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for t := p.peek(); t.kind == tokOp && t.text == "||"; t = p.peek() {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for t := p.peek(); t.kind == tokOp && t.text == "&&"; t = p.peek() {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if t := p.peek(); t.kind == tokOp && t.text == "!" {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	if t.kind != tokOp {
+		return left, nil
+	}
+
+	switch t.text {
+	case "==", "!=", "<", "<=", ">", ">=", "in":
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return &compareNode{op: t.text, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokString:
+		return &literalNode{v: t.text}, nil
+
+	case tokNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("position %d: invalid number %q", t.pos, t.text)
+		}
+		return &literalNode{v: f}, nil
+
+	case tokLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return n, nil
+
+	case tokLBracket:
+		return p.parseList(t)
+
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{v: true}, nil
+		case "false":
+			return &literalNode{v: false}, nil
+		}
+
+		if p.peek().kind == tokLParen {
+			return p.parseCall(t)
+		}
+		return p.resolveField(t)
+
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	return nil, fmt.Errorf("position %d: unexpected %q", t.pos, t.text)
+}
+
+func (p *parser) parseList(start token) (node, error) {
+	var items []string
+
+	for p.peek().kind != tokRBracket {
+		t := p.next()
+		if t.kind != tokString {
+			return nil, fmt.Errorf("position %d: list literals may only contain strings", start.pos)
+		}
+		items = append(items, t.text)
+
+		if p.peek().kind == tokComma {
+			p.next()
+			continue
+		}
+		if p.peek().kind != tokRBracket {
+			return nil, fmt.Errorf("position %d: expected \",\" or \"]\" in list", p.peek().pos)
+		}
+	}
+	p.next()
+
+	return &literalNode{v: items}, nil
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, fmt.Errorf("position %d: unknown function %q", name.pos, name.text)
+	}
+	if fn.component && p.scope != ScopeComponent {
+		return nil, fmt.Errorf("position %d: function %q is only available for component checks", name.pos, name.text)
+	}
+
+	p.next() // (
+	var args []node
+	for p.peek().kind != tokRParen {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		if p.peek().kind == tokComma {
+			p.next()
+			continue
+		}
+		if p.peek().kind != tokRParen {
+			break
+		}
+	}
+	if err := p.expect(tokRParen, ")"); err != nil {
+		return nil, err
+	}
+
+	if len(args) != fn.args {
+		return nil, fmt.Errorf("position %d: function %q takes %d argument(s), got %d", name.pos, name.text, fn.args, len(args))
+	}
+
+	if fn.validate != nil {
+		if err := fn.validate(args); err != nil {
+			return nil, fmt.Errorf("position %d: %s(): %w", name.pos, name.text, err)
+		}
+	}
+
+	return &callNode{name: name.text, fn: fn, args: args}, nil
+}
+
+func (p *parser) resolveField(t token) (node, error) {
+	name := t.text
+
+	if strings.HasPrefix(name, "doc.") || p.scope == ScopeDocument {
+		f, ok := documentFields[strings.TrimPrefix(name, "doc.")]
+		if !ok {
+			return nil, fmt.Errorf("position %d: unknown document field %q", t.pos, name)
+		}
+		return &fieldNode{name: name, get: func(env Env) value { return f(env.Doc) }}, nil
+	}
+
+	f, ok := componentFields[name]
+	if !ok {
+		return nil, fmt.Errorf("position %d: unknown component field %q", t.pos, name)
+	}
+	return &fieldNode{name: name, get: func(env Env) value { return f(env.Doc, env.Comp) }}, nil
+}
+
+type literalNode struct {
+	v value
+}
+
+func (n *literalNode) eval(_ Env) (value, error) {
+	return n.v, nil
+}
+
+type fieldNode struct {
+	name string
+	get  func(Env) value
+}
+
+func (n *fieldNode) eval(env Env) (value, error) {
+	return n.get(env), nil
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) eval(env Env) (value, error) {
+	b, err := evalBool(n.operand, env, "!")
+	if err != nil {
+		return nil, err
+	}
+	return !b, nil
+}
+
+type logicalNode struct {
+	op          string
+	left, right node
+}
+
+func (n *logicalNode) eval(env Env) (value, error) {
+	l, err := evalBool(n.left, env, n.op)
+	if err != nil {
+		return nil, err
+	}
+
+	// short circuit
+	if n.op == "&&" && !l {
+		return false, nil
+	}
+	if n.op == "||" && l {
+		return true, nil
+	}
+
+	return evalBool(n.right, env, n.op)
+}
+
+func evalBool(n node, env Env, op string) (bool, error) {
+	v, err := n.eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("operator %s expects a boolean, got %s", op, typeName(v))
+	}
+	return b, nil
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n *compareNode) eval(env Env) (value, error) {
+	l, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "in" {
+		return in(l, r)
+	}
+
+	switch lv := l.(type) {
+	case string:
+		if rv, ok := r.(string); ok {
+			return compareOrdered(n.op, strings.Compare(lv, rv))
+		}
+	case float64:
+		if rv, ok := r.(float64); ok {
+			switch {
+			case lv < rv:
+				return compareOrdered(n.op, -1)
+			case lv > rv:
+				return compareOrdered(n.op, 1)
+			default:
+				return compareOrdered(n.op, 0)
+			}
+		}
+	case bool:
+		if rv, ok := r.(bool); ok {
+			switch n.op {
+			case "==":
+				return lv == rv, nil
+			case "!=":
+				return lv != rv, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("cannot compare %s %s %s", typeName(l), n.op, typeName(r))
+}
+
+func compareOrdered(op string, c int) (value, error) {
+	switch op {
+	case "==":
+		return c == 0, nil
+	case "!=":
+		return c != 0, nil
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	case ">=":
+		return c >= 0, nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", op)
+}
+
+func in(l, r value) (value, error) {
+	needle, ok := l.(string)
+	if !ok {
+		return nil, fmt.Errorf("operator in expects a string on the left, got %s", typeName(l))
+	}
+
+	switch haystack := r.(type) {
+	case []string:
+		for _, s := range haystack {
+			if s == needle {
+				return true, nil
+			}
+		}
+		return false, nil
+	case string:
+		return strings.Contains(haystack, needle), nil
+	}
+
+	return nil, fmt.Errorf("operator in expects a list or string on the right, got %s", typeName(r))
+}
+
+type callNode struct {
+	name string
+	fn   function
+	args []node
+}
+
+func (n *callNode) eval(env Env) (value, error) {
+	args := make([]value, 0, len(n.args))
+	for _, a := range n.args {
+		v, err := a.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+
+	v, err := n.fn.call(env, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s(): %w", n.name, err)
+	}
+	return v, nil
+}
+
+func typeName(v value) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []string:
+		return "list"
+	case nil:
+		return "nothing"
+	}
+	return fmt.Sprintf("%T", v)
+}
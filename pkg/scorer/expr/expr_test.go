@@ -0,0 +1,144 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCdx = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {
+    "component": {"bom-ref": "app", "type": "application", "name": "app", "version": "1.0.0"}
+  },
+  "components": [
+    {
+      "bom-ref": "pkg:npm/left-pad@1.3.0",
+      "type": "library",
+      "name": "left-pad",
+      "version": "1.3.0",
+      "purl": "pkg:npm/left-pad@1.3.0",
+      "hashes": [{"alg": "SHA-512", "content": "abcd"}],
+      "licenses": [{"license": {"id": "MIT"}}]
+    },
+    {
+      "bom-ref": "pkg:golang/github.com/spf13/cobra@v1.8.0",
+      "type": "library",
+      "name": "cobra",
+      "version": "v1.8.0",
+      "purl": "pkg:golang/github.com/spf13/cobra@v1.8.0"
+    }
+  ]
+}`
+
+func testDoc(t *testing.T) sbom.Document {
+	t.Helper()
+	doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(testCdx), sbom.Signature{})
+	require.NoError(t, err)
+	return doc
+}
+
+func TestEvalComponent(t *testing.T) {
+	doc := testDoc(t)
+	comps := doc.Components()
+	require.Len(t, comps, 3, "the primary component is listed too")
+
+	tests := []struct {
+		expr string
+		want map[string]bool
+	}{
+		{`name == "left-pad"`, map[string]bool{"app": false, "left-pad": true, "cobra": false}},
+		{`"npm" in purl_types`, map[string]bool{"app": false, "left-pad": true, "cobra": false}},
+		{`has_checksum("sha512")`, map[string]bool{"app": false, "left-pad": true, "cobra": false}},
+		{`len(licenses) > 0 && !has_checksum("SHA-256")`, map[string]bool{"app": false, "left-pad": true, "cobra": false}},
+		{`has_prefix(version, "v") || lower(name) in ["left-pad"]`, map[string]bool{"app": false, "left-pad": true, "cobra": true}},
+		{`matches(version, '^v?1\.')`, map[string]bool{"app": true, "left-pad": true, "cobra": true}},
+		{`doc.spec == "cyclonedx" && doc.components == 3`, map[string]bool{"app": true, "left-pad": true, "cobra": true}},
+		{`not_a_field == ""`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := Compile(tt.expr, ScopeComponent)
+			if tt.want == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			for _, comp := range comps {
+				got, err := e.Eval(Env{Doc: doc, Comp: comp})
+				require.NoError(t, err)
+				assert.Equal(t, tt.want[comp.GetName()], got, comp.GetName())
+			}
+		})
+	}
+}
+
+func TestEvalDocument(t *testing.T) {
+	doc := testDoc(t)
+
+	e, err := Compile(`spec_version >= "1.4" && has_primary_component`, ScopeDocument)
+	require.NoError(t, err)
+	ok, err := e.Eval(Env{Doc: doc})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = Compile(`has_checksum("SHA-512")`, ScopeDocument)
+	assert.Error(t, err, "component functions are not available on documents")
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, src := range []string{
+		``,
+		`name ==`,
+		`(name == "a"`,
+		`name == "a" extra`,
+		`unknown_fn(name)`,
+		`len(name, version)`,
+		`"unterminated`,
+		`matches(name, "[a-")`,
+	} {
+		_, err := Compile(src, ScopeComponent)
+		assert.Error(t, err, src)
+	}
+}
+
+func TestEvalTypeErrors(t *testing.T) {
+	doc := testDoc(t)
+
+	e, err := Compile(`name`, ScopeComponent)
+	require.NoError(t, err)
+	_, err = e.Eval(Env{Doc: doc, Comp: doc.Components()[0]})
+	assert.Error(t, err, "a string is not a predicate")
+
+	e, err = Compile(`name > 3`, ScopeComponent)
+	require.NoError(t, err)
+	_, err = e.Eval(Env{Doc: doc, Comp: doc.Components()[0]})
+	assert.Error(t, err)
+
+	e, err = Compile(`matches(name, lower("[a-"))`, ScopeComponent)
+	require.NoError(t, err)
+	_, err = e.Eval(Env{Doc: doc, Comp: doc.Components()[0]})
+	assert.Error(t, err, "a pattern built at runtime is checked when evaluated")
+}
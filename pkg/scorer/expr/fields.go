@@ -0,0 +1,169 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"github.com/interlynk-io/sbomqs/pkg/cpe"
+	"github.com/interlynk-io/sbomqs/pkg/licenses"
+	"github.com/interlynk-io/sbomqs/pkg/omniborid"
+	"github.com/interlynk-io/sbomqs/pkg/purl"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/interlynk-io/sbomqs/pkg/swhid"
+	"github.com/interlynk-io/sbomqs/pkg/swid"
+	pkg_purl "github.com/package-url/packageurl-go"
+	"github.com/samber/lo"
+)
+
+type componentField func(doc sbom.Document, comp sbom.GetComponent) value
+
+type documentField func(doc sbom.Document) value
+
+var componentFields = map[string]componentField{
+	"name":      func(_ sbom.Document, c sbom.GetComponent) value { return c.GetName() },
+	"version":   func(_ sbom.Document, c sbom.GetComponent) value { return c.GetVersion() },
+	"id":        func(_ sbom.Document, c sbom.GetComponent) value { return c.GetID() },
+	"purpose":   func(_ sbom.Document, c sbom.GetComponent) value { return c.PrimaryPurpose() },
+	"copyright": func(_ sbom.Document, c sbom.GetComponent) value { return c.GetCopyRight() },
+
+	"supplier": func(_ sbom.Document, c sbom.GetComponent) value {
+		if c.Suppliers() == nil {
+			return ""
+		}
+		return c.Suppliers().GetName()
+	},
+	"supplier_email": func(_ sbom.Document, c sbom.GetComponent) value {
+		if c.Suppliers() == nil {
+			return ""
+		}
+		return c.Suppliers().GetEmail()
+	},
+	"supplier_url": func(_ sbom.Document, c sbom.GetComponent) value {
+		if c.Suppliers() == nil {
+			return ""
+		}
+		return c.Suppliers().GetURL()
+	},
+	"has_supplier": func(_ sbom.Document, c sbom.GetComponent) value {
+		return c.Suppliers() != nil && c.Suppliers().IsPresent()
+	},
+	"manufacturer": func(_ sbom.Document, c sbom.GetComponent) value {
+		if c.Manufacturer() == nil {
+			return ""
+		}
+		return c.Manufacturer().GetName()
+	},
+
+	"purls": func(_ sbom.Document, c sbom.GetComponent) value {
+		return lo.Map(c.GetPurls(), func(p purl.PURL, _ int) string { return p.String() })
+	},
+	"purl_types": func(_ sbom.Document, c sbom.GetComponent) value {
+		types := []string{}
+		for _, p := range c.GetPurls() {
+			if parsed, err := pkg_purl.FromString(p.String()); err == nil {
+				types = append(types, parsed.Type)
+			}
+		}
+		return types
+	},
+	"cpes": func(_ sbom.Document, c sbom.GetComponent) value {
+		return lo.Map(c.GetCpes(), func(p cpe.CPE, _ int) string { return p.String() })
+	},
+	"swhids": func(_ sbom.Document, c sbom.GetComponent) value {
+		return lo.Map(c.Swhids(), func(p swhid.SWHID, _ int) string { return p.String() })
+	},
+	"swids": func(_ sbom.Document, c sbom.GetComponent) value {
+		return lo.Map(c.Swids(), func(p swid.SWID, _ int) string { return p.String() })
+	},
+	"omniborids": func(_ sbom.Document, c sbom.GetComponent) value {
+		return lo.Map(c.OmniborIDs(), func(p omniborid.OMNIBORID, _ int) string { return p.String() })
+	},
+	"checksum_algos": func(_ sbom.Document, c sbom.GetComponent) value {
+		return lo.Map(c.GetChecksums(), func(ck sbom.GetChecksum, _ int) string { return ck.GetAlgo() })
+	},
+
+	"licenses":           func(_ sbom.Document, c sbom.GetComponent) value { return licenseIDs(c.Licenses()) },
+	"declared_licenses":  func(_ sbom.Document, c sbom.GetComponent) value { return licenseIDs(c.DeclaredLicenses()) },
+	"concluded_licenses": func(_ sbom.Document, c sbom.GetComponent) value { return licenseIDs(c.ConcludedLicenses()) },
+
+	"source_code_url":  func(_ sbom.Document, c sbom.GetComponent) value { return c.SourceCodeURL() },
+	"source_code_hash": func(_ sbom.Document, c sbom.GetComponent) value { return c.SourceCodeHash() },
+	"download_url":     func(_ sbom.Document, c sbom.GetComponent) value { return c.GetDownloadLocationURL() },
+	"external_ref_types": func(_ sbom.Document, c sbom.GetComponent) value {
+		return lo.Map(c.ExternalReferences(), func(r sbom.GetExternalReference, _ int) string { return r.GetRefType() })
+	},
+
+	"is_primary":        func(_ sbom.Document, c sbom.GetComponent) value { return c.IsPrimaryComponent() },
+	"has_relationships": func(_ sbom.Document, c sbom.GetComponent) value { return c.HasRelationShips() },
+	"dependencies": func(d sbom.Document, c sbom.GetComponent) value {
+		return float64(len(d.GetRelationships(c.GetID())))
+	},
+}
+
+var documentFields = map[string]documentField{
+	"spec":               func(d sbom.Document) value { return d.Spec().GetSpecType() },
+	"spec_version":       func(d sbom.Document) value { return d.Spec().GetVersion() },
+	"file_format":        func(d sbom.Document) value { return d.Spec().FileFormat() },
+	"name":               func(d sbom.Document) value { return d.Spec().GetName() },
+	"namespace":          func(d sbom.Document) value { return d.Spec().GetNamespace() },
+	"uri":                func(d sbom.Document) value { return d.Spec().GetURI() },
+	"organization":       func(d sbom.Document) value { return d.Spec().GetOrganization() },
+	"creation_timestamp": func(d sbom.Document) value { return d.Spec().GetCreationTimestamp() },
+	"licenses":           func(d sbom.Document) value { return licenseIDs(d.Spec().GetLicenses()) },
+	"ext_doc_refs":       func(d sbom.Document) value { return append([]string{}, d.Spec().GetExtDocRef()...) },
+
+	"authors": func(d sbom.Document) value {
+		return lo.Map(d.Authors(), func(a sbom.GetAuthor, _ int) string { return a.GetName() })
+	},
+	"tools": func(d sbom.Document) value {
+		return lo.Map(d.Tools(), func(t sbom.GetTool, _ int) string { return t.GetName() })
+	},
+	"lifecycles": func(d sbom.Document) value {
+		return lo.Filter(d.Lifecycles(), func(l string, _ int) bool { return l != "" })
+	},
+	"vulnerabilities": func(d sbom.Document) value {
+		return lo.Map(d.Vulnerabilities(), func(v sbom.GetVulnerabilities, _ int) string { return v.GetID() })
+	},
+
+	"components": func(d sbom.Document) value { return float64(len(d.Components())) },
+	"relations":  func(d sbom.Document) value { return float64(len(d.Relations())) },
+
+	"primary_component": func(d sbom.Document) value {
+		if d.PrimaryComp() == nil {
+			return ""
+		}
+		return d.PrimaryComp().GetName()
+	},
+	"has_primary_component": func(d sbom.Document) value {
+		return d.PrimaryComp() != nil && d.PrimaryComp().IsPresent()
+	},
+	"has_signature": func(d sbom.Document) value {
+		return d.Signature() != nil && d.Signature().GetSigValue() != ""
+	},
+}
+
+func licenseIDs(lics []licenses.License) []string {
+	ids := []string{}
+	for _, l := range lics {
+		if l == nil {
+			continue
+		}
+		if l.ShortID() != "" {
+			ids = append(ids, l.ShortID())
+		} else {
+			ids = append(ids, l.Name())
+		}
+	}
+	return ids
+}
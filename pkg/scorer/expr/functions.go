@@ -0,0 +1,161 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type function struct {
+	args int
+
+	// component functions are only available in ScopeComponent
+	component bool
+
+	call func(env Env, args []value) (value, error)
+
+	// validate checks literal arguments when the expression is compiled
+	validate func(args []node) error
+}
+
+var functions = map[string]function{
+	"len": {args: 1, call: func(_ Env, args []value) (value, error) {
+		switch v := args[0].(type) {
+		case string:
+			return float64(len(v)), nil
+		case []string:
+			return float64(len(v)), nil
+		}
+		return nil, fmt.Errorf("expects a string or list, got %s", typeName(args[0]))
+	}},
+
+	"lower": {args: 1, call: func(_ Env, args []value) (value, error) {
+		s, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToLower(s), nil
+	}},
+
+	"upper": {args: 1, call: func(_ Env, args []value) (value, error) {
+		s, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToUpper(s), nil
+	}},
+
+	"contains": {args: 2, call: func(_ Env, args []value) (value, error) {
+		return in(args[1], args[0])
+	}},
+
+	"has_prefix": {args: 2, call: func(_ Env, args []value) (value, error) {
+		return stringPredicate(args, strings.HasPrefix)
+	}},
+
+	"has_suffix": {args: 2, call: func(_ Env, args []value) (value, error) {
+		return stringPredicate(args, strings.HasSuffix)
+	}},
+
+	"matches": {args: 2, validate: validatePattern, call: func(_ Env, args []value) (value, error) {
+		s, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		pattern, err := stringArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		re, err := compileRegexp(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString(s), nil
+	}},
+
+	"has_checksum": {args: 1, component: true, call: func(env Env, args []value) (value, error) {
+		alg, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		if env.Comp == nil {
+			return false, nil
+		}
+		for _, c := range env.Comp.GetChecksums() {
+			if NormalizeAlgorithm(c.GetAlgo()) == NormalizeAlgorithm(alg) && c.GetContent() != "" {
+				return true, nil
+			}
+		}
+		return false, nil
+	}},
+}
+
+// NormalizeAlgorithm maps the spellings of a checksum algorithm used by SPDX
+// and CycloneDX (SHA256, SHA-256, sha256) to a single form.
+func NormalizeAlgorithm(alg string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", "_", "").Replace(alg))
+}
+
+func stringArg(args []value, i int) (string, error) {
+	s, ok := args[i].(string)
+	if !ok {
+		return "", fmt.Errorf("argument %d must be a string, got %s", i+1, typeName(args[i]))
+	}
+	return s, nil
+}
+
+func stringPredicate(args []value, f func(string, string) bool) (value, error) {
+	s, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	p, err := stringArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return f(s, p), nil
+}
+
+// validatePattern rejects a literal regular expression that doesn't
+// compile, so the mistake is reported before any SBOM is scored.
+func validatePattern(args []node) error {
+	lit, ok := args[1].(*literalNode)
+	if !ok {
+		return nil
+	}
+	pattern, ok := lit.v.(string)
+	if !ok {
+		return nil
+	}
+	_, err := compileRegexp(pattern)
+	return err
+}
+
+var regexpCache sync.Map
+
+func compileRegexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexpCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexpCache.Store(pattern, re)
+	return re, nil
+}
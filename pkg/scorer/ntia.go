@@ -16,103 +16,33 @@ package scorer
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/interlynk-io/sbomqs/pkg/compliance/common"
 	"github.com/interlynk-io/sbomqs/pkg/sbom"
-	"github.com/samber/lo"
 )
 
 func compWithSupplierCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	withNames := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have supplier names", func(c sbom.GetComponent) bool {
 		return c.Suppliers().IsPresent()
 	})
-
-	if totalComponents > 0 {
-		s.setScore((float64(withNames) / float64(totalComponents)) * 10.0)
-	}
-
-	s.setDesc(fmt.Sprintf("%d/%d have supplier names", withNames, totalComponents))
-
-	return *s
 }
 
 func compWithNameCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-	withNames := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have names", func(c sbom.GetComponent) bool {
 		return c.GetName() != ""
 	})
-	if totalComponents > 0 {
-		s.setScore((float64(withNames) / float64(totalComponents)) * 10.0)
-	}
-	s.setDesc(fmt.Sprintf("%d/%d have names", withNames, totalComponents))
-
-	return *s
 }
 
 func compWithVersionCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-	withVersions := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+	return countComponents(d, c, "have versions", func(c sbom.GetComponent) bool {
 		return c.GetVersion() != ""
 	})
-	if totalComponents > 0 {
-		s.setScore((float64(withVersions) / float64(totalComponents)) * 10.0)
-	}
-	s.setDesc(fmt.Sprintf("%d/%d have versions", withVersions, totalComponents))
-
-	return *s
 }
 
 func compWithUniqIDCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	compIDs := lo.FilterMap(d.Components(), func(c sbom.GetComponent, _ int) (string, bool) {
-		if c.GetID() == "" {
-			return "", false
-		}
-		return strings.Join([]string{d.Spec().GetNamespace(), c.GetID()}, ""), true
+	return countComponents(d, c, "have unique ID's", func(c sbom.GetComponent) bool {
+		return c.GetID() != ""
 	})
-
-	// uniqComps := lo.Uniq(compIDs)
-
-	if totalComponents > 0 {
-		s.setScore((float64(len(compIDs)) / float64(totalComponents)) * 10.0)
-	}
-	s.setDesc(fmt.Sprintf("%d/%d have unique ID's", len(compIDs), totalComponents))
-	return *s
 }
 
 func sbomWithDepedenciesCheck(d sbom.Document, c *check) score {
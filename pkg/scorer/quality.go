@@ -16,7 +16,6 @@ package scorer
 
 import (
 	"fmt"
-	"math"
 	"strings"
 
 	"github.com/interlynk-io/sbomqs/pkg/licenses"
@@ -25,191 +24,101 @@ import (
 )
 
 func compWithValidLicensesCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	compScores := lo.Map(d.Components(), func(c sbom.GetComponent, _ int) float64 {
-		tl := len(c.Licenses())
-
-		if tl == 0 {
-			return 0.0
-		}
-
-		validLic := lo.CountBy(c.Licenses(), func(l licenses.License) bool {
-			return l.Spdx()
-		})
-
-		if validLic == 0 {
-			return 0.0
-		}
-
-		return (float64(validLic) / float64(tl)) * 10.0
-	})
-
-	totalCompScore := lo.Reduce(compScores, func(agg float64, a float64, _ int) float64 {
-		if !math.IsNaN(a) {
-			return agg + a
-		}
-		return agg
-	}, 0.0)
-
-	finalScore := (totalCompScore / float64(totalComponents))
-
-	compsWithValidScores := lo.CountBy(compScores, func(score float64) bool {
-		return score > 0.0
+	var totalCompScore float64
+	var compsWithValidScores int
+
+	return scoreComponents(d, tally{
+		add: func(c sbom.GetComponent) {
+			tl := len(c.Licenses())
+			if tl == 0 {
+				return
+			}
+
+			validLic := lo.CountBy(c.Licenses(), func(l licenses.License) bool {
+				return l.Spdx()
+			})
+			if validLic == 0 {
+				return
+			}
+
+			totalCompScore += (float64(validLic) / float64(tl)) * 10.0
+			compsWithValidScores++
+		},
+		score: func(totalComponents int) score {
+			s := newScoreFromCheck(c)
+			if totalComponents == 0 {
+				return noComponents(s)
+			}
+
+			s.setScore(totalCompScore / float64(totalComponents))
+			s.setDesc(fmt.Sprintf("%d/%d components with valid license ", compsWithValidScores, totalComponents))
+			return *s
+		},
 	})
-
-	s.setScore(finalScore)
-
-	s.setDesc(fmt.Sprintf("%d/%d components with valid license ", compsWithValidScores, totalComponents))
-
-	return *s
 }
 
 func compWithPrimaryPackageCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
+	purposes := sbom.SupportedPrimaryPurpose(d.Spec().GetSpecType())
 
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-	withPurpose := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
-		return c.PrimaryPurpose() != "" && lo.Contains(sbom.SupportedPrimaryPurpose(d.Spec().GetSpecType()), strings.ToLower(c.PrimaryPurpose()))
+	return countComponents(d, c, "components have primary purpose specified", func(c sbom.GetComponent) bool {
+		return c.PrimaryPurpose() != "" && lo.Contains(purposes, strings.ToLower(c.PrimaryPurpose()))
 	})
-
-	finalScore := (float64(withPurpose) / float64(totalComponents)) * 10.0
-	s.setScore(finalScore)
-
-	s.setDesc(fmt.Sprintf("%d/%d components have primary purpose specified", withPurpose, totalComponents))
-	return *s
 }
 
 func compWithNoDepLicensesCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	totalLicenses := lo.Reduce(d.Components(), func(agg int, c sbom.GetComponent, _ int) int {
-		return agg + len(c.Licenses())
-	}, 0)
-
-	withDepLicense := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
-		deps := lo.CountBy(c.Licenses(), func(l licenses.License) bool {
-			return l.Deprecated()
-		})
-		return deps > 0
+	return compWithoutLicenses(d, c, "deprecated", func(l licenses.License) bool {
+		return l.Deprecated()
 	})
-
-	if totalLicenses == 0 {
-		s.setScore(0.0)
-		s.setDesc("no licenses found")
-	} else {
-		finalScore := (float64(totalComponents-withDepLicense) / float64(totalComponents)) * 10.0
-		s.setScore(finalScore)
-		s.setDesc(fmt.Sprintf("%d/%d components have deprecated licenses", withDepLicense, totalComponents))
-	}
-	return *s
 }
 
 func compWithRestrictedLicensesCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	totalLicenses := lo.Reduce(d.Components(), func(agg int, c sbom.GetComponent, _ int) int {
-		return agg + len(c.Licenses())
-	}, 0)
-
-	withRestrictLicense := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
-		rest := lo.CountBy(c.Licenses(), func(l licenses.License) bool {
-			return l.Restrictive()
-		})
-		return rest > 0
+	return compWithoutLicenses(d, c, "restricted", func(l licenses.License) bool {
+		return l.Restrictive()
 	})
+}
 
-	if totalLicenses == 0 {
-		s.setScore(0.0)
-		s.setDesc("no licenses found")
-	} else {
-		finalScore := (float64(totalComponents-withRestrictLicense) / float64(totalComponents)) * 10.0
-		s.setScore(finalScore)
-		s.setDesc(fmt.Sprintf("%d/%d components have restricted licenses", withRestrictLicense, totalComponents))
-	}
-	return *s
+// compWithoutLicenses scores c by the share of components without a kind
+// license, one for which is returns true. An SBOM without any licenses
+// scores nothing.
+func compWithoutLicenses(d sbom.Document, c *check, kind string, is func(licenses.License) bool) score {
+	var totalLicenses, withLicense int
+
+	return scoreComponents(d, tally{
+		add: func(c sbom.GetComponent) {
+			totalLicenses += len(c.Licenses())
+			if lo.ContainsBy(c.Licenses(), is) {
+				withLicense++
+			}
+		},
+		score: func(totalComponents int) score {
+			s := newScoreFromCheck(c)
+			if totalComponents == 0 {
+				return noComponents(s)
+			}
+
+			if totalLicenses == 0 {
+				s.setScore(0.0)
+				s.setDesc("no licenses found")
+			} else {
+				finalScore := (float64(totalComponents-withLicense) / float64(totalComponents)) * 10.0
+				s.setScore(finalScore)
+				s.setDesc(fmt.Sprintf("%d/%d components have %s licenses", withLicense, totalComponents, kind))
+			}
+			return *s
+		},
+	})
 }
 
 func compWithAnyLookupIDCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	withAnyLookupID := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
-		if len(c.GetCpes()) > 0 || len(c.GetPurls()) > 0 {
-			return true
-		}
-		return false
+	return countComponents(d, c, "components have any lookup id", func(c sbom.GetComponent) bool {
+		return len(c.GetCpes()) > 0 || len(c.GetPurls()) > 0
 	})
-
-	finalScore := (float64(withAnyLookupID) / float64(totalComponents)) * 10.0
-
-	s.setScore(finalScore)
-
-	s.setDesc(fmt.Sprintf("%d/%d components have any lookup id", withAnyLookupID, totalComponents))
-
-	return *s
 }
 
 func compWithMultipleIDCheck(d sbom.Document, c *check) score {
-	s := newScoreFromCheck(c)
-
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
-		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
-		s.setIgnore(true)
-		return *s
-	}
-
-	withMultipleID := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
-		if len(c.GetCpes()) > 0 && len(c.GetPurls()) > 0 {
-			return true
-		}
-		return false
+	return countComponents(d, c, "components have multiple lookup id", func(c sbom.GetComponent) bool {
+		return len(c.GetCpes()) > 0 && len(c.GetPurls()) > 0
 	})
-
-	finalScore := (float64(withMultipleID) / float64(totalComponents)) * 10.0
-
-	s.setScore(finalScore)
-
-	s.setDesc(fmt.Sprintf("%d/%d components have multiple lookup id", withMultipleID, totalComponents))
-
-	return *s
 }
 
 func sbomWithCreatorCheck(d sbom.Document, c *check) score {
@@ -24,6 +24,7 @@ type Score interface {
 	Score() float64
 	Descr() string
 	MaxScore() float64
+	Weight() float64
 }
 
 //nolint:revive,stylecheck
@@ -35,6 +36,7 @@ type score struct {
 	descr    string
 	score    float64
 	ignore   bool
+	weight   float64
 }
 
 func newScoreFromCheck(c *check) *score {
@@ -42,6 +44,7 @@ func newScoreFromCheck(c *check) *score {
 		category: c.Category,
 		feature:  c.Key,
 		ignore:   false,
+		weight:   DefaultWeight,
 	}
 }
 
@@ -61,6 +64,10 @@ func (s *score) setIgnore(i bool) {
 	s.ignore = i
 }
 
+func (s *score) setWeight(w float64) {
+	s.weight = w
+}
+
 // Category returns particular category.
 // It contains collection of features within it.
 func (s score) Category() string {
@@ -88,3 +95,8 @@ func (s score) Descr() string {
 func (s score) MaxScore() float64 {
 	return MAX_SCORE
 }
+
+// Weight represent the relative importance of the feature in the average score.
+func (s score) Weight() float64 {
+	return s.weight
+}
@@ -21,7 +21,7 @@ import (
 	"github.com/interlynk-io/sbomqs/pkg/sbom"
 )
 
-const EngineVersion = "7"
+const EngineVersion = "10"
 
 type filterType int
 
@@ -35,6 +35,14 @@ type Filter struct {
 	Name     string
 	Ftype    filterType
 	Category string
+
+	// Weight and CategoryWeight are only honoured for Mix filters,
+	// which is what config files produce. nil leaves the default weight.
+	Weight         *float64
+	CategoryWeight *float64
+
+	// custom is set for features defined by an expression in the config file
+	custom *check
 }
 
 type Scorer struct {
@@ -45,6 +53,22 @@ type Scorer struct {
 	featFilter map[string]bool
 	catFilter  map[string]bool
 	mixFilter  map[string]map[string]bool
+
+	// per category, per feature weights
+	weights    map[string]map[string]float64
+	catWeights map[string]float64
+
+	// checks defined in the config file, scored after the built-in ones
+	customChecks []check
+
+	// known failures left out of component features
+	baseline Baseline
+
+	// the components of a streamed document, scored in a single walk by
+	// scoreStream, and the error which ended it
+	stream   sbom.ComponentStream
+	streamed map[string]score
+	err      error
 }
 
 func NewScorer(ctx context.Context, doc sbom.Document) *Scorer {
@@ -54,6 +78,14 @@ func NewScorer(ctx context.Context, doc sbom.Document) *Scorer {
 		featFilter: make(map[string]bool),
 		catFilter:  make(map[string]bool),
 		mixFilter:  make(map[string]map[string]bool),
+		weights:    make(map[string]map[string]float64),
+		catWeights: make(map[string]float64),
+	}
+
+	// the checks share one dependency graph of the document
+	if doc != nil {
+		scorer.doc = &graphDoc{Document: doc}
+		scorer.stream = sbom.Streamed(doc)
 	}
 
 	return scorer
@@ -72,6 +104,21 @@ func (s *Scorer) AddFilter(f Filter) {
 			s.mixFilter[f.Category] = make(map[string]bool)
 		}
 		s.mixFilter[f.Category][f.Name] = true
+
+		if f.Weight != nil {
+			if s.weights[f.Category] == nil {
+				s.weights[f.Category] = make(map[string]float64)
+			}
+			s.weights[f.Category][f.Name] = *f.Weight
+		}
+
+		if f.CategoryWeight != nil {
+			s.catWeights[f.Category] = *f.CategoryWeight
+		}
+
+		if f.custom != nil {
+			s.customChecks = append(s.customChecks, *f.custom)
+		}
 	}
 }
 
@@ -95,13 +142,40 @@ func (s *Scorer) Score() Scores {
 	return s.AllScores()
 }
 
-func (s *Scorer) catScores() Scores {
+// checks returns the built-in checks followed by the custom ones.
+func (s *Scorer) checks() []check {
+	all := make([]check, 0, len(checks)+len(s.customChecks))
+	for _, c := range checks {
+		// An ignored check still counts against its category, so the schema
+		// check is left out unless the document was validated.
+		if c.Key == schemaCheckKey && s.doc.Schema() == nil {
+			continue
+		}
+		// compositions are CycloneDX only
+		if cdxOnlyChecks[c.Key] && s.doc.Spec().GetSpecType() != string(sbom.SBOMSpecCDX) {
+			continue
+		}
+		all = append(all, c)
+	}
+	return append(all, s.customChecks...)
+}
+
+// newScores returns an empty result set carrying the configured category weights.
+func (s *Scorer) newScores() *scores {
 	scores := newScores()
+	for cat, w := range s.catWeights {
+		scores.catWeights[cat] = w
+	}
+	return scores
+}
 
-	for _, c := range checks {
+func (s *Scorer) catScores() Scores {
+	scores := s.newScores()
+
+	for _, c := range s.checks() {
 		cCopy := c // Create a copy of c
-		if s.catFilter[c.Category] {
-			scores.addScore(c.evaluate(s.doc, &cCopy))
+		if s.catFilter[c.Category] && !c.Ignore {
+			scores.addScore(s.evaluate(&cCopy))
 		}
 	}
 
@@ -110,16 +184,16 @@ func (s *Scorer) catScores() Scores {
 
 func (s *Scorer) featureScores() Scores {
 	fmt.Println("Scoring features with filters:", s.featFilter)
-	scores := newScores()
+	scores := s.newScores()
 
 	checkMap := make(map[string]bool)
 
-	for _, c := range checks {
+	for _, c := range s.checks() {
 		if _, exists := checkMap[c.Key]; exists {
 			continue // Skip if the feature has already been processed
 		}
 		if s.featFilter[c.Key] {
-			scores.addScore(c.evaluate(s.doc, &c)) //nolint:gosec
+			scores.addScore(s.evaluate(&c)) //nolint:gosec
 		}
 	}
 
@@ -128,11 +202,15 @@ func (s *Scorer) featureScores() Scores {
 
 // featureAndCatScores returns scores for both features and categories
 func (s *Scorer) featureAndCatScores() Scores {
-	scores := newScores()
+	scores := s.newScores()
 
-	for _, c := range checks {
+	for _, c := range s.checks() {
 		if s.mixFilter[c.Category][c.Key] {
-			scores.addScore(c.evaluate(s.doc, &c)) //nolint:gosec
+			sc := s.evaluate(&c) //nolint:gosec
+			if w, ok := s.weights[c.Category][c.Key]; ok {
+				sc.setWeight(w)
+			}
+			scores.addScore(sc)
 		}
 	}
 
@@ -140,10 +218,14 @@ func (s *Scorer) featureAndCatScores() Scores {
 }
 
 func (s *Scorer) AllScores() Scores {
-	scores := newScores()
+	scores := s.newScores()
 
-	for _, c := range checks {
-		scores.addScore(c.evaluate(s.doc, &c)) //nolint:gosec
+	for _, c := range s.checks() {
+		// opt-in checks are only scored when selected by name
+		if c.Ignore {
+			continue
+		}
+		scores.addScore(s.evaluate(&c)) //nolint:gosec
 	}
 
 	return scores
@@ -19,15 +19,26 @@ type Scores interface {
 	Count() int
 	AvgScore() float64
 	ScoreList() []Score
+	CategoryScores() []CategoryScore
+}
+
+// CategoryScore represent the aggregated score of all the features within a category.
+type CategoryScore interface {
+	Category() string
+	Score() float64
+	Weight() float64
+	Count() int
 }
 
 type scores struct {
-	scs []Score
+	scs        []Score
+	catWeights map[string]float64
 }
 
 func newScores() *scores {
 	return &scores{
-		scs: []Score{},
+		scs:        []Score{},
+		catWeights: make(map[string]float64),
 	}
 }
 
@@ -39,17 +50,78 @@ func (s scores) Count() int {
 	return len(s.scs)
 }
 
-// total score is the sum of all scores divided by the number of scores
+// total score is the weighted mean of the per category scores
 func (s scores) AvgScore() float64 {
 	score := 0.0
-	for _, s := range s.scs {
-		if !s.Ignore() {
-			score += s.Score()
-		}
+	weights := 0.0
+	for _, c := range s.CategoryScores() {
+		score += c.Score() * c.Weight()
+		weights += c.Weight()
+	}
+	if weights == 0 {
+		return 0.0
 	}
-	return score / float64(s.Count())
+	return score / weights
 }
 
 func (s scores) ScoreList() []Score {
 	return s.scs
 }
+
+// CategoryScores returns the categories in the order they were scored.
+// A category score is the weighted sum of its feature scores divided by
+// the sum of its feature weights.
+func (s scores) CategoryScores() []CategoryScore {
+	var cats []CategoryScore
+	index := make(map[string]*categoryScore)
+
+	for _, sc := range s.scs {
+		cs, ok := index[sc.Category()]
+		if !ok {
+			cs = &categoryScore{
+				category: sc.Category(),
+				weight:   DefaultWeight,
+			}
+			if w, ok := s.catWeights[sc.Category()]; ok {
+				cs.weight = w
+			}
+			index[sc.Category()] = cs
+			cats = append(cats, cs)
+		}
+
+		cs.count++
+		cs.weights += sc.Weight()
+		if !sc.Ignore() {
+			cs.total += sc.Score() * sc.Weight()
+		}
+	}
+
+	return cats
+}
+
+type categoryScore struct {
+	category string
+	weight   float64
+	count    int
+	total    float64
+	weights  float64
+}
+
+func (c categoryScore) Category() string {
+	return c.category
+}
+
+func (c categoryScore) Score() float64 {
+	if c.weights == 0 {
+		return 0.0
+	}
+	return c.total / c.weights
+}
+
+func (c categoryScore) Weight() float64 {
+	return c.weight
+}
+
+func (c categoryScore) Count() int {
+	return c.count
+}
@@ -17,51 +17,141 @@ package scorer
 import (
 	"fmt"
 
+	"github.com/interlynk-io/sbomqs/pkg/graph"
 	"github.com/interlynk-io/sbomqs/pkg/sbom"
 	"github.com/samber/lo"
 )
 
+// graphDoc is the scored document with its dependency graph, built the
+// first time a check asks for it.
+type graphDoc struct {
+	sbom.Document
+	g *graph.Graph
+}
+
+// docGraph returns the dependency graph of all of the components of d,
+// built once per scored document.
+func docGraph(d sbom.Document) *graph.Graph {
+	gd, ok := fullDoc(d).(*graphDoc)
+	if !ok {
+		return graph.New(fullDoc(d))
+	}
+	if gd.g == nil {
+		gd.g = graph.New(gd.Document)
+	}
+	return gd.g
+}
+
 func sbomWithRequiredFieldCheck(d sbom.Document, c *check) score {
+	docOK := d.Spec().RequiredFields()
+	noOfPkgs := 0
+
+	return scoreComponents(d, tally{
+		add: func(c sbom.GetComponent) {
+			if c.RequiredFields() {
+				noOfPkgs++
+			}
+		},
+		score: func(totalComponents int) score {
+			s := newScoreFromCheck(c)
+
+			pkgsOK := false
+			if totalComponents > 0 && noOfPkgs == totalComponents {
+				pkgsOK = true
+			}
+
+			var docScore, pkgScore float64
+
+			if !docOK && pkgsOK {
+				docScore = 0
+				pkgScore = 10.0
+				s.setScore((docScore + pkgScore) / 2.0)
+				s.setScore(0.0)
+			}
+
+			if docOK && !pkgsOK {
+				docScore = 10.0
+				if totalComponents > 0 {
+					pkgScore = (float64(noOfPkgs) / float64(totalComponents)) * 10.0
+				}
+				s.setScore((docScore + pkgScore) / 2.0)
+			}
+
+			if docOK && pkgsOK {
+				s.setScore(10.0)
+			}
+
+			s.setDesc(fmt.Sprintf("Doc Fields:%t Pkg Fields:%t", docOK, pkgsOK))
+
+			return *s
+		},
+	})
+}
+
+func compWithLicensesCheck(d sbom.Document, c *check) score {
+	return countComponents(d, c, "have licenses", func(c sbom.GetComponent) bool {
+		return len(c.Licenses()) > 0
+	})
+}
+
+func compWithChecksumsCheck(d sbom.Document, c *check) score {
+	return countComponents(d, c, "have checksums", func(c sbom.GetComponent) bool {
+		return len(c.GetChecksums()) > 0
+	})
+}
+
+func compWithDanglingDependenciesCheck(d sbom.Document, c *check) score {
 	s := newScoreFromCheck(c)
 
 	totalComponents := len(d.Components())
+	if totalComponents == 0 {
+		s.setScore(0.0)
+		s.setDesc("N/A (no components)")
+		s.setIgnore(true)
+		return *s
+	}
 
-	docOK := d.Spec().RequiredFields()
-	noOfPkgs := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
-		return c.RequiredFields()
+	g := docGraph(d)
+	withDangling := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+		return len(g.Dangling(c.GetID())) > 0
 	})
-	pkgsOK := false
-	if totalComponents > 0 && noOfPkgs == totalComponents {
-		pkgsOK = true
-	}
 
-	var docScore, pkgScore float64
+	s.setScore((float64(totalComponents-withDangling) / float64(totalComponents)) * 10.0)
+	s.setDesc(fmt.Sprintf("%d/%d have dangling dependencies", withDangling, totalComponents))
 
-	if !docOK && pkgsOK {
-		docScore = 0
-		pkgScore = 10.0
-		s.setScore((docScore + pkgScore) / 2.0)
+	return *s
+}
+
+func compReachableCheck(d sbom.Document, c *check) score {
+	s := newScoreFromCheck(c)
+
+	totalComponents := len(d.Components())
+	if totalComponents == 0 {
 		s.setScore(0.0)
+		s.setDesc("N/A (no components)")
+		s.setIgnore(true)
+		return *s
 	}
 
-	if docOK && !pkgsOK {
-		docScore = 10.0
-		if totalComponents > 0 {
-			pkgScore = (float64(noOfPkgs) / float64(totalComponents)) * 10.0
-		}
-		s.setScore((docScore + pkgScore) / 2.0)
+	g := docGraph(d)
+	if g.Root() == "" {
+		s.setScore(0.0)
+		s.setDesc("N/A (no primary component)")
+		s.setIgnore(true)
+		return *s
 	}
 
-	if docOK && pkgsOK {
-		s.setScore(10.0)
-	}
+	reachable := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+		return g.Reachable(c.GetID())
+	})
 
-	s.setDesc(fmt.Sprintf("Doc Fields:%t Pkg Fields:%t", docOK, pkgsOK))
+	s.setScore((float64(reachable) / float64(totalComponents)) * 10.0)
+	s.setDesc(fmt.Sprintf("%d/%d reachable from the primary component", reachable, totalComponents))
 
 	return *s
 }
 
-func compWithLicensesCheck(d sbom.Document, c *check) score {
+func compInDependencyCycleCheck(d sbom.Document, c *check) score {
 	s := newScoreFromCheck(c)
 
 	totalComponents := len(d.Components())
@@ -71,39 +161,212 @@ func compWithLicensesCheck(d sbom.Document, c *check) score {
 		s.setIgnore(true)
 		return *s
 	}
-	withLicenses := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
-		return len(c.Licenses()) > 0
+
+	g := docGraph(d)
+	inCycle := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
+		return g.InCycle(c.GetID())
 	})
 
-	if totalComponents > 0 {
-		s.setScore((float64(withLicenses) / float64(totalComponents)) * 10.0)
+	s.setScore((float64(totalComponents-inCycle) / float64(totalComponents)) * 10.0)
+	s.setDesc(fmt.Sprintf("%d/%d in %d dependency cycles", inCycle, totalComponents, len(g.Cycles())))
+
+	return *s
+}
+
+// sbomDependencyDepthCheck scores whether the dependencies of the primary
+// component are recorded transitively rather than only the direct ones, as
+// the share of its direct dependencies that record dependencies of their
+// own.
+func sbomDependencyDepthCheck(d sbom.Document, c *check) score {
+	s := newScoreFromCheck(c)
+
+	g := docGraph(d)
+	if g.Root() == "" {
+		s.setScore(0.0)
+		s.setDesc("N/A (no primary component)")
+		s.setIgnore(true)
+		return *s
 	}
 
-	s.setDesc(fmt.Sprintf("%d/%d have licenses", withLicenses, totalComponents))
+	if !g.HasDependencies() {
+		s.setScore(0.0)
+		s.setDesc("N/A (no dependencies)")
+		s.setIgnore(true)
+		return *s
+	}
+
+	direct := g.Dependencies(g.Root())
+	if len(direct) == 0 {
+		s.setDesc("primary comp has no dependencies")
+		return *s
+	}
+
+	withDeps := lo.CountBy(direct, func(id string) bool {
+		return len(g.Dependencies(id)) > 0 || len(g.Dangling(id)) > 0
+	})
+
+	maxDepth, avgDepth := g.Depths()
+	s.setScore((float64(withDeps) / float64(len(direct))) * 10.0)
+	s.setDesc(fmt.Sprintf("%d/%d direct dependencies record theirs, max depth %d, avg %.2f", withDeps, len(direct), maxDepth, avgDepth))
 
 	return *s
 }
 
-func compWithChecksumsCheck(d sbom.Document, c *check) score {
+// DescribedPart reports whether a part of an assembly has a name, a version
+// and a vulnerability lookup id.
+func DescribedPart(c sbom.GetComponent) bool {
+	return c.GetName() != "" && c.GetVersion() != "" && (len(c.GetPurls()) > 0 || len(c.GetCpes()) > 0)
+}
+
+// compWithDescribedPartsCheck scores the assemblies of the SBOM, the
+// components with parts nested in CycloneDX or contained in SPDX, by
+// whether all of their parts, at any depth, are described.
+func compWithDescribedPartsCheck(d sbom.Document, c *check) score {
 	s := newScoreFromCheck(c)
 
-	totalComponents := len(d.Components())
-	if totalComponents == 0 {
+	var assemblies, described, maxDepth int
+	// walk returns whether all of the parts below n are described
+	var walk func(n *sbom.ComponentNode) bool
+	walk = func(n *sbom.ComponentNode) bool {
+		maxDepth = max(maxDepth, n.Component.GetDepth())
+		ok := true
+		for _, part := range n.Children {
+			// every part is walked, its own parts are assemblies too
+			if !walk(part) || !DescribedPart(part.Component) {
+				ok = false
+			}
+		}
+		if len(n.Children) > 0 {
+			assemblies++
+			if ok {
+				described++
+			}
+		}
+		return ok
+	}
+	for _, root := range d.ComponentTree() {
+		walk(root)
+	}
+
+	if assemblies == 0 {
 		s.setScore(0.0)
-		s.setDesc("N/A (no components)")
+		s.setDesc("N/A (no assemblies)")
 		s.setIgnore(true)
 		return *s
 	}
 
-	withChecksums := lo.CountBy(d.Components(), func(c sbom.GetComponent) bool {
-		return len(c.GetChecksums()) > 0
+	s.setScore((float64(described) / float64(assemblies)) * 10.0)
+	s.setDesc(fmt.Sprintf("%d/%d assemblies have described parts, max depth %d", described, assemblies, maxDepth))
+
+	return *s
+}
+
+// cdxOnlyChecks score CycloneDX compositions, they're left out for other
+// specs, see Scorer.checks.
+var cdxOnlyChecks = map[string]bool{
+	"comp_with_complete_dependencies": true,
+	"comp_dependency_completeness":    true,
+}
+
+// compositionWeights score the aggregates a composition can declare for
+// dependencies. Those complete for either first or third party components
+// score half, one not declared or unknown scores nothing.
+var compositionWeights = map[string]float64{
+	"complete":                                10.0,
+	"incomplete_first_party_only":             5.0,
+	"incomplete_first_party_proprietary_only": 5.0,
+	"incomplete_first_party_opensource_only":  5.0,
+	"incomplete_third_party_only":             5.0,
+	"incomplete_third_party_proprietary_only": 5.0,
+	"incomplete_third_party_opensource_only":  5.0,
+	"incomplete":                              2.5,
+}
+
+// compWithCompleteDependenciesCheck weights every component by the
+// aggregate its dependencies are declared with, so a component declared
+// complete scores 10 and one declared partially complete scores less. An
+// SBOM without compositions isn't scored.
+func compWithCompleteDependenciesCheck(d sbom.Document, c *check) score {
+	var comps compositionTally
+
+	return scoreComponents(d, tally{
+		add: comps.add,
+		score: func(totalComponents int) score {
+			s := newScoreFromCheck(c)
+			if totalComponents == 0 {
+				return noComponents(s)
+			}
+			return comps.score(s, "")
+		},
 	})
+}
+
+// compDependencyCompletenessCheck weights the primary component and its
+// transitive dependencies by the aggregate their dependencies are declared
+// with. Components outside the dependency tree of the primary component
+// don't count.
+func compDependencyCompletenessCheck(d sbom.Document, c *check) score {
+	s := newScoreFromCheck(c)
 
-	if totalComponents > 0 {
-		s.setScore((float64(withChecksums) / float64(totalComponents)) * 10.0)
+	g := docGraph(d)
+	if g.Root() == "" {
+		s.setScore(0.0)
+		s.setDesc("N/A (no primary component)")
+		s.setIgnore(true)
+		return *s
+	}
+
+	// a component can repeat an id, count each once
+	seen := map[string]bool{}
+	tree := lo.Filter(d.Components(), func(comp sbom.GetComponent, _ int) bool {
+		_, ok := g.Depth(comp.GetID())
+		if !ok || seen[comp.GetID()] {
+			return false
+		}
+		seen[comp.GetID()] = true
+		return true
+	})
+
+	var comps compositionTally
+	for _, comp := range tree {
+		comps.add(comp)
+	}
+
+	maxDepth, _ := g.Depths()
+	return comps.score(s, fmt.Sprintf(" in the dependency tree, max depth %d", maxDepth))
+}
+
+// compositionTally adds up the weights of the aggregates the dependencies of
+// components are declared with.
+type compositionTally struct {
+	total                     float64
+	comps, complete, declared int
+}
+
+func (t *compositionTally) add(comp sbom.GetComponent) {
+	aggregate := comp.GetDependencyComposition()
+	t.total += compositionWeights[aggregate]
+	t.comps++
+	if aggregate != "" {
+		t.declared++
+	}
+	if aggregate == "complete" {
+		t.complete++
+	}
+}
+
+// score scores the components by the average weight of their aggregates,
+// they aren't scored if none is declared.
+func (t *compositionTally) score(s *score, suffix string) score {
+	if t.declared == 0 {
+		s.setScore(0.0)
+		s.setDesc("N/A (no compositions)")
+		s.setIgnore(true)
+		return *s
 	}
 
-	s.setDesc(fmt.Sprintf("%d/%d have checksums", withChecksums, totalComponents))
+	s.setScore(t.total / float64(t.comps))
+	s.setDesc(fmt.Sprintf("%d/%d complete, %d/%d declared%s", t.complete, t.comps, t.declared, t.comps, suffix))
 
 	return *s
 }
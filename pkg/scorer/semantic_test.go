@@ -0,0 +1,209 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositionChecks(t *testing.T) {
+	input := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"type": "application", "bom-ref": "app", "name": "app"}},
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a"},
+    {"type": "library", "bom-ref": "b", "name": "b"},
+    {"type": "library", "bom-ref": "c", "name": "c"},
+    {"type": "library", "bom-ref": "x", "name": "x"}
+  ],
+  "dependencies": [{"ref": "app", "dependsOn": ["a", "b", "c"]}],
+  "compositions": [
+    {"aggregate": "complete", "dependencies": ["app", "a"]},
+    {"aggregate": "incomplete", "dependencies": ["b", "x"]}
+  ]
+}`
+	doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(input), sbom.Signature{})
+	require.NoError(t, err)
+
+	// app and a complete, b and x incomplete, c not declared
+	s := compWithCompleteDependenciesCheck(doc, &check{Key: "comp_with_complete_dependencies"})
+	assert.InDelta(t, 25.0/5, s.Score(), 0.001)
+	assert.Equal(t, "2/5 complete, 4/5 declared", s.Descr())
+
+	// x isn't a dependency of app
+	s = compDependencyCompletenessCheck(doc, &check{Key: "comp_dependency_completeness"})
+	assert.InDelta(t, 22.5/4, s.Score(), 0.001)
+	assert.Equal(t, "2/4 complete, 3/4 declared in the dependency tree, max depth 1", s.Descr())
+}
+
+func TestCompositionChecksWithoutCompositions(t *testing.T) {
+	f, err := os.Open("../../samples/stree-cdxgen.cdx.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err := sbom.NewSBOMDocument(context.Background(), f, sbom.Signature{})
+	require.NoError(t, err)
+
+	for _, c := range []check{
+		{Key: "comp_with_complete_dependencies", evaluate: compWithCompleteDependenciesCheck},
+		{Key: "comp_dependency_completeness", evaluate: compDependencyCompletenessCheck},
+	} {
+		s := c.evaluate(doc, &c)
+		assert.True(t, s.Ignore(), c.Key)
+		assert.Equal(t, "N/A (no compositions)", s.Descr(), c.Key)
+	}
+
+	// compositions are opt-in, they don't count against the default score
+	scores := NewScorer(context.Background(), doc).Score()
+	for _, s := range scores.ScoreList() {
+		assert.NotContains(t, []string{"comp_with_complete_dependencies", "comp_dependency_completeness"}, s.Feature())
+	}
+	assert.InDelta(t, 5.9, scores.AvgScore(), 0.05)
+}
+
+func TestCompositionChecksSkipped(t *testing.T) {
+	f, err := os.Open("../../samples/sbomqs-spdx-syft.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err := sbom.NewSBOMDocument(context.Background(), f, sbom.Signature{})
+	require.NoError(t, err)
+
+	for _, s := range NewScorer(context.Background(), doc).Score().ScoreList() {
+		assert.False(t, cdxOnlyChecks[s.Feature()], "compositions are CycloneDX only: %s", s.Feature())
+	}
+}
+
+func TestGraphChecksOptIn(t *testing.T) {
+	f, err := os.Open("../../samples/sbomqs-spdx-syft.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err := sbom.NewSBOMDocument(context.Background(), f, sbom.Signature{})
+	require.NoError(t, err)
+
+	features := func(s Scores) []string {
+		var keys []string
+		for _, sc := range s.ScoreList() {
+			keys = append(keys, sc.Feature())
+		}
+		return keys
+	}
+
+	assert.NotContains(t, features(NewScorer(context.Background(), doc).Score()), "comp_reachable")
+
+	sr := NewScorer(context.Background(), doc)
+	sr.AddFilter(Filter{Name: string(semantic), Ftype: Category})
+	assert.NotContains(t, features(sr.Score()), "comp_reachable")
+
+	sr = NewScorer(context.Background(), doc)
+	sr.AddFilter(Filter{Name: "comp_reachable", Ftype: Feature})
+	assert.Equal(t, []string{"comp_reachable"}, features(sr.Score()))
+}
+
+func TestDependencyDepthCheck(t *testing.T) {
+	parse := func(deps string) sbom.Document {
+		input := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"type": "application", "bom-ref": "app", "name": "app"}},
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a"},
+    {"type": "library", "bom-ref": "b", "name": "b"},
+    {"type": "library", "bom-ref": "c", "name": "c"},
+    {"type": "library", "bom-ref": "d", "name": "d"}
+  ]` + deps + `
+}`
+		doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(input), sbom.Signature{})
+		require.NoError(t, err)
+		return doc
+	}
+	c := &check{Key: "sbom_dependency_depth"}
+
+	s := sbomDependencyDepthCheck(parse(""), c)
+	assert.True(t, s.Ignore(), "no dependency data")
+
+	s = sbomDependencyDepthCheck(parse(`,
+  "dependencies": [{"ref": "app", "dependsOn": ["a", "b", "c", "d"]}]`), c)
+	assert.False(t, s.Ignore())
+	assert.InDelta(t, 0.0, s.Score(), 0.001)
+
+	s = sbomDependencyDepthCheck(parse(`,
+  "dependencies": [
+    {"ref": "app", "dependsOn": ["a", "b"]},
+    {"ref": "a", "dependsOn": ["c"]}
+  ]`), c)
+	assert.InDelta(t, 5.0, s.Score(), 0.001)
+	assert.Equal(t, "1/2 direct dependencies record theirs, max depth 2, avg 1.33", s.Descr())
+}
+
+func TestDescribedPartsCheck(t *testing.T) {
+	input := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"type": "application", "bom-ref": "app", "name": "app",
+    "components": [{"type": "library", "bom-ref": "plugin", "name": "plugin", "version": "2.0", "purl": "pkg:generic/plugin@2.0"}]}},
+  "components": [
+    {"type": "library", "bom-ref": "a", "name": "a", "components": [
+      {"type": "library", "bom-ref": "b", "name": "b", "version": "1", "purl": "pkg:generic/b@1", "components": [
+        {"type": "library", "bom-ref": "c", "name": "c"}
+      ]}
+    ]},
+    {"type": "library", "bom-ref": "d", "name": "d"}
+  ]
+}`
+	doc, err := sbom.NewSBOMDocument(context.Background(), strings.NewReader(input), sbom.Signature{})
+	require.NoError(t, err)
+
+	// c isn't described, which fails both b and a
+	s := compWithDescribedPartsCheck(doc, &check{Key: "comp_with_described_parts"})
+	assert.InDelta(t, 10.0/3, s.Score(), 0.001)
+	assert.Equal(t, "1/3 assemblies have described parts, max depth 2", s.Descr())
+
+	f, err := os.Open("../../samples/stree-cdxgen.cdx.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err = sbom.NewSBOMDocument(context.Background(), f, sbom.Signature{})
+	require.NoError(t, err)
+
+	s = compWithDescribedPartsCheck(doc, &check{Key: "comp_with_described_parts"})
+	assert.True(t, s.Ignore(), "no nested components")
+}
+
+func TestGraphBuiltOnce(t *testing.T) {
+	f, err := os.Open("../../samples/sbomqs-spdx-syft.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err := sbom.NewSBOMDocument(context.Background(), f, sbom.Signature{})
+	require.NoError(t, err)
+
+	sr := NewScorer(context.Background(), doc)
+	g := docGraph(sr.doc)
+	assert.Same(t, g, docGraph(sr.doc))
+	assert.Same(t, g, docGraph(baselineDoc{Document: sr.doc}), "baselined checks share the graph of all components")
+}
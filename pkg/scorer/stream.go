@@ -0,0 +1,204 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+)
+
+// tally scores a check a component at a time, so that the components of a
+// streamed document needn't be held together, see Scorer.scoreStream.
+type tally struct {
+	add   func(sbom.GetComponent)
+	score func(total int) score
+}
+
+// scoreComponents adds the components of d to t and scores it. The tally of
+// a streamed document is left to the walk over its components.
+func scoreComponents(d sbom.Document, t tally) score {
+	if sd, ok := d.(*streamDoc); ok {
+		sd.tally = &t
+		return score{}
+	}
+
+	comps := d.Components()
+	for _, comp := range comps {
+		t.add(comp)
+	}
+	return t.score(len(comps))
+}
+
+// countComponents scores c by the share of the components of d which has
+// holds for, described as "n/total what".
+func countComponents(d sbom.Document, c *check, what string, has func(sbom.GetComponent) bool) score {
+	n := 0
+	return scoreComponents(d, tally{
+		add: func(comp sbom.GetComponent) {
+			if has(comp) {
+				n++
+			}
+		},
+		score: func(total int) score {
+			s := newScoreFromCheck(c)
+			if total == 0 {
+				return noComponents(s)
+			}
+			s.setScore((float64(n) / float64(total)) * 10.0)
+			s.setDesc(fmt.Sprintf("%d/%d %s", n, total, what))
+			return *s
+		},
+	})
+}
+
+// noComponents leaves a component check out of a document without
+// components.
+func noComponents(s *score) score {
+	s.setScore(0.0)
+	s.setDesc("N/A (no components)")
+	s.setIgnore(true)
+	return *s
+}
+
+// streamDoc stands in for a streamed document while its checks are
+// prepared, a check which tallies components leaves its tally there.
+type streamDoc struct {
+	sbom.Document
+	tally *tally
+}
+
+// wholeDocChecks need the relationships between all of the components, or
+// their hierarchy, which a streamed document doesn't keep. They're opt-in,
+// so the default checks can always be streamed.
+var wholeDocChecks = map[string]bool{
+	"comp_with_dangling_dependencies": true,
+	"comp_reachable":                  true,
+	"comp_in_dependency_cycle":        true,
+	"sbom_dependency_depth":           true,
+	"comp_with_described_parts":       true,
+	"comp_dependency_completeness":    true,
+}
+
+// Streamable reports whether the checks selected by filters can score a
+// document parsed with sbom.WithComponentStream. Custom checks can't, their
+// expressions may look at all of the components.
+func Streamable(filters []Filter) bool {
+	for _, f := range filters {
+		if f.custom != nil {
+			return false
+		}
+		// a category only selects checks which aren't opt-in
+		if f.Ftype != Category && wholeDocChecks[f.Name] {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns the error which ended the walk over the components of a
+// streamed document, whose component checks are incomplete then.
+func (s *Scorer) Err() error {
+	return s.err
+}
+
+// streamedTally is a check of a streamed document waiting for the walk
+// over its components. kept tallies the components the baseline didn't
+// suppress, as Scorer.evaluate does for a document in memory.
+type streamedTally struct {
+	key, feature      string
+	all, kept         *tally
+	total, suppressed int
+}
+
+func (t *streamedTally) score() score {
+	sc := t.all.score(t.total)
+	if t.kept == nil || t.suppressed == 0 || sc.Ignore() {
+		return sc
+	}
+
+	// every failure is known
+	if t.suppressed == t.total {
+		sc.setScore(10.0)
+		sc.setDesc(fmt.Sprintf("%d/%d baselined", t.suppressed, t.total))
+		return sc
+	}
+
+	sc = t.kept.score(t.total - t.suppressed)
+	sc.setDesc(fmt.Sprintf("%s (%d baselined)", sc.Descr(), t.suppressed))
+	return sc
+}
+
+func streamKey(c *check) string {
+	return c.Category + "/" + c.Key
+}
+
+// scoreStream scores every check of a streamed document, for evaluate to
+// look up, in a single walk over its components. The checks which need all
+// of the components at once aren't scored.
+func (s *Scorer) scoreStream() {
+	s.streamed = map[string]score{}
+
+	all := s.checks()
+	// the custom checks come last
+	builtin := len(all) - len(s.customChecks)
+
+	var tallies []*streamedTally
+	for i, c := range all {
+		if i >= builtin || wholeDocChecks[c.Key] {
+			sc := newScoreFromCheck(&c) //nolint:gosec
+			sc.setScore(0.0)
+			sc.setDesc("N/A (not scored for a streamed SBOM)")
+			sc.setIgnore(true)
+			s.streamed[streamKey(&c)] = *sc
+			continue
+		}
+
+		sd := &streamDoc{Document: s.doc}
+		sc := c.evaluate(sd, &c) //nolint:gosec
+		if sd.tally == nil {
+			s.streamed[streamKey(&c)] = sc
+			continue
+		}
+
+		t := &streamedTally{key: streamKey(&c), feature: c.Key, all: sd.tally}
+		if s.baseline != nil && strings.HasPrefix(c.Key, "comp_") {
+			kd := &streamDoc{Document: s.doc}
+			c.evaluate(kd, &c) //nolint:gosec
+			t.kept = kd.tally
+		}
+		tallies = append(tallies, t)
+	}
+
+	s.err = s.stream.WalkComponents(func(comp sbom.GetComponent) {
+		for _, t := range tallies {
+			t.total++
+			t.all.add(comp)
+			if t.kept == nil {
+				continue
+			}
+			if s.baseline.Suppressed(t.feature, comp) {
+				t.suppressed++
+			} else {
+				t.kept.add(comp)
+			}
+		}
+	})
+
+	for _, t := range tallies {
+		s.streamed[t.key] = t.score()
+	}
+}
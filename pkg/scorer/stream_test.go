@@ -0,0 +1,110 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var cdxJSONSamples = []string{
+	"../../samples/sbomqs-cdx-cgomod.json",
+	"../../samples/sbomqs-dummy-bomlinks-data.cdx.json",
+	"../../samples/sbomqs-dummy-licenses.cdx-1.6.json",
+	"../../samples/sbomqs-sbomsh-with-vuln.cdx.json",
+	"../../samples/stree-cdxgen.cdx.json",
+}
+
+func parseSample(t *testing.T, path string, opts ...sbom.ParseOption) sbom.Document {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+
+	doc, err := sbom.NewSBOMDocument(context.Background(), f, sbom.Signature{}, opts...)
+	require.NoError(t, err)
+	return doc
+}
+
+// emptyVersions is a baseline which knows every failure of a component
+// without a version.
+type emptyVersions struct{}
+
+func (emptyVersions) Suppressed(_ string, c sbom.GetComponent) bool {
+	return c.GetVersion() == ""
+}
+
+func TestStreamedScoresMatch(t *testing.T) {
+	for _, sample := range cdxJSONSamples {
+		t.Run(sample, func(t *testing.T) {
+			doc := parseSample(t, sample)
+			streamed := parseSample(t, sample, sbom.WithComponentStream(true))
+			require.NotNil(t, sbom.Streamed(streamed))
+			assert.Empty(t, streamed.Components())
+
+			sr := NewScorer(context.Background(), streamed)
+			assert.Equal(t, NewScorer(context.Background(), doc).Score(), sr.Score())
+			assert.NoError(t, sr.Err())
+			assert.Equal(t, len(doc.Components()), sbom.ComponentCount(streamed))
+
+			// every check which can be streamed, opt-in ones included
+			all := func(d sbom.Document) Scores {
+				sr := NewScorer(context.Background(), d)
+				for _, c := range checks {
+					if !wholeDocChecks[c.Key] {
+						sr.AddFilter(Filter{Name: c.Key, Ftype: Mix, Category: c.Category})
+					}
+				}
+				return sr.Score()
+			}
+			assert.Equal(t, all(doc), all(streamed))
+
+			baselined := func(d sbom.Document) Scores {
+				sr := NewScorer(context.Background(), d)
+				sr.SetBaseline(emptyVersions{})
+				return sr.Score()
+			}
+			assert.Equal(t, baselined(doc), baselined(streamed))
+		})
+	}
+}
+
+func TestStreamedWholeDocChecks(t *testing.T) {
+	for _, c := range checks {
+		if wholeDocChecks[c.Key] {
+			assert.True(t, c.Ignore, "%s has to be opt-in to be left out of streamed SBOMs", c.Key)
+		}
+	}
+
+	doc := parseSample(t, "../../samples/stree-cdxgen.cdx.json", sbom.WithComponentStream(true))
+	sr := NewScorer(context.Background(), doc)
+	sr.AddFilter(Filter{Name: "comp_reachable", Ftype: Mix, Category: string(semantic)})
+
+	scores := sr.Score().ScoreList()
+	require.Len(t, scores, 1)
+	assert.Equal(t, "N/A (not scored for a streamed SBOM)", scores[0].Descr())
+	assert.True(t, scores[0].Ignore())
+
+	assert.True(t, Streamable([]Filter{{Name: string(semantic), Ftype: Category}}))
+	assert.True(t, Streamable([]Filter{{Name: "comp_with_name", Ftype: Feature}}))
+	assert.False(t, Streamable([]Filter{{Name: "comp_reachable", Ftype: Feature}}))
+	assert.False(t, Streamable([]Filter{{Name: "custom", Ftype: Mix, custom: &check{}}}))
+}
@@ -65,6 +65,29 @@ func sbomFileFormatCheck(d sbom.Document, c *check) score {
 	return *s
 }
 
+// schemaCheckKey is the check that scores schema validation. It's only
+// scored for documents parsed with --strict, see Scorer.checks.
+const schemaCheckKey = "sbom_schema_valid"
+
+func specSchemaCheck(d sbom.Document, c *check) score {
+	s := newScoreFromCheck(c)
+
+	res := d.Schema()
+	if res == nil {
+		s.setDesc("N/A (not validated, run with --strict)")
+		s.setIgnore(true)
+		return *s
+	}
+
+	if res.Violations == 0 {
+		s.setScore(10.0)
+		s.setDesc(fmt.Sprintf("valid against the %s schema", res.Schema))
+	} else {
+		s.setDesc(fmt.Sprintf("%d violations of the %s schema", res.Violations, res.Schema))
+	}
+	return *s
+}
+
 func specParsableCheck(d sbom.Document, c *check) score {
 	s := newScoreFromCheck(c)
 	s.setDesc("provided sbom is parsable")
@@ -0,0 +1,59 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample string
+		strict bool
+		scored bool
+		want   float64
+	}{
+		{name: "not validated", sample: "../../samples/stree-cdxgen.cdx.json"},
+		{name: "valid", sample: "../../samples/stree-cdxgen.cdx.json", strict: true, scored: true, want: 10.0},
+		{name: "violations", sample: "../../samples/sbomqs-spdx-syft.json", strict: true, scored: true, want: 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.sample)
+			require.NoError(t, err)
+			defer f.Close()
+
+			doc, err := sbom.NewSBOMDocument(context.Background(), f, sbom.Signature{}, sbom.WithSchemaValidation(tt.strict))
+			require.NoError(t, err)
+
+			scored := false
+			for _, s := range NewScorer(context.Background(), doc).Score().ScoreList() {
+				if s.Feature() == schemaCheckKey {
+					scored = true
+					assert.Equal(t, tt.want, s.Score())
+				}
+			}
+			assert.Equal(t, tt.scored, scored)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import "fmt"
+
+// Thresholds holds the minimum scores a document has to reach. A minimum of
+// zero means the threshold isn't set.
+type Thresholds struct {
+	Overall    float64
+	Categories map[string]float64
+
+	// Features is keyed either by feature name, which applies in every
+	// category, or by "category/feature" to limit it to one category.
+	Features map[string]float64
+}
+
+// Breach describes a score that fell below its minimum.
+type Breach struct {
+	Kind  string // overall, category or feature
+	Name  string
+	Score float64
+	Min   float64
+}
+
+func (b Breach) String() string {
+	if b.Kind == "overall" {
+		return fmt.Sprintf("overall score %.2f is below %.2f", b.Score, b.Min)
+	}
+	return fmt.Sprintf("%s %s score %.2f is below %.2f", b.Kind, b.Name, b.Score, b.Min)
+}
+
+// IsZero reports whether no threshold is set.
+func (t Thresholds) IsZero() bool {
+	return t.Overall <= 0 && len(t.Categories) == 0 && len(t.Features) == 0
+}
+
+// Merge overlays o on top of t, values set in o take precedence.
+func (t *Thresholds) Merge(o Thresholds) {
+	if o.Overall > 0 {
+		t.Overall = o.Overall
+	}
+
+	for name, minimum := range o.Categories {
+		if t.Categories == nil {
+			t.Categories = make(map[string]float64)
+		}
+		t.Categories[name] = minimum
+	}
+
+	for name, minimum := range o.Features {
+		if t.Features == nil {
+			t.Features = make(map[string]float64)
+		}
+		t.Features[name] = minimum
+	}
+}
+
+// FeatureMin returns the minimum of feature in category, a minimum set for
+// "category/feature" takes precedence over one set for the feature.
+func (t Thresholds) FeatureMin(category, feature string) (float64, bool) {
+	if minimum, ok := t.Features[category+"/"+feature]; ok {
+		return minimum, true
+	}
+	minimum, ok := t.Features[feature]
+	return minimum, ok
+}
+
+// Check returns every threshold breached by s, overall first, followed by
+// categories and features in scoring order. Ignored features are skipped.
+func (t Thresholds) Check(s Scores) []Breach {
+	var breaches []Breach
+
+	if t.Overall > 0 && s.AvgScore() < t.Overall {
+		breaches = append(breaches, Breach{Kind: "overall", Score: s.AvgScore(), Min: t.Overall})
+	}
+
+	for _, c := range s.CategoryScores() {
+		if minimum, ok := t.Categories[c.Category()]; ok && c.Score() < minimum {
+			breaches = append(breaches, Breach{Kind: "category", Name: c.Category(), Score: c.Score(), Min: minimum})
+		}
+	}
+
+	for _, sc := range s.ScoreList() {
+		if sc.Ignore() {
+			continue
+		}
+
+		minimum, ok := t.FeatureMin(sc.Category(), sc.Feature())
+		if ok && sc.Score() < minimum {
+			breaches = append(breaches, Breach{
+				Kind:  "feature",
+				Name:  sc.Category() + "/" + sc.Feature(),
+				Score: sc.Score(),
+				Min:   minimum,
+			})
+		}
+	}
+
+	return breaches
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdsCheck(t *testing.T) {
+	s := newScores()
+	s.addScore(score{category: "Structural", feature: "sbom_spec", score: 10.0, weight: 1})
+	s.addScore(score{category: "Structural", feature: "sbom_file_format", score: 4.0, weight: 1})
+	s.addScore(score{category: "Sharing", feature: "sbom_sharable", score: 0.0, weight: 1})
+	s.addScore(score{category: "Sharing", feature: "comp_with_name", score: 0.0, weight: 1, ignore: true})
+
+	assert.Empty(t, Thresholds{}.Check(s))
+	assert.True(t, Thresholds{}.IsZero())
+
+	th := Thresholds{
+		Overall:    5,
+		Categories: map[string]float64{"Structural": 8, "Sharing": 0},
+		Features: map[string]float64{
+			"sbom_file_format":         5,
+			"Structural/sbom_spec":     10,
+			"Sharing/comp_with_name":   10,
+			"Structural/sbom_sharable": 10,
+		},
+	}
+
+	breaches := th.Check(s)
+	require.Len(t, breaches, 3)
+	assert.Equal(t, "overall", breaches[0].Kind)
+	assert.InDelta(t, 3.5, breaches[0].Score, 1e-9)
+	assert.Equal(t, Breach{Kind: "category", Name: "Structural", Score: 7, Min: 8}, breaches[1])
+	assert.Equal(t, Breach{Kind: "feature", Name: "Structural/sbom_file_format", Score: 4, Min: 5}, breaches[2])
+	assert.Equal(t, "feature Structural/sbom_file_format score 4.00 is below 5.00", breaches[2].String())
+}
+
+func TestThresholdsMerge(t *testing.T) {
+	th := Thresholds{Overall: 5, Categories: map[string]float64{"Structural": 8}}
+	th.Merge(Thresholds{Categories: map[string]float64{"Structural": 9}, Features: map[string]float64{"sbom_spec": 10}})
+
+	assert.InDelta(t, 5.0, th.Overall, 1e-9)
+	assert.Equal(t, map[string]float64{"Structural": 9}, th.Categories)
+	assert.Equal(t, map[string]float64{"sbom_spec": 10}, th.Features)
+}
+
+func TestThresholdsFeatureMin(t *testing.T) {
+	th := Thresholds{Features: map[string]float64{"comp_with_supplier": 5, "Structural/comp_with_supplier": 8}}
+
+	tests := []struct {
+		category, feature string
+		minimum           float64
+		ok                bool
+	}{
+		{"Structural", "comp_with_supplier", 8, true},
+		{"NTIA-minimum-elements", "comp_with_supplier", 5, true},
+		{"Structural", "comp_with_name", 0, false},
+	}
+
+	for _, tt := range tests {
+		minimum, ok := th.FeatureMin(tt.category, tt.feature)
+		assert.Equal(t, tt.ok, ok, tt.category+"/"+tt.feature)
+		assert.InDelta(t, tt.minimum, minimum, 1e-9, tt.category+"/"+tt.feature)
+	}
+}
+
+func TestReadThresholdsFile(t *testing.T) {
+	path := writeConfig(t, `
+min_score: 7.5
+categories:
+- name: Structural
+  min_score: 9
+  features:
+  - name: sbom_spec
+    min_score: 10
+  - name: sbom_file_format
+    ignore: true
+    min_score: 10
+`)
+
+	th, err := ReadThresholdsFile(path)
+	require.NoError(t, err)
+	assert.InDelta(t, 7.5, th.Overall, 1e-9)
+	assert.Equal(t, map[string]float64{"Structural": 9}, th.Categories)
+	assert.Equal(t, map[string]float64{"Structural/sbom_spec": 10}, th.Features)
+
+	path = writeConfig(t, `
+categories:
+- name: Structural
+  min_score: 11
+`)
+	_, err = ReadThresholdsFile(path)
+	assert.Error(t, err)
+}
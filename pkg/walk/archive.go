@@ -0,0 +1,374 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walk
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ArchiveSep separates the archive from the path of an entry inside it, as in
+// bundle.zip!/sboms/app.spdx.json.
+const ArchiveSep = "!/"
+
+// maxDecompressedSize guards against decompression bombs.
+const maxDecompressedSize = 1 << 30
+
+// compressed layers are peeled off up to this depth, e.g. a gzipped zstd file
+const maxCompressionLayers = 3
+
+var (
+	magicGzip  = []byte{0x1f, 0x8b}
+	magicBzip2 = []byte("BZh")
+	magicZstd  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	magicZip   = []byte("PK\x03\x04")
+	magicTar   = []byte("ustar")
+)
+
+const tarMagicOffset = 257
+
+// decompressor returns a reader decompressing r if head starts with the magic
+// bytes of a supported compression format, or nil.
+func decompressor(head []byte, r io.Reader) (io.Reader, error) {
+	switch {
+	case bytes.HasPrefix(head, magicGzip):
+		return gzip.NewReader(r)
+	case bytes.HasPrefix(head, magicBzip2):
+		return bzip2.NewReader(r), nil
+	case bytes.HasPrefix(head, magicZstd):
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	}
+	return nil, nil
+}
+
+// decompress peels off any compression layers from data.
+func decompress(data []byte) ([]byte, error) {
+	for i := 0; i < maxCompressionLayers; i++ {
+		r, err := decompressor(data, bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if r == nil {
+			return data, nil
+		}
+
+		out, err := io.ReadAll(io.LimitReader(r, maxDecompressedSize+1))
+		if c, ok := r.(io.Closer); ok {
+			c.Close()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress: %w", err)
+		}
+		if len(out) > maxDecompressedSize {
+			return nil, fmt.Errorf("decompressed size exceeds %d bytes", maxDecompressedSize)
+		}
+		data = out
+	}
+
+	return data, nil
+}
+
+// isCompressed reports whether head starts with the magic bytes of a
+// supported compression format.
+func isCompressed(head []byte) bool {
+	return bytes.HasPrefix(head, magicGzip) || bytes.HasPrefix(head, magicBzip2) || bytes.HasPrefix(head, magicZstd)
+}
+
+func isZip(head []byte) bool {
+	return bytes.HasPrefix(head, magicZip)
+}
+
+func isTar(head []byte) bool {
+	return len(head) >= tarMagicOffset+len(magicTar) &&
+		bytes.Equal(head[tarMagicOffset:tarMagicOffset+len(magicTar)], magicTar)
+}
+
+// isArchive reports whether the file at path is a zip or a, possibly
+// compressed, tar archive. Only the start of the file is read.
+func isArchive(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	var r io.Reader = bufio.NewReader(f)
+	for i := 0; i < maxCompressionLayers; i++ {
+		br := bufio.NewReaderSize(r, 512)
+		head, _ := br.Peek(512)
+
+		if isZip(head) || isTar(head) {
+			return true, nil
+		}
+
+		d, err := decompressor(head, br)
+		if err != nil || d == nil {
+			return false, nil
+		}
+		r = d
+	}
+
+	return false, nil
+}
+
+// errStopEntries ends eachEntry early without an error.
+var errStopEntries = errors.New("stop")
+
+// eachEntry calls fn with the name and contents of every regular file inside
+// the archive in data, in archive order, until fn returns an error.
+func eachEntry(data []byte, fn func(name string, r io.Reader) error) error {
+	err := walkEntries(data, fn)
+	if errors.Is(err, errStopEntries) {
+		return nil
+	}
+	return err
+}
+
+func walkEntries(data []byte, fn func(name string, r io.Reader) error) error {
+	switch {
+	case isZip(data):
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return err
+		}
+		for _, f := range zr.File {
+			if !f.Mode().IsRegular() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return err
+			}
+			err = fn(cleanEntry(f.Name), rc)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+	case isTar(data):
+		tr := tar.NewReader(bytes.NewReader(data))
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			if err := fn(cleanEntry(hdr.Name), tr); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("not a zip or tar archive")
+	}
+
+	return nil
+}
+
+// archiveEntries lists the regular files inside the archive in data for
+// which keep returns true, in lexical order.
+func archiveEntries(data []byte, keep func(name string, r io.Reader) bool) ([]string, error) {
+	var names []string
+
+	err := eachEntry(data, func(name string, r io.Reader) error {
+		if keep == nil || keep(name, r) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// archiveEntry returns the contents of the entry called name.
+func archiveEntry(data []byte, name string) ([]byte, error) {
+	entries, err := readEntries(data, map[string]bool{name: true})
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := entries[name]
+	if !ok {
+		return nil, fmt.Errorf("entry %s not found in archive", name)
+	}
+	return entry, nil
+}
+
+// readEntries returns the contents of the entries named in want, in a single
+// pass over the archive.
+func readEntries(data []byte, want map[string]bool) (map[string][]byte, error) {
+	entries := make(map[string][]byte, len(want))
+
+	err := eachEntry(data, func(name string, r io.Reader) error {
+		if !want[name] {
+			return nil
+		}
+		if _, ok := entries[name]; ok {
+			return nil
+		}
+
+		entry, err := readLimited(r)
+		if err != nil {
+			return err
+		}
+		entries[name] = entry
+
+		if len(entries) == len(want) {
+			return errStopEntries
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxDecompressedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDecompressedSize {
+		return nil, fmt.Errorf("entry size exceeds %d bytes", maxDecompressedSize)
+	}
+	return data, nil
+}
+
+func cleanEntry(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// splitArchivePath splits name into the archive and the entry inside it. ok
+// is false if name doesn't refer to an archive entry, or if a file with that
+// exact name exists.
+func splitArchivePath(name string) (archive, entry string, ok bool) {
+	i := strings.Index(name, ArchiveSep)
+	if i <= 0 {
+		return "", "", false
+	}
+	if _, err := os.Stat(name); err == nil {
+		return "", "", false
+	}
+	return name[:i], name[i+len(ArchiveSep):], true
+}
+
+// archives holds the entries of the archives listed by Paths until they are
+// read, so an archive is decompressed once however many of its entries are
+// scored, and by however many workers.
+var archives = struct {
+	sync.Mutex
+	m map[string]*cachedArchive
+}{m: make(map[string]*cachedArchive)}
+
+type cachedArchive struct {
+	once sync.Once
+	err  error
+
+	mu      sync.Mutex
+	pending map[string]bool
+	entries map[string][]byte
+}
+
+// expectEntries records that the entries called names of archive are going
+// to be read.
+func expectEntries(archive string, names []string) {
+	pending := make(map[string]bool, len(names))
+	for _, n := range names {
+		pending[n] = true
+	}
+
+	archives.Lock()
+	defer archives.Unlock()
+	archives.m[archive] = &cachedArchive{pending: pending}
+}
+
+// takeEntry returns the contents of entry if it was listed by Paths and hasn't
+// been read yet. The first entry taken decompresses the archive and keeps the
+// other listed entries, each is released once it has been taken. ok is false
+// if the entry isn't cached, and the caller has to read it from the archive.
+func takeEntry(archive, entry string) (data []byte, ok bool, err error) {
+	archives.Lock()
+	ca := archives.m[archive]
+	archives.Unlock()
+
+	if ca == nil {
+		return nil, false, nil
+	}
+
+	ca.mu.Lock()
+	listed := ca.pending[entry]
+	ca.mu.Unlock()
+	if !listed {
+		return nil, false, nil
+	}
+
+	ca.once.Do(func() {
+		var data []byte
+		if data, ca.err = readDecompressed(archive); ca.err == nil {
+			ca.entries, ca.err = readEntries(data, ca.pending)
+		}
+	})
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	data, found := ca.entries[entry]
+	delete(ca.pending, entry)
+	delete(ca.entries, entry)
+
+	if len(ca.pending) == 0 {
+		archives.Lock()
+		if archives.m[archive] == ca {
+			delete(archives.m, archive)
+		}
+		archives.Unlock()
+	}
+
+	if ca.err != nil {
+		return nil, true, ca.err
+	}
+	if !found {
+		return nil, true, fmt.Errorf("entry %s not found in archive", entry)
+	}
+	return data, true, nil
+}
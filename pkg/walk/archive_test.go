@@ -0,0 +1,227 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walk
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bzip2 of "hello sbom", the standard library can't compress bzip2
+const bzip2Hello = "QlpoOTFBWSZTWTQz/UYAAAGRgEAAEkaIACAAIgMahDAiDIPA8XckU4UJA0M/1GA="
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func zstdBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer enc.Close()
+	return enc.EncodeAll(data, nil)
+}
+
+func zipBytes(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	_, err := zw.Create("sboms/")
+	require.NoError(t, err)
+	for name, data := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func tarBytes(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "./sboms/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for name, data := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "./" + name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(data))}))
+		_, err := tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func TestDecompress(t *testing.T) {
+	hello := []byte("hello sbom")
+	bz, err := base64.StdEncoding.DecodeString(bzip2Hello)
+	require.NoError(t, err)
+
+	for name, data := range map[string][]byte{
+		"plain":     hello,
+		"gzip":      gzipBytes(t, hello),
+		"bzip2":     bz,
+		"zstd":      zstdBytes(t, hello),
+		"zstd+gzip": gzipBytes(t, zstdBytes(t, hello)),
+	} {
+		out, err := decompress(data)
+		require.NoError(t, err, name)
+		assert.Equal(t, hello, out, name)
+	}
+
+	_, err = decompress(append([]byte{0x1f, 0x8b}, []byte("not really gzip")...))
+	assert.Error(t, err)
+}
+
+func TestArchivePaths(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"sboms/a.spdx.json":    []byte("spdx"),
+		"sboms/b.cdx.json.zst": zstdBytes(t, []byte("cdx")),
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bundle.zip"), zipBytes(t, files), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bundle.tar.gz"), gzipBytes(t, tarBytes(t, files)), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "single.json.gz"), gzipBytes(t, []byte("single")), 0o600))
+
+	paths, err := Paths(context.Background(), dir, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"bundle.tar.gz!/sboms/a.spdx.json",
+		"bundle.tar.gz!/sboms/b.cdx.json.zst",
+		"bundle.zip!/sboms/a.spdx.json",
+		"bundle.zip!/sboms/b.cdx.json.zst",
+		"single.json.gz",
+	}, relPaths(t, dir, paths))
+
+	want := map[string]string{
+		"bundle.tar.gz!/sboms/a.spdx.json":    "spdx",
+		"bundle.tar.gz!/sboms/b.cdx.json.zst": "cdx",
+		"bundle.zip!/sboms/a.spdx.json":       "spdx",
+		"bundle.zip!/sboms/b.cdx.json.zst":    "cdx",
+		"single.json.gz":                      "single",
+	}
+	for _, p := range paths {
+		data, err := ReadFile(p)
+		require.NoError(t, err, p)
+		rel, _ := filepath.Rel(dir, p)
+		assert.Equal(t, want[filepath.ToSlash(rel)], string(data), p)
+	}
+
+	_, err = ReadFile(filepath.Join(dir, "bundle.zip") + ArchiveSep + "missing.json")
+	assert.Error(t, err)
+}
+
+func TestArchiveReadOnce(t *testing.T) {
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "bundle.tar.gz")
+	require.NoError(t, os.WriteFile(bundle, gzipBytes(t, tarBytes(t, map[string][]byte{
+		"sboms/a.spdx.json": []byte("a"),
+		"sboms/b.spdx.json": []byte("b"),
+		"sboms/c.spdx.json": []byte("c"),
+	})), 0o600))
+
+	paths, err := Paths(context.Background(), bundle, Options{})
+	require.NoError(t, err)
+	require.Len(t, paths, 3)
+
+	data, err := ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+
+	// the other entries were kept when the archive was first decompressed
+	require.NoError(t, os.Remove(bundle))
+	for i, want := range []string{"b", "c"} {
+		data, err := ReadFile(paths[i+1])
+		require.NoError(t, err)
+		assert.Equal(t, want, string(data))
+	}
+
+	archives.Lock()
+	defer archives.Unlock()
+	assert.NotContains(t, archives.m, bundle, "released once every entry is read")
+}
+
+func TestArchiveMembersFiltered(t *testing.T) {
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "bundle.zip")
+	require.NoError(t, os.WriteFile(bundle, zipBytes(t, map[string][]byte{
+		"README.md":             []byte("# release bundle"),
+		"LICENSE":               []byte("Apache License"),
+		"sboms/app.json":        []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5"}`),
+		"sboms/lib.spdx.json":   []byte("broken"),
+		"testdata/x.spdx.json":  []byte("fixture"),
+		"sboms/notes/info.json": []byte(`{"note": true}`),
+	}), 0o600))
+
+	paths, err := Paths(context.Background(), dir, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"bundle.zip!/sboms/app.json",
+		"bundle.zip!/sboms/lib.spdx.json",
+		"bundle.zip!/testdata/x.spdx.json",
+	}, relPaths(t, dir, paths))
+
+	paths, err = Paths(context.Background(), dir, Options{Include: []string{"*.spdx.json"}, Exclude: []string{"testdata"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bundle.zip!/sboms/lib.spdx.json"}, relPaths(t, dir, paths))
+
+	paths, err = Paths(context.Background(), bundle, Options{Exclude: []string{"sboms/**"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bundle.zip!/testdata/x.spdx.json"}, relPaths(t, dir, paths))
+}
+
+func TestOpenFile(t *testing.T) {
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "sbom.json")
+	require.NoError(t, os.WriteFile(plain, []byte(`{"bomFormat": "CycloneDX"}`), 0o600))
+	gz := filepath.Join(dir, "sbom.json.gz")
+	require.NoError(t, os.WriteFile(gz, gzipBytes(t, []byte(`{}`)), 0o600))
+
+	f, ok, err := OpenFile(plain)
+	require.NoError(t, err)
+	require.True(t, ok)
+	data, err := io.ReadAll(f)
+	f.Close()
+	require.NoError(t, err)
+	assert.Equal(t, `{"bomFormat": "CycloneDX"}`, string(data), "read from the start")
+
+	for _, name := range []string{gz, Stdin, "bundle.zip" + ArchiveSep + "sbom.json", OCILayoutPrefix + dir} {
+		_, ok, err := OpenFile(name)
+		assert.NoError(t, err, name)
+		assert.False(t, ok, name)
+	}
+
+	_, _, err = OpenFile(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,339 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walk
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// OCILayoutPrefix marks a path as a local OCI image layout, as in
+// oci-layout:/path/to/layout or oci-layout:/path/to/layout:tag.
+const OCILayoutPrefix = "oci-layout:"
+
+const (
+	ociLayoutFile = "oci-layout"
+	ociIndexFile  = "index.json"
+
+	ociRefNameAnnotation   = "org.opencontainers.image.ref.name"
+	ociTitleAnnotation     = "org.opencontainers.image.title"
+	ociPredicateAnnotation = "predicateType"
+)
+
+var ociDigest = regexp.MustCompile(`^([a-z0-9]+(?:[+._-][a-z0-9]+)*):([a-zA-Z0-9=_-]+)$`)
+
+type ociDescriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType"`
+	Digest       string            `json:"digest"`
+	Annotations  map[string]string `json:"annotations"`
+}
+
+// ociManifest covers image indexes, image manifests and the older artifact
+// manifests, which list their content as blobs rather than layers.
+type ociManifest struct {
+	MediaType    string          `json:"mediaType"`
+	ArtifactType string          `json:"artifactType"`
+	Config       *ociDescriptor  `json:"config"`
+	Layers       []ociDescriptor `json:"layers"`
+	Blobs        []ociDescriptor `json:"blobs"`
+	Manifests    []ociDescriptor `json:"manifests"`
+	Subject      *ociDescriptor  `json:"subject"`
+}
+
+// ociEntry is an SBOM blob found in a layout, with the digest of the image
+// it belongs to and the name it is listed under.
+type ociEntry struct {
+	image string
+	name  string
+	blob  ociDescriptor
+}
+
+type ociLayout struct {
+	dir string
+	tag string
+}
+
+// parseOCILayout splits oci-layout:dir[:tag] into the layout directory and
+// the tag. The whole reference is tried as a directory first, so layouts in
+// directories with a colon in their name still work.
+func parseOCILayout(ref string) (ociLayout, error) {
+	p := strings.TrimPrefix(ref, OCILayoutPrefix)
+
+	if isOCILayout(p) {
+		return ociLayout{dir: p}, nil
+	}
+
+	if i := strings.LastIndex(p, ":"); i > 0 && !strings.Contains(p[i+1:], "/") {
+		if isOCILayout(p[:i]) {
+			return ociLayout{dir: p[:i], tag: p[i+1:]}, nil
+		}
+	}
+
+	return ociLayout{}, fmt.Errorf("%s is not an OCI image layout", p)
+}
+
+func isOCILayout(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, ociLayoutFile))
+	return err == nil && info.Mode().IsRegular()
+}
+
+// ociPaths returns one path per SBOM in the layout referenced by ref, named
+// oci-layout:dir@image!/entry.
+func ociPaths(ref string) ([]string, error) {
+	l, err := parseOCILayout(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := l.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(entries))
+	for _, e := range entries {
+		paths = append(paths, OCILayoutPrefix+l.dir+"@"+e.image+ArchiveSep+e.name)
+	}
+	return paths, nil
+}
+
+// readOCIEntry reads an SBOM listed by ociPaths.
+func readOCIEntry(name string) ([]byte, error) {
+	p := strings.TrimPrefix(name, OCILayoutPrefix)
+
+	i := strings.LastIndex(p, ArchiveSep)
+	j := strings.LastIndex(p[:max(i, 0)], "@")
+	if i < 0 || j < 0 {
+		return nil, fmt.Errorf("%s doesn't name an SBOM in an OCI image layout", name)
+	}
+	l := ociLayout{dir: p[:j]}
+	image, entry := p[j+1:i], p[i+len(ArchiveSep):]
+
+	if ociDigest.MatchString(entry) {
+		return l.readBlob(entry)
+	}
+
+	entries, err := l.entries()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.image == image && e.name == entry {
+			return l.readBlob(e.blob.Digest)
+		}
+	}
+	return nil, fmt.Errorf("%s not found in OCI image layout %s", entry, l.dir)
+}
+
+// entries finds the SBOMs in the layout, restricted to the tagged image if
+// a tag is set. SBOMs are found as layers of artifacts whose artifact type
+// is an SBOM type, as layers with an SBOM media type in any manifest, and
+// as attestation layers with an SBOM predicate type. Each is attributed to
+// the image its manifest is attached to through its subject, or else to the
+// top level manifest it was found under.
+func (l ociLayout) entries() ([]ociEntry, error) {
+	var index ociManifest
+	if err := l.readJSON(filepath.Join(l.dir, ociIndexFile), &index); err != nil {
+		return nil, err
+	}
+
+	roots := index.Manifests
+	if l.tag != "" {
+		roots = nil
+		for _, d := range index.Manifests {
+			if d.Annotations[ociRefNameAnnotation] == l.tag {
+				roots = append(roots, d)
+			}
+		}
+		if len(roots) == 0 {
+			return nil, fmt.Errorf("tag %s not found in OCI image layout %s", l.tag, l.dir)
+		}
+	}
+
+	v := &ociVisit{seen: map[string]bool{}, images: map[string]bool{}}
+	for _, d := range roots {
+		if err := l.visit(d, "", v); err != nil {
+			return nil, err
+		}
+	}
+
+	// Referrers of the tagged image are stored as manifests of their own,
+	// so look through the rest of the index for ones attached to it.
+	if l.tag != "" {
+		rest := &ociVisit{seen: v.seen, images: map[string]bool{}}
+		for _, d := range index.Manifests {
+			if err := l.visit(d, "", rest); err != nil {
+				return nil, err
+			}
+		}
+		for _, e := range rest.entries {
+			if v.images[e.image] {
+				v.entries = append(v.entries, e)
+			}
+		}
+	}
+
+	return nameEntries(v.entries), nil
+}
+
+type ociVisit struct {
+	seen    map[string]bool
+	images  map[string]bool
+	entries []ociEntry
+}
+
+func (l ociLayout) visit(d ociDescriptor, image string, v *ociVisit) error {
+	if v.seen[d.Digest] {
+		return nil
+	}
+	v.seen[d.Digest] = true
+
+	var m ociManifest
+	if err := l.readJSON(l.blobPath(d.Digest), &m); err != nil {
+		return err
+	}
+
+	if m.Subject != nil {
+		image = m.Subject.Digest
+	} else if image == "" {
+		image = d.Digest
+	}
+	v.images[d.Digest] = true
+
+	for _, child := range m.Manifests {
+		if err := l.visit(child, image, v); err != nil {
+			return err
+		}
+	}
+
+	artifact := isSBOMMediaType(m.ArtifactType) || isSBOMMediaType(d.ArtifactType) ||
+		(m.Config != nil && isSBOMMediaType(m.Config.MediaType))
+
+	for _, b := range append(m.Layers, m.Blobs...) {
+		if artifact || isSBOMMediaType(b.MediaType) || isSBOMMediaType(b.Annotations[ociPredicateAnnotation]) {
+			v.entries = append(v.entries, ociEntry{image: image, blob: b})
+		}
+	}
+	return nil
+}
+
+// nameEntries drops duplicate blobs and names each entry after its title
+// annotation, falling back to the blob digest when there is no title or
+// another blob of the same image already has it.
+func nameEntries(entries []ociEntry) []ociEntry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.image != b.image {
+			return a.image < b.image
+		}
+		if ta, tb := title(a.blob), title(b.blob); ta != tb {
+			return ta < tb
+		}
+		return a.blob.Digest < b.blob.Digest
+	})
+
+	named := make([]ociEntry, 0, len(entries))
+	seen := map[string]bool{}
+	for _, e := range entries {
+		if seen[e.image+" "+e.blob.Digest] {
+			continue
+		}
+		seen[e.image+" "+e.blob.Digest] = true
+
+		e.name = title(e.blob)
+		if e.name == "" || seen[e.image+"/"+e.name] {
+			e.name = e.blob.Digest
+		}
+		seen[e.image+"/"+e.name] = true
+		named = append(named, e)
+	}
+	return named
+}
+
+func title(d ociDescriptor) string {
+	t := path.Base(path.Clean("/" + d.Annotations[ociTitleAnnotation]))
+	if t == "/" || ociDigest.MatchString(t) {
+		return ""
+	}
+	return t
+}
+
+func isSBOMMediaType(mediaType string) bool {
+	mt := strings.ToLower(mediaType)
+	return strings.Contains(mt, "spdx") || strings.Contains(mt, "cyclonedx")
+}
+
+func (l ociLayout) blobPath(digest string) string {
+	m := ociDigest.FindStringSubmatch(digest)
+	if m == nil {
+		return ""
+	}
+	return filepath.Join(l.dir, "blobs", m[1], m[2])
+}
+
+// readBlob reads a blob and checks it against its digest.
+func (l ociLayout) readBlob(digest string) ([]byte, error) {
+	p := l.blobPath(digest)
+	if p == "" {
+		return nil, fmt.Errorf("invalid digest %q", digest)
+	}
+
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var h hash.Hash
+	switch {
+	case strings.HasPrefix(digest, "sha256:"):
+		h = sha256.New()
+	case strings.HasPrefix(digest, "sha512:"):
+		h = sha512.New()
+	}
+	if h != nil {
+		h.Write(data)
+		if got := hex.EncodeToString(h.Sum(nil)); got != digest[strings.Index(digest, ":")+1:] {
+			return nil, fmt.Errorf("blob %s doesn't match its digest", digest)
+		}
+	}
+
+	return data, nil
+}
+
+func (l ociLayout) readJSON(p string, v any) error {
+	if p == "" {
+		return fmt.Errorf("invalid digest in OCI image layout %s", l.dir)
+	}
+
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", p, err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLayout struct {
+	t   *testing.T
+	dir string
+}
+
+func newTestLayout(t *testing.T) *testLayout {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ociLayoutFile), []byte(`{"imageLayoutVersion": "1.0.0"}`), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0o755))
+	return &testLayout{t: t, dir: dir}
+}
+
+// blob stores data and returns its digest.
+func (l *testLayout) blob(data []byte) string {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	require.NoError(l.t, os.WriteFile(filepath.Join(l.dir, "blobs", "sha256", digest), data, 0o600))
+	return "sha256:" + digest
+}
+
+func (l *testLayout) manifest(m any) string {
+	data, err := json.Marshal(m)
+	require.NoError(l.t, err)
+	return l.blob(data)
+}
+
+func (l *testLayout) index(manifests ...ociDescriptor) {
+	data, err := json.Marshal(ociManifest{MediaType: "application/vnd.oci.image.index.v1+json", Manifests: manifests})
+	require.NoError(l.t, err)
+	require.NoError(l.t, os.WriteFile(filepath.Join(l.dir, ociIndexFile), data, 0o600))
+}
+
+func TestOCILayout(t *testing.T) {
+	l := newTestLayout(t)
+	layer := ociDescriptor{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: l.blob([]byte("layer"))}
+	config := &ociDescriptor{MediaType: "application/vnd.oci.image.config.v1+json", Digest: l.blob([]byte("{}"))}
+
+	app := l.manifest(ociManifest{Config: config, Layers: []ociDescriptor{layer}})
+	base := l.manifest(ociManifest{Config: config, Layers: []ociDescriptor{layer, {MediaType: "application/vnd.oci.image.layer.v1.tar", Digest: l.blob([]byte("other"))}}})
+
+	spdx := []byte(`{"spdxVersion": "SPDX-2.3"}`)
+	cdx := []byte(`{"bomFormat": "CycloneDX"}`)
+
+	// an oras style referrer, with the SBOM as a generic layer
+	sbomArtifact := l.manifest(ociManifest{
+		ArtifactType: "application/spdx+json",
+		Config:       &ociDescriptor{MediaType: "application/vnd.oci.empty.v1+json", Digest: l.blob([]byte("{}"))},
+		Layers: []ociDescriptor{{
+			MediaType:   "application/vnd.oci.image.layer.v1.tar",
+			Digest:      l.blob(spdx),
+			Annotations: map[string]string{ociTitleAnnotation: "app.spdx.json"},
+		}},
+		Subject: &ociDescriptor{Digest: app},
+	})
+	// a cosign style attestation on the base image
+	attestation := l.manifest(ociManifest{
+		Config: config,
+		Layers: []ociDescriptor{{
+			MediaType:   "application/vnd.dsse.envelope.v1+json",
+			Digest:      l.blob(cdx),
+			Annotations: map[string]string{ociPredicateAnnotation: "https://cyclonedx.org/bom"},
+		}},
+		Subject: &ociDescriptor{Digest: base},
+	})
+	// a signature, which isn't an SBOM
+	signature := l.manifest(ociManifest{
+		ArtifactType: "application/vnd.dev.cosign.artifact.sig.v1+json",
+		Layers:       []ociDescriptor{{MediaType: "application/vnd.dev.cosign.simplesigning.v1+json", Digest: l.blob([]byte("sig"))}},
+		Subject:      &ociDescriptor{Digest: app},
+	})
+
+	l.index(
+		ociDescriptor{Digest: app, Annotations: map[string]string{ociRefNameAnnotation: "v1"}},
+		ociDescriptor{Digest: base, Annotations: map[string]string{ociRefNameAnnotation: "base"}},
+		ociDescriptor{Digest: sbomArtifact, ArtifactType: "application/spdx+json"},
+		ociDescriptor{Digest: attestation},
+		ociDescriptor{Digest: signature},
+	)
+
+	ref := OCILayoutPrefix + l.dir
+	appSBOM := ref + "@" + app + ArchiveSep + "app.spdx.json"
+	baseSBOM := ref + "@" + base + ArchiveSep + l.blob(cdx)
+
+	paths, err := Paths(context.Background(), ref, Options{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{appSBOM, baseSBOM}, paths)
+
+	paths, err = Paths(context.Background(), ref+":v1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{appSBOM}, paths)
+
+	data, err := ReadFile(appSBOM)
+	require.NoError(t, err)
+	assert.Equal(t, spdx, data)
+
+	data, err = ReadFile(baseSBOM)
+	require.NoError(t, err)
+	assert.Equal(t, cdx, data)
+
+	_, err = Paths(context.Background(), ref+":v2", Options{})
+	assert.ErrorContains(t, err, "tag v2 not found")
+
+	_, err = Paths(context.Background(), OCILayoutPrefix+t.TempDir(), Options{})
+	assert.ErrorContains(t, err, "not an OCI image layout")
+
+	_, err = ReadFile(ref + "@" + app + ArchiveSep + "missing.json")
+	assert.Error(t, err)
+
+	// a blob that doesn't match its digest is rejected
+	require.NoError(t, os.WriteFile(filepath.Join(l.dir, "blobs", "sha256", l.blob(spdx)[len("sha256:"):]), []byte("tampered"), 0o600))
+	_, err = ReadFile(appSBOM)
+	assert.ErrorContains(t, err, "doesn't match its digest")
+}
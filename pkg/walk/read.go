@@ -0,0 +1,123 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walk
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Stdin is the path given on the command line to read an SBOM from standard
+// input.
+const Stdin = "-"
+
+var (
+	stdinOnce sync.Once
+	stdinData []byte
+	stdinErr  error
+
+	// stdinReader is replaced in tests
+	stdinReader io.Reader = os.Stdin
+)
+
+// ReadFile returns the contents of name, or of standard input when name is
+// Stdin. Standard input is buffered on first use, later calls return the
+// same bytes. Compressed data is transparently decompressed, and a name of
+// the form archive!/entry reads the entry from a zip or tar archive, which is
+// decompressed once for all the entries listed by Paths, and
+// names listed by Paths for an OCI image layout read the SBOM blob.
+func ReadFile(name string) ([]byte, error) {
+	if strings.HasPrefix(name, OCILayoutPrefix) {
+		data, err := readOCIEntry(name)
+		if err != nil {
+			return nil, err
+		}
+		return decompress(data)
+	}
+
+	if archive, entry, ok := splitArchivePath(name); ok {
+		data, cached, err := takeEntry(archive, entry)
+		if !cached {
+			if data, err = readDecompressed(archive); err != nil {
+				return nil, err
+			}
+			data, err = archiveEntry(data, entry)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		return decompress(data)
+	}
+
+	return readDecompressed(name)
+}
+
+// OpenFile opens name to be read in place, when it is a local file that
+// isn't compressed. ok is false for standard input, archive entries, OCI
+// image layouts and compressed files, which have to be read with ReadFile.
+func OpenFile(name string) (f *os.File, ok bool, err error) {
+	if name == Stdin || strings.HasPrefix(name, OCILayoutPrefix) {
+		return nil, false, nil
+	}
+	if _, _, entry := splitArchivePath(name); entry {
+		return nil, false, nil
+	}
+
+	f, err = os.Open(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	head := make([]byte, 4)
+	n, _ := io.ReadFull(f, head)
+	if isCompressed(head[:n]) {
+		f.Close()
+		return nil, false, nil
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, false, err
+	}
+
+	return f, true, nil
+}
+
+func readDecompressed(name string) ([]byte, error) {
+	data, err := readRaw(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return decompress(data)
+}
+
+func readRaw(name string) ([]byte, error) {
+	if name != Stdin {
+		return os.ReadFile(name)
+	}
+
+	stdinOnce.Do(func() {
+		stdinData, stdinErr = io.ReadAll(stdinReader)
+		if stdinErr == nil && len(stdinData) == 0 {
+			stdinErr = fmt.Errorf("no data on standard input")
+		}
+	})
+
+	return stdinData, stdinErr
+}
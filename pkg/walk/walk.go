@@ -0,0 +1,333 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package walk expands the paths given on the command line into the list of
+// SBOM files to process.
+package walk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/interlynk-io/sbomqs/pkg/logger"
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"go.uber.org/zap"
+)
+
+// Options controls how directories are expanded.
+type Options struct {
+	// Recurse descends into subdirectories, including symlinked ones.
+	Recurse bool
+
+	// Include and Exclude are glob patterns matched against the slash
+	// separated path relative to the directory being walked. Patterns
+	// without a slash match the base name only. "**" matches any number of
+	// directories. When Include is set a file has to match one of its
+	// patterns, directories matching Exclude are skipped entirely. The
+	// members of an archive are matched by their path inside the archive.
+	Include []string
+	Exclude []string
+}
+
+// Paths returns the files to process for root. A file, or Stdin, is returned
+// as is, a directory is expanded according to opts. Zip and tar archives,
+// compressed or not, are expanded into one path per entry, named
+// archive!/entry. A root starting with OCILayoutPrefix is expanded into the
+// SBOMs stored in that OCI image layout. The result is in lexical order and
+// never lists the same directory twice, so symlink loops are harmless.
+func Paths(ctx context.Context, root string, opts Options) ([]string, error) {
+	log := logger.FromContext(ctx)
+
+	if strings.HasPrefix(root, OCILayoutPrefix) {
+		return ociPaths(root)
+	}
+
+	w := &walker{
+		log:     log,
+		recurse: opts.Recurse,
+		visited: make(map[string]bool),
+	}
+
+	var err error
+	if w.include, err = compileGlobs(opts.Include); err != nil {
+		return nil, err
+	}
+	if w.exclude, err = compileGlobs(opts.Exclude); err != nil {
+		return nil, err
+	}
+
+	if root == Stdin {
+		w.addFile(Stdin)
+		return w.paths, nil
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat path %s: %w", root, err)
+	}
+
+	if !info.IsDir() {
+		w.addFile(root)
+		return w.paths, nil
+	}
+
+	if err := w.walkDir(root, ""); err != nil {
+		return nil, err
+	}
+
+	return w.paths, nil
+}
+
+type walker struct {
+	log     *zap.SugaredLogger
+	recurse bool
+	include []glob
+	exclude []glob
+	visited map[string]bool
+	paths   []string
+}
+
+func (w *walker) walkDir(dir, rel string) error {
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve directory %s: %w", dir, err)
+	}
+	if resolved, err = filepath.Abs(resolved); err != nil {
+		return fmt.Errorf("failed to resolve directory %s: %w", dir, err)
+	}
+
+	if w.visited[resolved] {
+		w.log.Debugf("skipping %s, already visited as %s", dir, resolved)
+		return nil
+	}
+	w.visited[resolved] = true
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		r := path.Join(rel, entry.Name())
+
+		// stat follows symlinks, so linked files and directories are treated
+		// like their targets
+		info, err := os.Stat(p)
+		if err != nil {
+			w.log.Debugf("skipping %s: %v", p, err)
+			continue
+		}
+
+		if matchAny(w.exclude, r) {
+			w.log.Debugf("skipping %s, excluded", p)
+			continue
+		}
+
+		if info.IsDir() {
+			if !w.recurse {
+				continue
+			}
+			if err := w.walkDir(p, r); err != nil {
+				w.log.Debugf("%v", err)
+			}
+			continue
+		}
+
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		// include patterns pick the members of an archive, not the archive
+		if len(w.include) > 0 && !matchAny(w.include, r) && !w.isArchive(p) {
+			continue
+		}
+
+		w.addFile(p)
+	}
+
+	return nil
+}
+
+// addFile adds p, or the entries of p if it is an archive.
+func (w *walker) addFile(p string) {
+	if !w.isArchive(p) {
+		w.paths = append(w.paths, p)
+		return
+	}
+
+	data, err := readDecompressed(p)
+	if err == nil {
+		var entries []string
+		if entries, err = archiveEntries(data, w.keepEntry); err == nil {
+			for _, e := range entries {
+				w.paths = append(w.paths, p+ArchiveSep+e)
+			}
+			expectEntries(p, entries)
+			return
+		}
+	}
+
+	// leave it to the caller to report the broken archive
+	w.log.Debugf("failed to list archive %s: %v", p, err)
+	w.paths = append(w.paths, p)
+}
+
+// keepEntry matches the include and exclude patterns against the path of an
+// archive member inside the archive, and leaves out members which aren't
+// SBOMs, such as a README or LICENSE.
+func (w *walker) keepEntry(name string, r io.Reader) bool {
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if matchAny(w.exclude, dir) {
+			return false
+		}
+	}
+	if matchAny(w.exclude, name) {
+		return false
+	}
+
+	if len(w.include) > 0 && !matchAny(w.include, name) {
+		return false
+	}
+
+	if !isSBOM(name, r) {
+		w.log.Debugf("skipping archive member %s, not an SBOM", name)
+		return false
+	}
+	return true
+}
+
+// sbomNameMarkers are looked for in the base name of an archive member, so
+// a broken SBOM is still reported rather than skipped.
+var sbomNameMarkers = []string{"bom", "spdx", "cdx", "cyclonedx"}
+
+// isSBOM reports whether the archive member called name, with the contents
+// in r, is an SBOM, going by its name or else by its contents.
+func isSBOM(name string, r io.Reader) bool {
+	base := strings.ToLower(path.Base(name))
+	for _, m := range sbomNameMarkers {
+		if strings.Contains(base, m) {
+			return true
+		}
+	}
+
+	data, err := readLimited(r)
+	if err != nil {
+		return false
+	}
+	if data, err = decompress(data); err != nil {
+		return false
+	}
+	return sbom.DetectSpec(data) != sbom.SBOMSpecUnknown
+}
+
+func (w *walker) isArchive(p string) bool {
+	if p == Stdin {
+		data, err := readDecompressed(Stdin)
+		return err == nil && (isZip(data) || isTar(data))
+	}
+
+	ok, err := isArchive(p)
+	if err != nil {
+		w.log.Debugf("failed to inspect %s: %v", p, err)
+	}
+	return ok
+}
+
+type glob struct {
+	re       *regexp.Regexp
+	baseOnly bool
+}
+
+func compileGlobs(patterns []string) ([]glob, error) {
+	globs := make([]glob, 0, len(patterns))
+
+	for _, p := range patterns {
+		p = strings.TrimSpace(filepath.ToSlash(p))
+		if p == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(globToRegexp(strings.TrimPrefix(p, "./")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w", p, err)
+		}
+
+		globs = append(globs, glob{re: re, baseOnly: !strings.Contains(p, "/")})
+	}
+
+	return globs, nil
+}
+
+func matchAny(globs []glob, rel string) bool {
+	for _, g := range globs {
+		name := rel
+		if g.baseOnly {
+			name = path.Base(rel)
+		}
+		if g.re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp translates a glob into an anchored regular expression.
+func globToRegexp(pattern string) string {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+	return b.String()
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walk
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+
+	for _, f := range []string{
+		"top.json",
+		"build/a/sbom/a.spdx.json",
+		"build/a/sbom/notes.txt",
+		"build/b/sbom/b.cdx.json",
+		"build/b/testdata/fixture.json",
+	} {
+		p := filepath.Join(root, filepath.FromSlash(f))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte("{}"), 0o600))
+	}
+
+	// a link back to the root must not loop forever
+	require.NoError(t, os.Symlink(root, filepath.Join(root, "build", "a", "loop")))
+
+	return root
+}
+
+func relPaths(t *testing.T, root string, paths []string) []string {
+	t.Helper()
+	rel := make([]string, 0, len(paths))
+	for _, p := range paths {
+		r, err := filepath.Rel(root, p)
+		require.NoError(t, err)
+		rel = append(rel, filepath.ToSlash(r))
+	}
+	return rel
+}
+
+func TestPaths(t *testing.T) {
+	ctx := context.Background()
+	root := testTree(t)
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{
+			name: "single level",
+			want: []string{"top.json"},
+		},
+		{
+			name: "recurse",
+			opts: Options{Recurse: true},
+			want: []string{
+				"build/a/sbom/a.spdx.json",
+				"build/a/sbom/notes.txt",
+				"build/b/sbom/b.cdx.json",
+				"build/b/testdata/fixture.json",
+				"top.json",
+			},
+		},
+		{
+			name: "include base name",
+			opts: Options{Recurse: true, Include: []string{"*.json"}, Exclude: []string{"testdata"}},
+			want: []string{"build/a/sbom/a.spdx.json", "build/b/sbom/b.cdx.json", "top.json"},
+		},
+		{
+			name: "include path",
+			opts: Options{Recurse: true, Include: []string{"build/*/sbom/*.json"}},
+			want: []string{"build/a/sbom/a.spdx.json", "build/b/sbom/b.cdx.json"},
+		},
+		{
+			name: "double star",
+			opts: Options{Recurse: true, Include: []string{"**/*.cdx.json", "**/fixture.json"}},
+			want: []string{"build/b/sbom/b.cdx.json", "build/b/testdata/fixture.json"},
+		},
+		{
+			name: "exclude directory path",
+			opts: Options{Recurse: true, Exclude: []string{"build/a", "*.txt"}},
+			want: []string{"build/b/sbom/b.cdx.json", "build/b/testdata/fixture.json", "top.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := Paths(ctx, root, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, relPaths(t, root, paths))
+		})
+	}
+}
+
+func TestPathsFile(t *testing.T) {
+	ctx := context.Background()
+	root := testTree(t)
+
+	file := filepath.Join(root, "build", "a", "sbom", "notes.txt")
+	paths, err := Paths(ctx, file, Options{Include: []string{"*.json"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{file}, paths, "explicit files are never filtered")
+
+	_, err = Paths(ctx, filepath.Join(root, "missing"), Options{})
+	assert.Error(t, err)
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob  string
+		path  string
+		match bool
+	}{
+		{"*.json", "a.json", true},
+		{"*.json", "a/b.json", false},
+		{"a/**/b.json", "a/b.json", true},
+		{"a/**/b.json", "a/x/y/b.json", true},
+		{"a/**", "a/x/y", true},
+		{"sbom-?.json", "sbom-1.json", true},
+		{"sbom-[!0-9].json", "sbom-1.json", false},
+		{"sbom-[ab].json", "sbom-b.json", true},
+		{"a.b", "axb", false},
+	}
+
+	for _, tt := range tests {
+		globs, err := compileGlobs([]string{tt.glob})
+		require.NoError(t, err)
+		assert.Equal(t, tt.match, globs[0].re.MatchString(tt.path), "%s ~ %s", tt.glob, tt.path)
+	}
+}
+
+func TestReadFileStdin(t *testing.T) {
+	defer func(r io.Reader) {
+		stdinReader = r
+		stdinOnce = sync.Once{}
+	}(stdinReader)
+
+	stdinOnce = sync.Once{}
+	stdinReader = strings.NewReader(`{"spdxVersion": "SPDX-2.3"}`)
+
+	paths, err := Paths(context.Background(), Stdin, Options{Recurse: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{Stdin}, paths)
+
+	for i := 0; i < 2; i++ {
+		data, err := ReadFile(Stdin)
+		require.NoError(t, err)
+		assert.Equal(t, `{"spdxVersion": "SPDX-2.3"}`, string(data), "stdin is buffered")
+	}
+
+	stdinOnce = sync.Once{}
+	stdinReader = strings.NewReader("")
+	_, err = ReadFile(Stdin)
+	assert.Error(t, err)
+}